@@ -19,6 +19,7 @@ package mockautoscaling
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"k8s.io/klog/v2"
@@ -41,6 +42,25 @@ func (m *MockAutoscaling) AttachLoadBalancers(ctx context.Context, request *auto
 	return &autoscaling.AttachLoadBalancersOutput{}, nil
 }
 
+func (m *MockAutoscaling) DetachLoadBalancers(ctx context.Context, request *autoscaling.DetachLoadBalancersInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DetachLoadBalancersOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DetachLoadBalancers: %v", request)
+
+	name := *request.AutoScalingGroupName
+
+	asg := m.Groups[name]
+	if asg == nil {
+		return nil, fmt.Errorf("Group %q not found", name)
+	}
+
+	asg.LoadBalancerNames = slices.DeleteFunc(asg.LoadBalancerNames, func(lb string) bool {
+		return slices.Contains(request.LoadBalancerNames, lb)
+	})
+	return &autoscaling.DetachLoadBalancersOutput{}, nil
+}
+
 func (m *MockAutoscaling) AttachLoadBalancerTargetGroups(ctx context.Context, request *autoscaling.AttachLoadBalancerTargetGroupsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.AttachLoadBalancerTargetGroupsOutput, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
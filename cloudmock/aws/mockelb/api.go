@@ -43,6 +43,7 @@ type loadBalancer struct {
 	description elbtypes.LoadBalancerDescription
 	attributes  elbtypes.LoadBalancerAttributes
 	tags        map[string]string
+	policies    map[string]elbtypes.PolicyDescription
 }
 
 func (m *MockELB) DescribeLoadBalancers(ctx context.Context, request *elb.DescribeLoadBalancersInput, optFns ...func(*elb.Options)) (*elb.DescribeLoadBalancersOutput, error) {
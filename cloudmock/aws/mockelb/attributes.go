@@ -19,9 +19,11 @@ package mockelb
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	"k8s.io/klog/v2"
 )
 
@@ -36,7 +38,28 @@ func (m *MockELB) ModifyLoadBalancerAttributes(ctx context.Context, request *elb
 		return nil, fmt.Errorf("LoadBalancer not found")
 	}
 
+	// Like ELB, keep the additional attributes that are not being set
+	additionalAttributes := slices.Clone(request.LoadBalancerAttributes.AdditionalAttributes)
+	for _, existing := range lb.attributes.AdditionalAttributes {
+		if !slices.ContainsFunc(additionalAttributes, func(a elbtypes.AdditionalAttribute) bool {
+			return aws.ToString(a.Key) == aws.ToString(existing.Key)
+		}) {
+			additionalAttributes = append(additionalAttributes, existing)
+		}
+	}
+	previousAccessLog := lb.attributes.AccessLog
 	lb.attributes = *request.LoadBalancerAttributes
+	lb.attributes.AdditionalAttributes = additionalAttributes
+
+	// Like ELB, keep reporting the destination of access logs once they are disabled
+	if accessLog := lb.attributes.AccessLog; accessLog != nil && !accessLog.Enabled && accessLog.S3BucketName == nil && previousAccessLog != nil {
+		lb.attributes.AccessLog = &elbtypes.AccessLog{
+			Enabled:        false,
+			EmitInterval:   previousAccessLog.EmitInterval,
+			S3BucketName:   previousAccessLog.S3BucketName,
+			S3BucketPrefix: previousAccessLog.S3BucketPrefix,
+		}
+	}
 
 	copy := lb.attributes
 
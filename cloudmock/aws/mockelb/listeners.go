@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockelb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"k8s.io/klog/v2"
+)
+
+func (m *MockELB) CreateLoadBalancerListeners(ctx context.Context, request *elb.CreateLoadBalancerListenersInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerListenersOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreateLoadBalancerListeners: %v", request)
+
+	lb := m.LoadBalancers[aws.ToString(request.LoadBalancerName)]
+	if lb == nil {
+		return nil, fmt.Errorf("LoadBalancer not found")
+	}
+
+	for _, listener := range request.Listeners {
+		for _, existing := range lb.description.ListenerDescriptions {
+			if existing.Listener != nil && existing.Listener.LoadBalancerPort == listener.LoadBalancerPort {
+				return nil, &elbtypes.DuplicateListenerException{Message: aws.String(fmt.Sprintf("listener on port %d already exists", listener.LoadBalancerPort))}
+			}
+		}
+		listener := listener
+		lb.description.ListenerDescriptions = append(lb.description.ListenerDescriptions, elbtypes.ListenerDescription{
+			Listener: &listener,
+		})
+	}
+
+	return &elb.CreateLoadBalancerListenersOutput{}, nil
+}
+
+func (m *MockELB) DeleteLoadBalancerListeners(ctx context.Context, request *elb.DeleteLoadBalancerListenersInput, optFns ...func(*elb.Options)) (*elb.DeleteLoadBalancerListenersOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteLoadBalancerListeners: %v", request)
+
+	lb := m.LoadBalancers[aws.ToString(request.LoadBalancerName)]
+	if lb == nil {
+		return nil, fmt.Errorf("LoadBalancer not found")
+	}
+
+	lb.description.ListenerDescriptions = slices.DeleteFunc(lb.description.ListenerDescriptions, func(l elbtypes.ListenerDescription) bool {
+		return l.Listener != nil && slices.Contains(request.LoadBalancerPorts, l.Listener.LoadBalancerPort)
+	})
+
+	return &elb.DeleteLoadBalancerListenersOutput{}, nil
+}
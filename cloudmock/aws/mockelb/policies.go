@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockelb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"k8s.io/klog/v2"
+)
+
+func (m *MockELB) CreateLoadBalancerPolicy(ctx context.Context, request *elb.CreateLoadBalancerPolicyInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerPolicyOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreateLoadBalancerPolicy: %v", request)
+
+	lb := m.LoadBalancers[aws.ToString(request.LoadBalancerName)]
+	if lb == nil {
+		return nil, fmt.Errorf("LoadBalancer not found")
+	}
+
+	policyName := aws.ToString(request.PolicyName)
+	if _, found := lb.policies[policyName]; found {
+		return nil, &elbtypes.DuplicatePolicyNameException{Message: aws.String(fmt.Sprintf("policy %q already exists", policyName))}
+	}
+
+	policy := elbtypes.PolicyDescription{
+		PolicyName:     request.PolicyName,
+		PolicyTypeName: request.PolicyTypeName,
+	}
+	for _, attribute := range request.PolicyAttributes {
+		policy.PolicyAttributeDescriptions = append(policy.PolicyAttributeDescriptions, elbtypes.PolicyAttributeDescription{
+			AttributeName:  attribute.AttributeName,
+			AttributeValue: attribute.AttributeValue,
+		})
+	}
+	if lb.policies == nil {
+		lb.policies = make(map[string]elbtypes.PolicyDescription)
+	}
+	lb.policies[policyName] = policy
+
+	return &elb.CreateLoadBalancerPolicyOutput{}, nil
+}
+
+func (m *MockELB) DescribeLoadBalancerPolicies(ctx context.Context, request *elb.DescribeLoadBalancerPoliciesInput, optFns ...func(*elb.Options)) (*elb.DescribeLoadBalancerPoliciesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.V(2).Infof("DescribeLoadBalancerPolicies: %v", request)
+
+	lb := m.LoadBalancers[aws.ToString(request.LoadBalancerName)]
+	if lb == nil {
+		return nil, fmt.Errorf("LoadBalancer not found")
+	}
+
+	response := &elb.DescribeLoadBalancerPoliciesOutput{}
+	for _, policyName := range request.PolicyNames {
+		policy, found := lb.policies[policyName]
+		if !found {
+			return nil, &elbtypes.PolicyNotFoundException{Message: aws.String(fmt.Sprintf("policy %q not found", policyName))}
+		}
+		response.PolicyDescriptions = append(response.PolicyDescriptions, policy)
+	}
+
+	return response, nil
+}
+
+func (m *MockELB) SetLoadBalancerPoliciesOfListener(ctx context.Context, request *elb.SetLoadBalancerPoliciesOfListenerInput, optFns ...func(*elb.Options)) (*elb.SetLoadBalancerPoliciesOfListenerOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("SetLoadBalancerPoliciesOfListener: %v", request)
+
+	lb := m.LoadBalancers[aws.ToString(request.LoadBalancerName)]
+	if lb == nil {
+		return nil, fmt.Errorf("LoadBalancer not found")
+	}
+
+	for _, policyName := range request.PolicyNames {
+		if _, found := lb.policies[policyName]; !found {
+			return nil, &elbtypes.PolicyNotFoundException{Message: aws.String(fmt.Sprintf("policy %q not found", policyName))}
+		}
+	}
+
+	for i := range lb.description.ListenerDescriptions {
+		listener := &lb.description.ListenerDescriptions[i]
+		if listener.Listener != nil && listener.Listener.LoadBalancerPort == request.LoadBalancerPort {
+			listener.PolicyNames = request.PolicyNames
+			return &elb.SetLoadBalancerPoliciesOfListenerOutput{}, nil
+		}
+	}
+	return nil, &elbtypes.ListenerNotFoundException{Message: aws.String(fmt.Sprintf("listener on port %d not found", request.LoadBalancerPort))}
+}
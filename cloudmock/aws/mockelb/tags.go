@@ -87,3 +87,22 @@ func (m *MockELB) AddTags(ctx context.Context, request *elb.AddTagsInput, optFns
 
 	return &elb.AddTagsOutput{}, nil
 }
+
+func (m *MockELB) RemoveTags(ctx context.Context, request *elb.RemoveTagsInput, optFns ...func(*elb.Options)) (*elb.RemoveTagsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("RemoveTags %v", request)
+
+	for _, name := range request.LoadBalancerNames {
+		elb := m.LoadBalancers[name]
+		if elb == nil {
+			return nil, fmt.Errorf("ELB %q not found", name)
+		}
+		for _, tag := range request.Tags {
+			delete(elb.tags, aws.ToString(tag.Key))
+		}
+	}
+
+	return &elb.RemoveTagsOutput{}, nil
+}
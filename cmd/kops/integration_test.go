@@ -72,6 +72,8 @@ type integrationTest struct {
 	// nth is true if we should check for files created by nth queue processor add on
 	nth          bool
 	nthRebalance bool
+	// terraformFileName is the name of the generated terraform configuration, if not kubernetes.tf
+	terraformFileName string
 }
 
 func newIntegrationTest(clusterName, srcDir string) *integrationTest {
@@ -131,6 +133,11 @@ func (i *integrationTest) withBastionUserData() *integrationTest {
 	return i
 }
 
+func (i *integrationTest) withTerraformJSON() *integrationTest {
+	i.terraformFileName = "kubernetes.tf.json"
+	return i
+}
+
 func (i *integrationTest) withCiliumEtcd() *integrationTest {
 	i.ciliumEtcd = true
 	return i
@@ -516,6 +523,40 @@ func TestCompress(t *testing.T) {
 		runTestTerraformAWS(t)
 }
 
+// TestAPILoadBalancerTerraform tests the terraform options of a Classic API load balancer
+func TestAPILoadBalancerTerraform(t *testing.T) {
+	newIntegrationTest("apielb.example.com", "api-elb-terraform").
+		withAddons(
+			awsEBSCSIAddon,
+			dnsControllerAddon,
+			awsCCMAddon,
+		).
+		runTestTerraformAWS(t)
+}
+
+// TestTerraformJSON tests writing the terraform configuration in JSON syntax
+func TestTerraformJSON(t *testing.T) {
+	newIntegrationTest("apielb.example.com", "terraform-json").
+		withAddons(
+			awsEBSCSIAddon,
+			dnsControllerAddon,
+			awsCCMAddon,
+		).
+		withTerraformJSON().
+		runTestTerraformAWS(t)
+}
+
+// TestAPILoadBalancerTerraformImport tests adopting an existing Classic API load balancer into the terraform state
+func TestAPILoadBalancerTerraformImport(t *testing.T) {
+	newIntegrationTest("apielb.example.com", "api-elb-terraform-import").
+		withAddons(
+			awsEBSCSIAddon,
+			dnsControllerAddon,
+			awsCCMAddon,
+		).
+		runTestTerraformAWS(t)
+}
+
 // TestExternalPolicies tests external policies output
 func TestExternalPolicies(t *testing.T) {
 	newIntegrationTest("externalpolicies.example.com", "externalpolicies").
@@ -1603,7 +1644,7 @@ func (i *integrationTest) runTestTerraformAWS(t *testing.T) {
 	}
 	expectedFilenames = append(expectedFilenames, i.expectServiceAccountRolePolicies...)
 
-	i.runTest(t, ctx, h, expectedFilenames, "", "", nil)
+	i.runTest(t, ctx, h, expectedFilenames, i.terraformFileName, i.terraformFileName, nil)
 }
 
 func (i *integrationTest) runTestPhase(t *testing.T, phase cloudup.Phase) {
@@ -143,6 +143,15 @@ func TestLifecycleExternalLB(t *testing.T) {
 	})
 }
 
+// TestLifecycleAPIELBReplace runs the test on a cluster whose Classic API load balancer is replaced
+// when changing its naming strategy changes its name
+func TestLifecycleAPIELBReplace(t *testing.T) {
+	runLifecycleTestAWS(&LifecycleTestOptions{
+		t:      t,
+		SrcDir: "api-elb-replace",
+	})
+}
+
 // TestLifecycleSharedSubnet runs the test on a shared subnet
 func TestLifecycleSharedSubnet(t *testing.T) {
 	runLifecycleTestAWS(&LifecycleTestOptions{
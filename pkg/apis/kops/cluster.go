@@ -509,12 +509,23 @@ const (
 	LoadBalancerClassNetwork LoadBalancerClass = "Network"
 )
 
+// LoadBalancerNamingStrategy describes how the name of a classic load balancer is generated
+type LoadBalancerNamingStrategy string
+
+const (
+	// LoadBalancerNamingStrategyHashSuffix appends a long, collision-resistant hash to the truncated name
+	LoadBalancerNamingStrategyHashSuffix LoadBalancerNamingStrategy = "HashSuffix"
+	// LoadBalancerNamingStrategyClusterPrefix puts the cluster name before the resource name
+	LoadBalancerNamingStrategyClusterPrefix LoadBalancerNamingStrategy = "ClusterPrefix"
+)
+
 type AccessLogSpec struct {
 	// Interval is the publishing interval in minutes. This parameter is only used with classic load balancer.
 	Interval int `json:"interval,omitempty"`
 	// Bucket is the S3 bucket name to store the logs in.
 	Bucket *string `json:"bucket,omitempty"`
 	// BucketPrefix is the S3 bucket prefix. Logs are stored in the root if not configured.
+	// Logs are partitioned by date below the prefix, which is used as-is.
 	BucketPrefix *string `json:"bucketPrefix,omitempty"`
 }
 
@@ -523,6 +534,11 @@ var SupportedLoadBalancerClasses = []LoadBalancerClass{
 	LoadBalancerClassNetwork,
 }
 
+var SupportedLoadBalancerNamingStrategies = []LoadBalancerNamingStrategy{
+	LoadBalancerNamingStrategyHashSuffix,
+	LoadBalancerNamingStrategyClusterPrefix,
+}
+
 // LoadBalancerSubnetSpec provides configuration for subnets used for a load balancer
 type LoadBalancerSubnetSpec struct {
 	// Name specifies the name of the cluster subnet
@@ -556,7 +572,63 @@ type LoadBalancerAccessSpec struct {
 	// Subnets allows you to specify the subnets that must be used for the load balancer
 	Subnets []LoadBalancerSubnetSpec `json:"subnets,omitempty"`
 	// AccessLog is the configuration of access logs.
+	// Access logs cover all the listeners of the load balancer; they cannot be enabled per listener.
 	AccessLog *AccessLogSpec `json:"accessLog,omitempty"`
+	// NamingStrategy controls how the name of a Classic load balancer is generated: HashSuffix, ClusterPrefix.
+	// The existing naming scheme is used if not set.
+	NamingStrategy LoadBalancerNamingStrategy `json:"namingStrategy,omitempty"`
+	// RetainUnmanagedTags prevents kOps from removing tags on a Classic load balancer that it did not set itself.
+	// Tags managed by kOps are still added and updated.
+	RetainUnmanagedTags bool `json:"retainUnmanagedTags,omitempty"`
+	// HealthCheck configures the health check of a Classic load balancer.
+	HealthCheck *LoadBalancerHealthCheckSpec `json:"healthCheck,omitempty"`
+	// ResolveAddresses adds the IP addresses that the DNS name of a Classic load balancer currently resolves to
+	// to the addresses of the API server, so that the API can be reached by IP before DNS records propagate.
+	// The addresses of a load balancer change over time, which changes the configuration of the instances.
+	ResolveAddresses bool `json:"resolveAddresses,omitempty"`
+	// WaitUntilReady makes kops update cluster wait, after applying a Classic load balancer, until its DNS name
+	// resolves and it accepts connections on the API port. The load balancer must be reachable from where kOps runs.
+	WaitUntilReady bool `json:"waitUntilReady,omitempty"`
+	// AllowReplaceOnNameChange replaces a Classic load balancer when the name kOps generates for it changes,
+	// for example after changing namingStrategy, as load balancers cannot be renamed. By default the existing
+	// load balancer is kept under its old name. The replacement has a new DNS name, which clients only pick up
+	// once their cached DNS records expire.
+	AllowReplaceOnNameChange bool `json:"allowReplaceOnNameChange,omitempty"`
+	// DesyncMitigationMode is how a Classic load balancer handles HTTP requests that could be used for
+	// HTTP desync attacks: monitor, defensive or strictest. When not set, the mode of the load balancer is left alone.
+	DesyncMitigationMode *string `json:"desyncMitigationMode,omitempty"`
+	// ListenerProtocol is the front-end protocol of the API listener of a Classic load balancer: TCP, SSL, HTTP or HTTPS.
+	// It defaults to SSL when sslCertificate is set and to TCP otherwise. SSL and HTTPS require sslCertificate.
+	ListenerProtocol *string `json:"listenerProtocol,omitempty"`
+	// InstanceProtocol is the protocol a Classic load balancer uses to reach the API servers: TCP or SSL behind a
+	// TCP or SSL listener, HTTP or HTTPS behind an HTTP or HTTPS listener. It defaults to the listener protocol.
+	InstanceProtocol *string `json:"instanceProtocol,omitempty"`
+}
+
+// LoadBalancerHealthCheckSpec configures the health check of a Classic load balancer.
+type LoadBalancerHealthCheckSpec struct {
+	// AutoTune lengthens the health check interval and thresholds as the number of nodes in the cluster grows,
+	// to limit the probe load on large clusters. Explicitly configured values are not changed.
+	AutoTune bool `json:"autoTune,omitempty"`
+	// IntervalSeconds is the approximate interval, in seconds, between health checks of an individual instance.
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+	// HealthyThreshold is the number of consecutive successful health checks required before an instance is considered healthy.
+	HealthyThreshold *int32 `json:"healthyThreshold,omitempty"`
+	// UnhealthyThreshold is the number of consecutive failed health checks required before an instance is considered unhealthy.
+	UnhealthyThreshold *int32 `json:"unhealthyThreshold,omitempty"`
+	// ReadinessEndpoint points the health check at the readiness endpoint of the kube-apiserver-healthcheck sidecar,
+	// which only reports ready when both the API server and its etcd backends are reachable, instead of the API server port.
+	ReadinessEndpoint bool `json:"readinessEndpoint,omitempty"`
+	// ReadinessEndpointPort is the port the readiness endpoint listens on.
+	// Default: 3990
+	ReadinessEndpointPort *int32 `json:"readinessEndpointPort,omitempty"`
+	// Protocol is the protocol of the health check target: TCP, SSL, HTTP or HTTPS.
+	// It defaults to the instance protocol of the primary listener, using HTTP or HTTPS when a path is set.
+	Protocol *string `json:"protocol,omitempty"`
+	// Port is the instance port checked by the health check. It defaults to the instance port of the primary listener.
+	Port *int32 `json:"port,omitempty"`
+	// Path is the path requested by HTTP and HTTPS health checks.
+	Path *string `json:"path,omitempty"`
 }
 
 // KubeDNSConfig defines the kube dns configuration
@@ -814,10 +886,85 @@ type TerraformSpec struct {
 	ProviderExtraConfig map[string]string `json:"providerExtraConfig,omitempty"`
 	// FilesProviderExtraConfig contains key/value pairs to add to the terraform provider block used for managed files
 	FilesProviderExtraConfig map[string]string `json:"filesProviderExtraConfig,omitempty"`
+	// APILoadBalancer configures how the API Classic load balancer is rendered
+	APILoadBalancer *TerraformAPILoadBalancerSpec `json:"apiLoadBalancer,omitempty"`
+	// Indentation configures the indentation of the generated terraform
+	Indentation *TerraformIndentationSpec `json:"indentation,omitempty"`
+	// Syntax is the syntax the terraform configuration is written in: HCL, to kubernetes.tf, or JSON, to kubernetes.tf.json.
+	// Default: HCL
+	Syntax TerraformSyntax `json:"syntax,omitempty"`
+	// AllowLocalExecProvisioners allows local-exec provisioners to be written to the generated terraform.
+	// Provisioners run arbitrary commands on the machine that runs terraform apply, with its credentials.
+	AllowLocalExecProvisioners bool `json:"allowLocalExecProvisioners,omitempty"`
 }
 
 func (t *TerraformSpec) IsEmpty() bool {
-	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0
+	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0 && t.APILoadBalancer == nil && t.Indentation == nil && t.Syntax == "" && !t.AllowLocalExecProvisioners
+}
+
+// TerraformSyntax is the syntax of the generated terraform configuration
+type TerraformSyntax string
+
+const (
+	// TerraformSyntaxHCL writes terraform's native syntax
+	TerraformSyntaxHCL TerraformSyntax = "HCL"
+	// TerraformSyntaxJSON writes terraform's JSON syntax, for tooling that generates or inspects the configuration
+	TerraformSyntaxJSON TerraformSyntax = "JSON"
+)
+
+var SupportedTerraformSyntaxes = []TerraformSyntax{
+	TerraformSyntaxHCL,
+	TerraformSyntaxJSON,
+}
+
+// TerraformIndentationSpec configures the indentation of nested blocks in the generated terraform.
+type TerraformIndentationSpec struct {
+	// UseTabs indents each nesting level with a tab instead of spaces.
+	UseTabs bool `json:"useTabs,omitempty"`
+	// Width is the number of spaces per nesting level when tabs are not used.
+	// Default: 2
+	Width int32 `json:"width,omitempty"`
+}
+
+// TerraformTimeoutsSpec overrides the timeouts of terraform operations on a resource.
+type TerraformTimeoutsSpec struct {
+	// Create is the timeout for creating the resource.
+	Create *metav1.Duration `json:"create,omitempty"`
+	// Delete is the timeout for deleting the resource.
+	Delete *metav1.Duration `json:"delete,omitempty"`
+}
+
+// TerraformAPILoadBalancerSpec configures how the API Classic load balancer is rendered to terraform.
+// It has no effect on other resources, or when the API uses a Network load balancer.
+type TerraformAPILoadBalancerSpec struct {
+	// ResourceNamePrefix is prepended to the terraform resource name of the API load balancer, e.g. "prod_".
+	// References to the load balancer use the prefixed name.
+	ResourceNamePrefix string `json:"resourceNamePrefix,omitempty"`
+	// CreateVariable is the name of a boolean terraform input variable, declared with a default of true,
+	// that controls whether the API load balancer is created. The API DNS records and the attachment of
+	// the control plane autoscaling groups to the load balancer follow the variable.
+	CreateVariable string `json:"createVariable,omitempty"`
+	// ImportName is the name of an existing Classic load balancer to adopt as the API load balancer,
+	// using a terraform import block, which requires terraform 1.5+. The load balancer keeps its name.
+	ImportName string `json:"importName,omitempty"`
+	// IdleTimeoutVariable renders the idle timeout of the API load balancer as a terraform input variable,
+	// declared with the configured timeout as its default, so that it can be overridden without regenerating.
+	IdleTimeoutVariable bool `json:"idleTimeoutVariable,omitempty"`
+	// TagsVariable is the name of a terraform input variable holding a map of tags, defaulting to an empty map,
+	// which is merged into the tags of the API load balancer.
+	TagsVariable string `json:"tagsVariable,omitempty"`
+	// Timeouts overrides the terraform create and delete timeouts of the API load balancer.
+	// The provider defaults apply to timeouts that are not set.
+	Timeouts *TerraformTimeoutsSpec `json:"timeouts,omitempty"`
+	// LocalExecCommand is run by a local-exec provisioner on the machine that runs terraform apply, once the API
+	// load balancer is created, e.g. to warm its DNS name, which the command can reference as ${self.dns_name}.
+	// It requires allowLocalExecProvisioners.
+	LocalExecCommand string `json:"localExecCommand,omitempty"`
+	// SSLCertificateDomain is the domain of the ACM certificate set in spec.api.loadBalancer.sslCertificate,
+	// when that certificate is managed outside kOps. The load balancer listener then references the certificate
+	// through an aws_acm_certificate data source, which looks up the most recent issued certificate for the domain,
+	// instead of embedding its ARN.
+	SSLCertificateDomain string `json:"sslCertificateDomain,omitempty"`
 }
 
 // FillDefaults populates default values.
@@ -1088,6 +1088,9 @@ type ClusterAutoscalerConfig struct {
 	// BalanceSimilarNodeGroups makes the cluster autoscaler treat similar node groups as one.
 	// Default: false
 	BalanceSimilarNodeGroups *bool `json:"balanceSimilarNodeGroups,omitempty"`
+	// BalancingLabels are node labels; when BalanceSimilarNodeGroups is set, node groups with the same values for these labels are balanced together, even if they are otherwise dissimilar.
+	// Default: none
+	BalancingLabels []string `json:"balancingLabels,omitempty"`
 	// AWSUseStaticInstanceList makes cluster autoscaler to use statically defined set of AWS EC2 Instance List.
 	// Default: false
 	AWSUseStaticInstanceList *bool `json:"awsUseStaticInstanceList,omitempty"`
@@ -1118,10 +1121,23 @@ type ClusterAutoscalerConfig struct {
 	// ScaleDownUnreadyTime determines the time an unready node should be unneeded before it is eligible for scale down
 	// Default: 20m0s
 	ScaleDownUnreadyTime *string `json:"scaleDownUnreadyTime,omitempty"`
+	// ScaleDownUnreadyEnabled makes the cluster autoscaler scale down unready nodes, such as nodes whose instances
+	// were removed from their node group outside of the autoscaler. Only applied from Kubernetes 1.27.
+	// Default: true
+	ScaleDownUnreadyEnabled *bool `json:"scaleDownUnreadyEnabled,omitempty"`
 	// CordonNodeBeforeTerminating should CA cordon nodes before terminating during downscale process
 	// Default: false
 	CordonNodeBeforeTerminating *bool `json:"cordonNodeBeforeTerminating,omitempty"`
-	// Image is the container image used.
+	// WriteStatusConfigMap makes the cluster autoscaler write its status to a ConfigMap.
+	// Default: true
+	WriteStatusConfigMap *bool `json:"writeStatusConfigMap,omitempty"`
+	// StatusConfigMapName is the name of the ConfigMap the cluster autoscaler writes its status to.
+	// Default: cluster-autoscaler-status
+	StatusConfigMapName *string `json:"statusConfigMapName,omitempty"`
+	// Namespace is the namespace the cluster autoscaler runs in. The status and priority expander ConfigMaps are created there.
+	// Default: kube-system
+	Namespace *string `json:"namespace,omitempty"`
+	// Image is the container image used. It may be pinned by digest.
 	// Default: the latest supported image for the specified kubernetes version.
 	Image *string `json:"image,omitempty"`
 	// MemoryRequest of cluster autoscaler container.
@@ -1130,6 +1146,27 @@ type ClusterAutoscalerConfig struct {
 	// CPURequest of cluster autoscaler container.
 	// Default: 100m
 	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
+	// LivenessProbe configures the liveness probe of the cluster autoscaler container.
+	// Default: timeoutSeconds 1, periodSeconds 10, failureThreshold 3
+	LivenessProbe *ClusterAutoscalerProbeSpec `json:"livenessProbe,omitempty"`
+	// ReadinessProbe configures a readiness probe on the cluster autoscaler container.
+	// Default: no readiness probe
+	ReadinessProbe *ClusterAutoscalerProbeSpec `json:"readinessProbe,omitempty"`
+	// TerminationGracePeriodSeconds is the time the cluster autoscaler pod is given to shut down cleanly.
+	// Default: 30 (the Kubernetes default)
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// ExternalNodeGroupBounds makes the cluster autoscaler use the minimum and maximum size set on the cloud node group,
+	// rather than those of the InstanceGroup, for InstanceGroups annotated with cluster-autoscaler.kops.k8s.io/external-bounds: "true".
+	// Those groups are found through auto-discovery tags instead of being listed explicitly. Only supported on AWS.
+	// Default: false
+	ExternalNodeGroupBounds *bool `json:"externalNodeGroupBounds,omitempty"`
+	// FeatureGates enables or disables alpha and beta cluster autoscaler features, passed with --feature-gates.
+	// Default: none
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// IgnoreVirtualNodes keeps the cluster autoscaler from being scheduled onto Fargate or virtual-kubelet nodes,
+	// which are not backed by a node group the autoscaler can manage.
+	// Default: false
+	IgnoreVirtualNodes *bool `json:"ignoreVirtualNodes,omitempty"`
 	// MaxNodeProvisionTime determines how long CAS will wait for a node to join the cluster.
 	MaxNodeProvisionTime string `json:"maxNodeProvisionTime,omitempty"`
 	// PodAnnotations are the annotations added to cluster autoscaler pods when they are created.
@@ -1143,6 +1180,19 @@ type ClusterAutoscalerConfig struct {
 	CustomPriorityExpanderConfig map[string][]string `json:"customPriorityExpanderConfig,omitempty"`
 }
 
+// ClusterAutoscalerProbeSpec configures a health-check probe on the cluster autoscaler container.
+type ClusterAutoscalerProbeSpec struct {
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	// Default: 1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// PeriodSeconds is how often, in seconds, to perform the probe.
+	// Default: 10
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failures after which the probe is considered failed.
+	// Default: 3
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
 // MetricsServerConfig determines the metrics server configuration.
 type MetricsServerConfig struct {
 	// Enabled enables the metrics server.
@@ -29,6 +29,11 @@ const (
 	// UpdatePolicyExternal is a value for ClusterSpec.UpdatePolicy and InstanceGroup.UpdatePolicy indicating that upgrades are done externally, and we should disable automatic upgrades
 	UpdatePolicyExternal = "external"
 
+	// AnnotationNameClusterAutoscalerExternalBounds is the InstanceGroup annotation that indicates the cluster autoscaler
+	// should discover the group and honor its externally-managed min/max bounds, instead of the kOps-managed ones.
+	// It only takes effect when the ClusterAutoscaler externalNodeGroupBounds option is enabled.
+	AnnotationNameClusterAutoscalerExternalBounds = "cluster-autoscaler.kops.k8s.io/external-bounds"
+
 	// DiscoveryLabelKey is the label we use for services that should be exposed internally.
 	// Endpoints get the same labels as their services.
 	DiscoveryLabelKey = "discovery.kops.k8s.io/internal-name"
@@ -274,6 +274,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterAutoscalerProbeSpec)(nil), (*kops.ClusterAutoscalerProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ClusterAutoscalerProbeSpec_To_kops_ClusterAutoscalerProbeSpec(a.(*ClusterAutoscalerProbeSpec), b.(*kops.ClusterAutoscalerProbeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterAutoscalerProbeSpec)(nil), (*ClusterAutoscalerProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterAutoscalerProbeSpec_To_v1alpha2_ClusterAutoscalerProbeSpec(a.(*kops.ClusterAutoscalerProbeSpec), b.(*ClusterAutoscalerProbeSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterList)(nil), (*kops.ClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ClusterList_To_kops_ClusterList(a.(*ClusterList), b.(*kops.ClusterList), scope)
 	}); err != nil {
@@ -754,6 +764,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerHealthCheckSpec)(nil), (*kops.LoadBalancerHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(a.(*LoadBalancerHealthCheckSpec), b.(*kops.LoadBalancerHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.LoadBalancerHealthCheckSpec)(nil), (*LoadBalancerHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(a.(*kops.LoadBalancerHealthCheckSpec), b.(*LoadBalancerHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadBalancerSpec)(nil), (*kops.LoadBalancerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LoadBalancerSpec_To_kops_LoadBalancerSpec(a.(*LoadBalancerSpec), b.(*kops.LoadBalancerSpec), scope)
 	}); err != nil {
@@ -1124,6 +1144,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TerraformAPILoadBalancerSpec)(nil), (*kops.TerraformAPILoadBalancerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TerraformAPILoadBalancerSpec_To_kops_TerraformAPILoadBalancerSpec(a.(*TerraformAPILoadBalancerSpec), b.(*kops.TerraformAPILoadBalancerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TerraformAPILoadBalancerSpec)(nil), (*TerraformAPILoadBalancerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TerraformAPILoadBalancerSpec_To_v1alpha2_TerraformAPILoadBalancerSpec(a.(*kops.TerraformAPILoadBalancerSpec), b.(*TerraformAPILoadBalancerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TerraformIndentationSpec)(nil), (*kops.TerraformIndentationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TerraformIndentationSpec_To_kops_TerraformIndentationSpec(a.(*TerraformIndentationSpec), b.(*kops.TerraformIndentationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TerraformIndentationSpec)(nil), (*TerraformIndentationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TerraformIndentationSpec_To_v1alpha2_TerraformIndentationSpec(a.(*kops.TerraformIndentationSpec), b.(*TerraformIndentationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TerraformSpec)(nil), (*kops.TerraformSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(a.(*TerraformSpec), b.(*kops.TerraformSpec), scope)
 	}); err != nil {
@@ -1134,6 +1174,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TerraformTimeoutsSpec)(nil), (*kops.TerraformTimeoutsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_TerraformTimeoutsSpec_To_kops_TerraformTimeoutsSpec(a.(*TerraformTimeoutsSpec), b.(*kops.TerraformTimeoutsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TerraformTimeoutsSpec)(nil), (*TerraformTimeoutsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TerraformTimeoutsSpec_To_v1alpha2_TerraformTimeoutsSpec(a.(*kops.TerraformTimeoutsSpec), b.(*TerraformTimeoutsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*UserData)(nil), (*kops.UserData)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_UserData_To_kops_UserData(a.(*UserData), b.(*kops.UserData), scope)
 	}); err != nil {
@@ -2311,6 +2361,7 @@ func autoConvert_v1alpha2_ClusterAutoscalerConfig_To_kops_ClusterAutoscalerConfi
 	out.Enabled = in.Enabled
 	out.Expander = in.Expander
 	out.BalanceSimilarNodeGroups = in.BalanceSimilarNodeGroups
+	out.BalancingLabels = in.BalancingLabels
 	out.AWSUseStaticInstanceList = in.AWSUseStaticInstanceList
 	out.IgnoreDaemonSetsUtilization = in.IgnoreDaemonSetsUtilization
 	out.ScaleDownUtilizationThreshold = in.ScaleDownUtilizationThreshold
@@ -2321,10 +2372,36 @@ func autoConvert_v1alpha2_ClusterAutoscalerConfig_To_kops_ClusterAutoscalerConfi
 	out.ScaleDownDelayAfterAdd = in.ScaleDownDelayAfterAdd
 	out.ScaleDownUnneededTime = in.ScaleDownUnneededTime
 	out.ScaleDownUnreadyTime = in.ScaleDownUnreadyTime
+	out.ScaleDownUnreadyEnabled = in.ScaleDownUnreadyEnabled
 	out.CordonNodeBeforeTerminating = in.CordonNodeBeforeTerminating
+	out.WriteStatusConfigMap = in.WriteStatusConfigMap
+	out.StatusConfigMapName = in.StatusConfigMapName
+	out.Namespace = in.Namespace
 	out.Image = in.Image
 	out.MemoryRequest = in.MemoryRequest
 	out.CPURequest = in.CPURequest
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(kops.ClusterAutoscalerProbeSpec)
+		if err := Convert_v1alpha2_ClusterAutoscalerProbeSpec_To_kops_ClusterAutoscalerProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(kops.ClusterAutoscalerProbeSpec)
+		if err := Convert_v1alpha2_ClusterAutoscalerProbeSpec_To_kops_ClusterAutoscalerProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	out.TerminationGracePeriodSeconds = in.TerminationGracePeriodSeconds
+	out.ExternalNodeGroupBounds = in.ExternalNodeGroupBounds
+	out.FeatureGates = in.FeatureGates
+	out.IgnoreVirtualNodes = in.IgnoreVirtualNodes
 	out.MaxNodeProvisionTime = in.MaxNodeProvisionTime
 	out.PodAnnotations = in.PodAnnotations
 	out.CreatePriorityExpenderConfig = in.CreatePriorityExpenderConfig
@@ -2341,6 +2418,7 @@ func autoConvert_kops_ClusterAutoscalerConfig_To_v1alpha2_ClusterAutoscalerConfi
 	out.Enabled = in.Enabled
 	out.Expander = in.Expander
 	out.BalanceSimilarNodeGroups = in.BalanceSimilarNodeGroups
+	out.BalancingLabels = in.BalancingLabels
 	out.AWSUseStaticInstanceList = in.AWSUseStaticInstanceList
 	out.IgnoreDaemonSetsUtilization = in.IgnoreDaemonSetsUtilization
 	out.ScaleDownUtilizationThreshold = in.ScaleDownUtilizationThreshold
@@ -2351,10 +2429,36 @@ func autoConvert_kops_ClusterAutoscalerConfig_To_v1alpha2_ClusterAutoscalerConfi
 	out.ScaleDownDelayAfterAdd = in.ScaleDownDelayAfterAdd
 	out.ScaleDownUnneededTime = in.ScaleDownUnneededTime
 	out.ScaleDownUnreadyTime = in.ScaleDownUnreadyTime
+	out.ScaleDownUnreadyEnabled = in.ScaleDownUnreadyEnabled
 	out.CordonNodeBeforeTerminating = in.CordonNodeBeforeTerminating
+	out.WriteStatusConfigMap = in.WriteStatusConfigMap
+	out.StatusConfigMapName = in.StatusConfigMapName
+	out.Namespace = in.Namespace
 	out.Image = in.Image
 	out.MemoryRequest = in.MemoryRequest
 	out.CPURequest = in.CPURequest
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ClusterAutoscalerProbeSpec)
+		if err := Convert_kops_ClusterAutoscalerProbeSpec_To_v1alpha2_ClusterAutoscalerProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ClusterAutoscalerProbeSpec)
+		if err := Convert_kops_ClusterAutoscalerProbeSpec_To_v1alpha2_ClusterAutoscalerProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	out.TerminationGracePeriodSeconds = in.TerminationGracePeriodSeconds
+	out.ExternalNodeGroupBounds = in.ExternalNodeGroupBounds
+	out.FeatureGates = in.FeatureGates
+	out.IgnoreVirtualNodes = in.IgnoreVirtualNodes
 	out.MaxNodeProvisionTime = in.MaxNodeProvisionTime
 	out.PodAnnotations = in.PodAnnotations
 	out.CreatePriorityExpenderConfig = in.CreatePriorityExpenderConfig
@@ -2367,6 +2471,30 @@ func Convert_kops_ClusterAutoscalerConfig_To_v1alpha2_ClusterAutoscalerConfig(in
 	return autoConvert_kops_ClusterAutoscalerConfig_To_v1alpha2_ClusterAutoscalerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_ClusterAutoscalerProbeSpec_To_kops_ClusterAutoscalerProbeSpec(in *ClusterAutoscalerProbeSpec, out *kops.ClusterAutoscalerProbeSpec, s conversion.Scope) error {
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_v1alpha2_ClusterAutoscalerProbeSpec_To_kops_ClusterAutoscalerProbeSpec is an autogenerated conversion function.
+func Convert_v1alpha2_ClusterAutoscalerProbeSpec_To_kops_ClusterAutoscalerProbeSpec(in *ClusterAutoscalerProbeSpec, out *kops.ClusterAutoscalerProbeSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ClusterAutoscalerProbeSpec_To_kops_ClusterAutoscalerProbeSpec(in, out, s)
+}
+
+func autoConvert_kops_ClusterAutoscalerProbeSpec_To_v1alpha2_ClusterAutoscalerProbeSpec(in *kops.ClusterAutoscalerProbeSpec, out *ClusterAutoscalerProbeSpec, s conversion.Scope) error {
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_kops_ClusterAutoscalerProbeSpec_To_v1alpha2_ClusterAutoscalerProbeSpec is an autogenerated conversion function.
+func Convert_kops_ClusterAutoscalerProbeSpec_To_v1alpha2_ClusterAutoscalerProbeSpec(in *kops.ClusterAutoscalerProbeSpec, out *ClusterAutoscalerProbeSpec, s conversion.Scope) error {
+	return autoConvert_kops_ClusterAutoscalerProbeSpec_To_v1alpha2_ClusterAutoscalerProbeSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_ClusterList_To_kops_ClusterList(in *ClusterList, out *kops.ClusterList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -5762,6 +5890,23 @@ func autoConvert_v1alpha2_LoadBalancerAccessSpec_To_kops_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.NamingStrategy = kops.LoadBalancerNamingStrategy(in.NamingStrategy)
+	out.RetainUnmanagedTags = in.RetainUnmanagedTags
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(kops.LoadBalancerHealthCheckSpec)
+		if err := Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
+	out.ResolveAddresses = in.ResolveAddresses
+	out.WaitUntilReady = in.WaitUntilReady
+	out.AllowReplaceOnNameChange = in.AllowReplaceOnNameChange
+	out.DesyncMitigationMode = in.DesyncMitigationMode
+	out.ListenerProtocol = in.ListenerProtocol
+	out.InstanceProtocol = in.InstanceProtocol
 	return nil
 }
 
@@ -5800,6 +5945,23 @@ func autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha2_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.NamingStrategy = LoadBalancerNamingStrategy(in.NamingStrategy)
+	out.RetainUnmanagedTags = in.RetainUnmanagedTags
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		if err := Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
+	out.ResolveAddresses = in.ResolveAddresses
+	out.WaitUntilReady = in.WaitUntilReady
+	out.AllowReplaceOnNameChange = in.AllowReplaceOnNameChange
+	out.DesyncMitigationMode = in.DesyncMitigationMode
+	out.ListenerProtocol = in.ListenerProtocol
+	out.InstanceProtocol = in.InstanceProtocol
 	return nil
 }
 
@@ -5836,6 +5998,42 @@ func Convert_kops_LoadBalancerControllerSpec_To_v1alpha2_LoadBalancerControllerS
 	return autoConvert_kops_LoadBalancerControllerSpec_To_v1alpha2_LoadBalancerControllerSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in *LoadBalancerHealthCheckSpec, out *kops.LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	out.AutoTune = in.AutoTune
+	out.IntervalSeconds = in.IntervalSeconds
+	out.HealthyThreshold = in.HealthyThreshold
+	out.UnhealthyThreshold = in.UnhealthyThreshold
+	out.ReadinessEndpoint = in.ReadinessEndpoint
+	out.ReadinessEndpointPort = in.ReadinessEndpointPort
+	out.Protocol = in.Protocol
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec is an autogenerated conversion function.
+func Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in *LoadBalancerHealthCheckSpec, out *kops.LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in, out, s)
+}
+
+func autoConvert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(in *kops.LoadBalancerHealthCheckSpec, out *LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	out.AutoTune = in.AutoTune
+	out.IntervalSeconds = in.IntervalSeconds
+	out.HealthyThreshold = in.HealthyThreshold
+	out.UnhealthyThreshold = in.UnhealthyThreshold
+	out.ReadinessEndpoint = in.ReadinessEndpoint
+	out.ReadinessEndpointPort = in.ReadinessEndpointPort
+	out.Protocol = in.Protocol
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec is an autogenerated conversion function.
+func Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(in *kops.LoadBalancerHealthCheckSpec, out *LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_LoadBalancerSpec_To_kops_LoadBalancerSpec(in *LoadBalancerSpec, out *kops.LoadBalancerSpec, s conversion.Scope) error {
 	out.LoadBalancerName = in.LoadBalancerName
 	out.TargetGroupARN = in.TargetGroupARN
@@ -7336,9 +7534,101 @@ func Convert_kops_TargetSpec_To_v1alpha2_TargetSpec(in *kops.TargetSpec, out *Ta
 	return autoConvert_kops_TargetSpec_To_v1alpha2_TargetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_TerraformAPILoadBalancerSpec_To_kops_TerraformAPILoadBalancerSpec(in *TerraformAPILoadBalancerSpec, out *kops.TerraformAPILoadBalancerSpec, s conversion.Scope) error {
+	out.ResourceNamePrefix = in.ResourceNamePrefix
+	out.CreateVariable = in.CreateVariable
+	out.ImportName = in.ImportName
+	out.IdleTimeoutVariable = in.IdleTimeoutVariable
+	out.TagsVariable = in.TagsVariable
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(kops.TerraformTimeoutsSpec)
+		if err := Convert_v1alpha2_TerraformTimeoutsSpec_To_kops_TerraformTimeoutsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Timeouts = nil
+	}
+	out.LocalExecCommand = in.LocalExecCommand
+	out.SSLCertificateDomain = in.SSLCertificateDomain
+	return nil
+}
+
+// Convert_v1alpha2_TerraformAPILoadBalancerSpec_To_kops_TerraformAPILoadBalancerSpec is an autogenerated conversion function.
+func Convert_v1alpha2_TerraformAPILoadBalancerSpec_To_kops_TerraformAPILoadBalancerSpec(in *TerraformAPILoadBalancerSpec, out *kops.TerraformAPILoadBalancerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TerraformAPILoadBalancerSpec_To_kops_TerraformAPILoadBalancerSpec(in, out, s)
+}
+
+func autoConvert_kops_TerraformAPILoadBalancerSpec_To_v1alpha2_TerraformAPILoadBalancerSpec(in *kops.TerraformAPILoadBalancerSpec, out *TerraformAPILoadBalancerSpec, s conversion.Scope) error {
+	out.ResourceNamePrefix = in.ResourceNamePrefix
+	out.CreateVariable = in.CreateVariable
+	out.ImportName = in.ImportName
+	out.IdleTimeoutVariable = in.IdleTimeoutVariable
+	out.TagsVariable = in.TagsVariable
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(TerraformTimeoutsSpec)
+		if err := Convert_kops_TerraformTimeoutsSpec_To_v1alpha2_TerraformTimeoutsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Timeouts = nil
+	}
+	out.LocalExecCommand = in.LocalExecCommand
+	out.SSLCertificateDomain = in.SSLCertificateDomain
+	return nil
+}
+
+// Convert_kops_TerraformAPILoadBalancerSpec_To_v1alpha2_TerraformAPILoadBalancerSpec is an autogenerated conversion function.
+func Convert_kops_TerraformAPILoadBalancerSpec_To_v1alpha2_TerraformAPILoadBalancerSpec(in *kops.TerraformAPILoadBalancerSpec, out *TerraformAPILoadBalancerSpec, s conversion.Scope) error {
+	return autoConvert_kops_TerraformAPILoadBalancerSpec_To_v1alpha2_TerraformAPILoadBalancerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_TerraformIndentationSpec_To_kops_TerraformIndentationSpec(in *TerraformIndentationSpec, out *kops.TerraformIndentationSpec, s conversion.Scope) error {
+	out.UseTabs = in.UseTabs
+	out.Width = in.Width
+	return nil
+}
+
+// Convert_v1alpha2_TerraformIndentationSpec_To_kops_TerraformIndentationSpec is an autogenerated conversion function.
+func Convert_v1alpha2_TerraformIndentationSpec_To_kops_TerraformIndentationSpec(in *TerraformIndentationSpec, out *kops.TerraformIndentationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TerraformIndentationSpec_To_kops_TerraformIndentationSpec(in, out, s)
+}
+
+func autoConvert_kops_TerraformIndentationSpec_To_v1alpha2_TerraformIndentationSpec(in *kops.TerraformIndentationSpec, out *TerraformIndentationSpec, s conversion.Scope) error {
+	out.UseTabs = in.UseTabs
+	out.Width = in.Width
+	return nil
+}
+
+// Convert_kops_TerraformIndentationSpec_To_v1alpha2_TerraformIndentationSpec is an autogenerated conversion function.
+func Convert_kops_TerraformIndentationSpec_To_v1alpha2_TerraformIndentationSpec(in *kops.TerraformIndentationSpec, out *TerraformIndentationSpec, s conversion.Scope) error {
+	return autoConvert_kops_TerraformIndentationSpec_To_v1alpha2_TerraformIndentationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out *kops.TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	if in.APILoadBalancer != nil {
+		in, out := &in.APILoadBalancer, &out.APILoadBalancer
+		*out = new(kops.TerraformAPILoadBalancerSpec)
+		if err := Convert_v1alpha2_TerraformAPILoadBalancerSpec_To_kops_TerraformAPILoadBalancerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APILoadBalancer = nil
+	}
+	if in.Indentation != nil {
+		in, out := &in.Indentation, &out.Indentation
+		*out = new(kops.TerraformIndentationSpec)
+		if err := Convert_v1alpha2_TerraformIndentationSpec_To_kops_TerraformIndentationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Indentation = nil
+	}
+	out.Syntax = kops.TerraformSyntax(in.Syntax)
+	out.AllowLocalExecProvisioners = in.AllowLocalExecProvisioners
 	return nil
 }
 
@@ -7350,6 +7640,26 @@ func Convert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out
 func autoConvert_kops_TerraformSpec_To_v1alpha2_TerraformSpec(in *kops.TerraformSpec, out *TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	if in.APILoadBalancer != nil {
+		in, out := &in.APILoadBalancer, &out.APILoadBalancer
+		*out = new(TerraformAPILoadBalancerSpec)
+		if err := Convert_kops_TerraformAPILoadBalancerSpec_To_v1alpha2_TerraformAPILoadBalancerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APILoadBalancer = nil
+	}
+	if in.Indentation != nil {
+		in, out := &in.Indentation, &out.Indentation
+		*out = new(TerraformIndentationSpec)
+		if err := Convert_kops_TerraformIndentationSpec_To_v1alpha2_TerraformIndentationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Indentation = nil
+	}
+	out.Syntax = TerraformSyntax(in.Syntax)
+	out.AllowLocalExecProvisioners = in.AllowLocalExecProvisioners
 	return nil
 }
 
@@ -7358,6 +7668,28 @@ func Convert_kops_TerraformSpec_To_v1alpha2_TerraformSpec(in *kops.TerraformSpec
 	return autoConvert_kops_TerraformSpec_To_v1alpha2_TerraformSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_TerraformTimeoutsSpec_To_kops_TerraformTimeoutsSpec(in *TerraformTimeoutsSpec, out *kops.TerraformTimeoutsSpec, s conversion.Scope) error {
+	out.Create = in.Create
+	out.Delete = in.Delete
+	return nil
+}
+
+// Convert_v1alpha2_TerraformTimeoutsSpec_To_kops_TerraformTimeoutsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_TerraformTimeoutsSpec_To_kops_TerraformTimeoutsSpec(in *TerraformTimeoutsSpec, out *kops.TerraformTimeoutsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_TerraformTimeoutsSpec_To_kops_TerraformTimeoutsSpec(in, out, s)
+}
+
+func autoConvert_kops_TerraformTimeoutsSpec_To_v1alpha2_TerraformTimeoutsSpec(in *kops.TerraformTimeoutsSpec, out *TerraformTimeoutsSpec, s conversion.Scope) error {
+	out.Create = in.Create
+	out.Delete = in.Delete
+	return nil
+}
+
+// Convert_kops_TerraformTimeoutsSpec_To_v1alpha2_TerraformTimeoutsSpec is an autogenerated conversion function.
+func Convert_kops_TerraformTimeoutsSpec_To_v1alpha2_TerraformTimeoutsSpec(in *kops.TerraformTimeoutsSpec, out *TerraformTimeoutsSpec, s conversion.Scope) error {
+	return autoConvert_kops_TerraformTimeoutsSpec_To_v1alpha2_TerraformTimeoutsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_TopologySpec_To_kops_TopologySpec(in *TopologySpec, out *kops.TopologySpec, s conversion.Scope) error {
 	// INFO: in.Masters opted out of conversion generation
 	// INFO: in.Nodes opted out of conversion generation
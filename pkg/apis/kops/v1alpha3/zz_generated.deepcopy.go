@@ -897,6 +897,11 @@ func (in *ClusterAutoscalerConfig) DeepCopyInto(out *ClusterAutoscalerConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BalancingLabels != nil {
+		in, out := &in.BalancingLabels, &out.BalancingLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AWSUseStaticInstanceList != nil {
 		in, out := &in.AWSUseStaticInstanceList, &out.AWSUseStaticInstanceList
 		*out = new(bool)
@@ -947,11 +952,31 @@ func (in *ClusterAutoscalerConfig) DeepCopyInto(out *ClusterAutoscalerConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ScaleDownUnreadyEnabled != nil {
+		in, out := &in.ScaleDownUnreadyEnabled, &out.ScaleDownUnreadyEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.CordonNodeBeforeTerminating != nil {
 		in, out := &in.CordonNodeBeforeTerminating, &out.CordonNodeBeforeTerminating
 		*out = new(bool)
 		**out = **in
 	}
+	if in.WriteStatusConfigMap != nil {
+		in, out := &in.WriteStatusConfigMap, &out.WriteStatusConfigMap
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StatusConfigMapName != nil {
+		in, out := &in.StatusConfigMapName, &out.StatusConfigMapName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(string)
@@ -967,6 +992,38 @@ func (in *ClusterAutoscalerConfig) DeepCopyInto(out *ClusterAutoscalerConfig) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ClusterAutoscalerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ClusterAutoscalerProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExternalNodeGroupBounds != nil {
+		in, out := &in.ExternalNodeGroupBounds, &out.ExternalNodeGroupBounds
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IgnoreVirtualNodes != nil {
+		in, out := &in.IgnoreVirtualNodes, &out.IgnoreVirtualNodes
+		*out = new(bool)
+		**out = **in
+	}
 	if in.PodAnnotations != nil {
 		in, out := &in.PodAnnotations, &out.PodAnnotations
 		*out = make(map[string]string, len(*in))
@@ -1007,6 +1064,37 @@ func (in *ClusterAutoscalerConfig) DeepCopy() *ClusterAutoscalerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerProbeSpec) DeepCopyInto(out *ClusterAutoscalerProbeSpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerProbeSpec.
+func (in *ClusterAutoscalerProbeSpec) DeepCopy() *ClusterAutoscalerProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalerProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -4341,6 +4429,26 @@ func (in *LoadBalancerAccessSpec) DeepCopyInto(out *LoadBalancerAccessSpec) {
 		*out = new(AccessLogSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DesyncMitigationMode != nil {
+		in, out := &in.DesyncMitigationMode, &out.DesyncMitigationMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ListenerProtocol != nil {
+		in, out := &in.ListenerProtocol, &out.ListenerProtocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceProtocol != nil {
+		in, out := &in.InstanceProtocol, &out.InstanceProtocol
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -4380,6 +4488,57 @@ func (in *LoadBalancerControllerSpec) DeepCopy() *LoadBalancerControllerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerHealthCheckSpec) DeepCopyInto(out *LoadBalancerHealthCheckSpec) {
+	*out = *in
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadinessEndpointPort != nil {
+		in, out := &in.ReadinessEndpointPort, &out.ReadinessEndpointPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheckSpec.
+func (in *LoadBalancerHealthCheckSpec) DeepCopy() *LoadBalancerHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 	*out = *in
@@ -5658,6 +5817,43 @@ func (in *TargetSpec) DeepCopy() *TargetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformAPILoadBalancerSpec) DeepCopyInto(out *TerraformAPILoadBalancerSpec) {
+	*out = *in
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(TerraformTimeoutsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformAPILoadBalancerSpec.
+func (in *TerraformAPILoadBalancerSpec) DeepCopy() *TerraformAPILoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformAPILoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformIndentationSpec) DeepCopyInto(out *TerraformIndentationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformIndentationSpec.
+func (in *TerraformIndentationSpec) DeepCopy() *TerraformIndentationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformIndentationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 	*out = *in
@@ -5675,6 +5871,16 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.APILoadBalancer != nil {
+		in, out := &in.APILoadBalancer, &out.APILoadBalancer
+		*out = new(TerraformAPILoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Indentation != nil {
+		in, out := &in.Indentation, &out.Indentation
+		*out = new(TerraformIndentationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5688,6 +5894,32 @@ func (in *TerraformSpec) DeepCopy() *TerraformSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformTimeoutsSpec) DeepCopyInto(out *TerraformTimeoutsSpec) {
+	*out = *in
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformTimeoutsSpec.
+func (in *TerraformTimeoutsSpec) DeepCopy() *TerraformTimeoutsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformTimeoutsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
 	*out = *in
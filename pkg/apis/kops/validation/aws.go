@@ -19,6 +19,7 @@ package validation
 import (
 	"fmt"
 	"net"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -28,7 +29,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/featureflag"
+	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
@@ -42,6 +46,9 @@ func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
 		if strict || lbSpec.Class != "" {
 			allErrs = append(allErrs, IsValidValue(lbPath.Child("class"), &lbSpec.Class, kops.SupportedLoadBalancerClasses)...)
 		}
+		if lbSpec.NamingStrategy != "" {
+			allErrs = append(allErrs, IsValidValue(lbPath.Child("namingStrategy"), &lbSpec.NamingStrategy, kops.SupportedLoadBalancerNamingStrategies)...)
+		}
 		allErrs = append(allErrs, awsValidateTopologyDNS(lbPath.Child("type"), c)...)
 		allErrs = append(allErrs, awsValidateSecurityGroupOverride(lbPath.Child("securityGroupOverride"), lbSpec)...)
 		allErrs = append(allErrs, awsValidateAdditionalSecurityGroups(lbPath.Child("additionalSecurityGroups"), lbSpec.AdditionalSecurityGroups)...)
@@ -49,10 +56,50 @@ func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
 			allErrs = append(allErrs, field.Forbidden(lbPath.Child("useForInternalAPI"), "useForInternalAPI cannot be used with internal NLB due lack of hairpinning support"))
 		}
 		if lbSpec.SSLCertificate != "" && lbSpec.Class != kops.LoadBalancerClassNetwork {
-			allErrs = append(allErrs, field.Forbidden(lbPath.Child("sslCertificate"), "sslCertificate requires a network load balancer. See https://github.com/kubernetes/kops/blob/master/permalinks/acm_nlb.md"))
+			// A Classic load balancer terminates TLS itself, so client certificates don't reach kube-apiserver.
+			// Clusters that only use token or --internal credentials can still use one, e.g. to set sslPolicy.
+			klog.Warningf("spec.api.loadBalancer.sslCertificate on a Classic load balancer breaks client certificate authentication through it. See https://github.com/kubernetes/kops/blob/master/permalinks/acm_nlb.md")
+		}
+		if lbSpec.IdleTimeoutSeconds != nil && (*lbSpec.IdleTimeoutSeconds < 1 || *lbSpec.IdleTimeoutSeconds > 4000) {
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("idleTimeoutSeconds"), *lbSpec.IdleTimeoutSeconds, "must be between 1 and 4000"))
 		}
 		allErrs = append(allErrs, awsValidateSSLPolicy(lbPath.Child("sslPolicy"), lbSpec)...)
+		allErrs = append(allErrs, awsValidateListenerProtocols(lbPath, lbSpec)...)
 		allErrs = append(allErrs, awsValidateLoadBalancerSubnets(lbPath.Child("subnets"), c.Spec)...)
+		if lbSpec.AccessLog != nil {
+			allErrs = append(allErrs, awsValidateAccessLog(lbPath.Child("accessLog"), lbSpec.AccessLog)...)
+		}
+		if lbSpec.HealthCheck != nil {
+			allErrs = append(allErrs, awsValidateLoadBalancerHealthCheck(lbPath.Child("healthCheck"), lbSpec)...)
+		}
+		if lbSpec.ResolveAddresses && lbSpec.Class != kops.LoadBalancerClassClassic {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("resolveAddresses"), "resolveAddresses is only supported for Classic load balancers"))
+		}
+		if lbSpec.WaitUntilReady && lbSpec.Class != kops.LoadBalancerClassClassic {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("waitUntilReady"), "waitUntilReady is only supported for Classic load balancers"))
+		}
+		if lbSpec.AllowReplaceOnNameChange && lbSpec.Class != kops.LoadBalancerClassClassic {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("allowReplaceOnNameChange"), "allowReplaceOnNameChange is only supported for Classic load balancers"))
+		}
+		if lbSpec.DesyncMitigationMode != nil {
+			if lbSpec.Class != kops.LoadBalancerClassClassic {
+				allErrs = append(allErrs, field.Forbidden(lbPath.Child("desyncMitigationMode"), "desyncMitigationMode is only supported for Classic load balancers"))
+			} else {
+				allErrs = append(allErrs, IsValidValue(lbPath.Child("desyncMitigationMode"), lbSpec.DesyncMitigationMode, []string{"monitor", "defensive", "strictest"})...)
+			}
+		}
+		if strict {
+			allErrs = append(allErrs, awsValidateAPILoadBalancerAccess(field.NewPath("spec", "api", "access"), c)...)
+		}
+	}
+
+	if target := c.Spec.Target; target != nil && target.Terraform != nil && target.Terraform.APILoadBalancer != nil && target.Terraform.APILoadBalancer.SSLCertificateDomain != "" {
+		fieldPath := field.NewPath("spec", "target", "terraform", "apiLoadBalancer", "sslCertificateDomain")
+		if c.Spec.API.LoadBalancer == nil || c.Spec.API.LoadBalancer.SSLCertificate == "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "sslCertificateDomain requires spec.api.loadBalancer.sslCertificate"))
+		} else if parsedARN, err := arn.Parse(c.Spec.API.LoadBalancer.SSLCertificate); err != nil || parsedARN.Service != "acm" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "sslCertificateDomain requires spec.api.loadBalancer.sslCertificate to be an ACM certificate"))
+		}
 	}
 
 	allErrs = append(allErrs, awsValidateEBSCSIDriver(c)...)
@@ -64,6 +111,81 @@ func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
 	return allErrs
 }
 
+// awsValidateLoadBalancerHealthCheck validates the health check settings against the limits of Classic load balancers.
+// The health check timeout is 5 seconds, and the interval must be longer than it.
+func awsValidateLoadBalancerHealthCheck(fieldPath *field.Path, lbSpec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if lbSpec.Class != kops.LoadBalancerClassClassic {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "healthCheck is only supported for Classic load balancers"))
+		return allErrs
+	}
+
+	spec := lbSpec.HealthCheck
+	if spec.IntervalSeconds != nil && (*spec.IntervalSeconds < 6 || *spec.IntervalSeconds > 300) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("intervalSeconds"), *spec.IntervalSeconds, "must be between 6 and 300"))
+	}
+	if spec.HealthyThreshold != nil && (*spec.HealthyThreshold < 2 || *spec.HealthyThreshold > 10) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("healthyThreshold"), *spec.HealthyThreshold, "must be between 2 and 10"))
+	}
+	if spec.UnhealthyThreshold != nil && (*spec.UnhealthyThreshold < 2 || *spec.UnhealthyThreshold > 10) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("unhealthyThreshold"), *spec.UnhealthyThreshold, "must be between 2 and 10"))
+	}
+	if spec.ReadinessEndpointPort != nil {
+		port := *spec.ReadinessEndpointPort
+		if !spec.ReadinessEndpoint {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("readinessEndpointPort"), "readinessEndpointPort requires readinessEndpoint to be enabled"))
+		} else if port < 1 || port > 65535 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("readinessEndpointPort"), port, "must be a valid port number"))
+		} else if port == 443 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("readinessEndpointPort"), port, "must not be the API server port"))
+		}
+	}
+	if spec.ReadinessEndpoint && (spec.Protocol != nil || spec.Port != nil || spec.Path != nil) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "protocol, port and path cannot be set together with readinessEndpoint"))
+	}
+	if spec.Protocol != nil {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("protocol"), spec.Protocol, []string{"TCP", "SSL", "HTTP", "HTTPS"})...)
+	}
+	if spec.Port != nil && (*spec.Port < 1 || *spec.Port > 65535) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("port"), *spec.Port, "must be a valid port number"))
+	}
+
+	return allErrs
+}
+
+// s3BucketNameRegex matches the S3 bucket naming rules that apply in every region.
+var s3BucketNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// s3LegacyBucketNameRegex matches the legacy naming rules of buckets created in us-east-1 before March 1, 2018,
+// which also allowed uppercase letters and underscores, and names of up to 255 characters.
+var s3LegacyBucketNameRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,255}$`)
+
+// awsValidateAccessLog validates the access log destination.
+// The bucket may be owned by a different account or live in a different region than the cluster,
+// so only the name itself is checked; whether the bucket policy grants the ELB log delivery
+// principal access is only known once the load balancer attributes are applied.
+func awsValidateAccessLog(fieldPath *field.Path, spec *kops.AccessLogSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	bucket := fi.ValueOf(spec.Bucket)
+	if bucket == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("bucket"), "bucket must be specified when access logging is enabled"))
+	} else if !s3LegacyBucketNameRegex.MatchString(bucket) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("bucket"), bucket, "must be a valid S3 bucket name"))
+	} else if !s3BucketNameRegex.MatchString(bucket) || strings.Contains(bucket, "..") || net.ParseIP(bucket) != nil {
+		klog.Warningf("%s %q only follows the legacy S3 bucket naming rules, which only buckets created in us-east-1 before March 1, 2018 can use", fieldPath.Child("bucket"), bucket)
+	}
+
+	// The prefix is applied as-is: ELB already writes logs under
+	// <prefix>/AWSLogs/<account>/elasticloadbalancing/<region>/<yyyy>/<mm>/<dd>/.
+	if prefix := fi.ValueOf(spec.BucketPrefix); strings.Contains(prefix, "{{") {
+		klog.Warningf("%s %q is used as-is, template tokens are not expanded; access logs are already partitioned by year, month and day under the prefix", fieldPath.Child("bucketPrefix"), prefix)
+	}
+
+	return allErrs
+}
+
 func awsValidateEBSCSIDriver(cluster *kops.Cluster) (allErrs field.ErrorList) {
 	c := cluster.Spec
 
@@ -101,6 +223,32 @@ func awsValidateInstanceGroup(ig *kops.InstanceGroup, cloud awsup.AWSCloud) fiel
 		allErrs = append(allErrs, awsValidateMaximumInstanceLifetime(field.NewPath(ig.GetName(), "spec"), ig.Spec.MaxInstanceLifetime)...)
 	}
 
+	allErrs = append(allErrs, awsValidateNodeTemplateTags(field.NewPath("spec"), ig)...)
+
+	return allErrs
+}
+
+// awsMaxTagKeyLength is the maximum length of an EC2 tag key.
+const awsMaxTagKeyLength = 128
+
+// awsValidateNodeTemplateTags checks that node labels and taints can be represented as cluster-autoscaler node-template tags.
+func awsValidateNodeTemplateTags(fieldPath *field.Path, ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for key := range ig.Spec.NodeLabels {
+		if len(nodeidentityaws.ClusterAutoscalerNodeTemplateLabel+key) > awsMaxTagKeyLength {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("nodeLabels"), key, fmt.Sprintf("label key must be at most %d characters to be copied to the cluster-autoscaler node-template tags", awsMaxTagKeyLength-len(nodeidentityaws.ClusterAutoscalerNodeTemplateLabel))))
+		}
+	}
+
+	for i, taint := range ig.Spec.Taints {
+		key, _, _ := strings.Cut(taint, "=")
+		key, _, _ = strings.Cut(key, ":")
+		if len(nodeidentityaws.ClusterAutoscalerNodeTemplateTaint+key) > awsMaxTagKeyLength {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("taints").Index(i), taint, fmt.Sprintf("taint key must be at most %d characters to be copied to the cluster-autoscaler node-template tags", awsMaxTagKeyLength-len(nodeidentityaws.ClusterAutoscalerNodeTemplateTaint))))
+		}
+	}
+
 	return allErrs
 }
 
@@ -302,13 +450,34 @@ func awsValidateTopologyDNS(fieldPath *field.Path, c *kops.Cluster) field.ErrorL
 	return allErrs
 }
 
+// awsValidateAPILoadBalancerAccess flags public API load balancers whose security group allows the whole internet.
+// This is a warning unless the StrictAPILoadBalancerAccess feature flag is enabled.
+func awsValidateAPILoadBalancerAccess(fieldPath *field.Path, c *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.Spec.API.LoadBalancer == nil || c.Spec.API.LoadBalancer.Type != kops.LoadBalancerTypePublic {
+		return allErrs
+	}
+
+	for i, cidr := range c.Spec.API.Access {
+		if cidr != "0.0.0.0/0" && cidr != "::/0" {
+			continue
+		}
+		detail := fmt.Sprintf("public API load balancer allows access to port 443 from %s; restrict spec.api.access to the CIDRs that need access", cidr)
+		if featureflag.StrictAPILoadBalancerAccess.Enabled() {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i), detail))
+		} else {
+			klog.Warningf("%s", detail)
+		}
+	}
+
+	return allErrs
+}
+
 func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if spec.SSLPolicy != nil {
-		if spec.Class != kops.LoadBalancerClassNetwork {
-			allErrs = append(allErrs, field.Forbidden(fieldPath, "sslPolicy should be specified with Network Load Balancer"))
-		}
 		if spec.SSLCertificate == "" {
 			allErrs = append(allErrs, field.Forbidden(fieldPath, "sslPolicy should not be specified without SSLCertificate"))
 		}
@@ -317,6 +486,50 @@ func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSp
 	return allErrs
 }
 
+func awsValidateListenerProtocols(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ListenerProtocol == nil && spec.InstanceProtocol == nil {
+		return allErrs
+	}
+	if spec.Class != kops.LoadBalancerClassClassic {
+		if spec.ListenerProtocol != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("listenerProtocol"), "listenerProtocol is only supported for Classic load balancers"))
+		}
+		if spec.InstanceProtocol != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("instanceProtocol"), "instanceProtocol is only supported for Classic load balancers"))
+		}
+		return allErrs
+	}
+
+	protocol := "TCP"
+	if spec.SSLCertificate != "" {
+		protocol = "SSL"
+	}
+	if spec.ListenerProtocol != nil {
+		protocol = *spec.ListenerProtocol
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("listenerProtocol"), spec.ListenerProtocol, []string{"TCP", "SSL", "HTTP", "HTTPS"})...)
+		secure := protocol == "SSL" || protocol == "HTTPS"
+		if secure && spec.SSLCertificate == "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("listenerProtocol"), fmt.Sprintf("listenerProtocol %s requires sslCertificate", protocol)))
+		}
+		if !secure && spec.SSLCertificate != "" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("listenerProtocol"), fmt.Sprintf("listenerProtocol %s cannot be used with sslCertificate; use SSL or HTTPS", protocol)))
+		}
+	}
+
+	if spec.InstanceProtocol != nil {
+		switch protocol {
+		case "TCP", "SSL":
+			allErrs = append(allErrs, IsValidValue(fieldPath.Child("instanceProtocol"), spec.InstanceProtocol, []string{"TCP", "SSL"})...)
+		case "HTTP", "HTTPS":
+			allErrs = append(allErrs, IsValidValue(fieldPath.Child("instanceProtocol"), spec.InstanceProtocol, []string{"HTTP", "HTTPS"})...)
+		}
+	}
+
+	return allErrs
+}
+
 func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/featureflag"
 )
 
 func TestAWSValidateEBSCSIDriver(t *testing.T) {
@@ -886,3 +888,537 @@ func TestAWSAdditionalRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSValidateAPILoadBalancerAccess(t *testing.T) {
+	grid := []struct {
+		Type           kops.LoadBalancerType
+		Access         []string
+		Strict         bool
+		ExpectedErrors []string
+	}{
+		{
+			Type:   kops.LoadBalancerTypePublic,
+			Access: []string{"0.0.0.0/0", "::/0"},
+		},
+		{
+			Type:           kops.LoadBalancerTypePublic,
+			Access:         []string{"10.0.0.0/8", "0.0.0.0/0"},
+			Strict:         true,
+			ExpectedErrors: []string{"Forbidden::spec.api.access[1]"},
+		},
+		{
+			Type:   kops.LoadBalancerTypePublic,
+			Access: []string{"10.0.0.0/8"},
+			Strict: true,
+		},
+		{
+			Type:   kops.LoadBalancerTypeInternal,
+			Access: []string{"0.0.0.0/0"},
+			Strict: true,
+		},
+	}
+	for _, g := range grid {
+		if g.Strict {
+			featureflag.ParseFlags("+StrictAPILoadBalancerAccess")
+		}
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{Type: g.Type},
+					Access:       g.Access,
+				},
+			},
+		}
+		errs := awsValidateAPILoadBalancerAccess(field.NewPath("spec", "api", "access"), cluster)
+		if g.Strict {
+			featureflag.ParseFlags("-StrictAPILoadBalancerAccess")
+		}
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateAccessLog(t *testing.T) {
+	grid := []struct {
+		Bucket         *string
+		BucketPrefix   *string
+		ExpectedErrors []string
+	}{
+		{
+			Bucket: fi.PtrTo("access-log-example"),
+		},
+		{
+			Bucket: fi.PtrTo("central-logging-123456789012"),
+		},
+		{
+			Bucket:         nil,
+			ExpectedErrors: []string{"Required value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{
+			Bucket:         fi.PtrTo("arn:aws:s3:::central-logging"),
+			ExpectedErrors: []string{"Invalid value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{
+			// Legacy us-east-1 bucket name.
+			Bucket: fi.PtrTo("Central_Logging"),
+		},
+		{
+			Bucket:         fi.PtrTo("central logging"),
+			ExpectedErrors: []string{"Invalid value::spec.api.loadBalancer.accessLog.bucket"},
+		},
+		{
+			Bucket:       fi.PtrTo("access-log-example"),
+			BucketPrefix: fi.PtrTo("clusters/example"),
+		},
+		{
+			Bucket:       fi.PtrTo("access-log-example"),
+			BucketPrefix: fi.PtrTo("clusters/example/{{Year}}/{{Month}}"),
+		},
+	}
+	for _, g := range grid {
+		errs := awsValidateAccessLog(field.NewPath("spec", "api", "loadBalancer", "accessLog"), &kops.AccessLogSpec{Bucket: g.Bucket, BucketPrefix: g.BucketPrefix})
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateNodeTemplateTags(t *testing.T) {
+	longKey := "example.com/" + strings.Repeat("a", 80)
+	grid := []struct {
+		NodeLabels     map[string]string
+		Taints         []string
+		ExpectedErrors []string
+	}{
+		{
+			NodeLabels: map[string]string{"example.com/gpu": "true"},
+			Taints:     []string{"dedicated=gpu:NoSchedule", "nvidia.com/gpu:NoExecute"},
+		},
+		{
+			NodeLabels:     map[string]string{longKey: "true"},
+			ExpectedErrors: []string{"Invalid value::spec.nodeLabels"},
+		},
+		{
+			Taints:         []string{"dedicated=gpu:NoSchedule", longKey + ":NoSchedule"},
+			ExpectedErrors: []string{"Invalid value::spec.taints[1]"},
+		},
+	}
+	for _, g := range grid {
+		ig := &kops.InstanceGroup{
+			Spec: kops.InstanceGroupSpec{
+				NodeLabels: g.NodeLabels,
+				Taints:     g.Taints,
+			},
+		}
+		errs := awsValidateNodeTemplateTags(field.NewPath("spec"), ig)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerHealthCheck(t *testing.T) {
+	grid := []struct {
+		Class          kops.LoadBalancerClass
+		HealthCheck    *kops.LoadBalancerHealthCheckSpec
+		ExpectedErrors []string
+	}{
+		{
+			Class:       kops.LoadBalancerClassClassic,
+			HealthCheck: &kops.LoadBalancerHealthCheckSpec{AutoTune: true, IntervalSeconds: fi.PtrTo(int32(30))},
+		},
+		{
+			Class:          kops.LoadBalancerClassNetwork,
+			HealthCheck:    &kops.LoadBalancerHealthCheckSpec{AutoTune: true},
+			ExpectedErrors: []string{"Forbidden::spec.api.loadBalancer.healthCheck"},
+		},
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			HealthCheck:    &kops.LoadBalancerHealthCheckSpec{IntervalSeconds: fi.PtrTo(int32(5)), UnhealthyThreshold: fi.PtrTo(int32(11))},
+			ExpectedErrors: []string{"Invalid value::spec.api.loadBalancer.healthCheck.intervalSeconds", "Invalid value::spec.api.loadBalancer.healthCheck.unhealthyThreshold"},
+		},
+		{
+			Class:       kops.LoadBalancerClassClassic,
+			HealthCheck: &kops.LoadBalancerHealthCheckSpec{ReadinessEndpoint: true, ReadinessEndpointPort: fi.PtrTo(int32(3990))},
+		},
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			HealthCheck:    &kops.LoadBalancerHealthCheckSpec{ReadinessEndpointPort: fi.PtrTo(int32(3990))},
+			ExpectedErrors: []string{"Forbidden::spec.api.loadBalancer.healthCheck.readinessEndpointPort"},
+		},
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			HealthCheck:    &kops.LoadBalancerHealthCheckSpec{ReadinessEndpoint: true, ReadinessEndpointPort: fi.PtrTo(int32(70000))},
+			ExpectedErrors: []string{"Invalid value::spec.api.loadBalancer.healthCheck.readinessEndpointPort"},
+		},
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			HealthCheck:    &kops.LoadBalancerHealthCheckSpec{ReadinessEndpoint: true, ReadinessEndpointPort: fi.PtrTo(int32(443))},
+			ExpectedErrors: []string{"Invalid value::spec.api.loadBalancer.healthCheck.readinessEndpointPort"},
+		},
+		{
+			Class:       kops.LoadBalancerClassClassic,
+			HealthCheck: &kops.LoadBalancerHealthCheckSpec{Path: fi.PtrTo("/healthz")},
+		},
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			HealthCheck:    &kops.LoadBalancerHealthCheckSpec{ReadinessEndpoint: true, Path: fi.PtrTo("/healthz")},
+			ExpectedErrors: []string{"Forbidden::spec.api.loadBalancer.healthCheck"},
+		},
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			HealthCheck:    &kops.LoadBalancerHealthCheckSpec{Protocol: fi.PtrTo("UDP"), Port: fi.PtrTo(int32(0))},
+			ExpectedErrors: []string{"Unsupported value::spec.api.loadBalancer.healthCheck.protocol", "Invalid value::spec.api.loadBalancer.healthCheck.port"},
+		},
+	}
+	for _, g := range grid {
+		lbSpec := &kops.LoadBalancerAccessSpec{Class: g.Class, HealthCheck: g.HealthCheck}
+		errs := awsValidateLoadBalancerHealthCheck(field.NewPath("spec", "api", "loadBalancer", "healthCheck"), lbSpec)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerIdleTimeout(t *testing.T) {
+	grid := []struct {
+		IdleTimeoutSeconds *int64
+		ExpectedErrors     []string
+	}{
+		{
+			IdleTimeoutSeconds: nil,
+		},
+		{
+			IdleTimeoutSeconds: fi.PtrTo(int64(300)),
+		},
+		{
+			IdleTimeoutSeconds: fi.PtrTo(int64(-1)),
+			ExpectedErrors:     []string{"Invalid value::spec.api.loadBalancer.idleTimeoutSeconds"},
+		},
+		{
+			IdleTimeoutSeconds: fi.PtrTo(int64(0)),
+			ExpectedErrors:     []string{"Invalid value::spec.api.loadBalancer.idleTimeoutSeconds"},
+		},
+		{
+			IdleTimeoutSeconds: fi.PtrTo(int64(2147483647)),
+			ExpectedErrors:     []string{"Invalid value::spec.api.loadBalancer.idleTimeoutSeconds"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:              kops.LoadBalancerClassClassic,
+						Type:               kops.LoadBalancerTypePublic,
+						IdleTimeoutSeconds: g.IdleTimeoutSeconds,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerResolveAddresses(t *testing.T) {
+	grid := []struct {
+		Class          kops.LoadBalancerClass
+		ExpectedErrors []string
+	}{
+		{
+			Class: kops.LoadBalancerClassClassic,
+		},
+		{
+			Class:          kops.LoadBalancerClassNetwork,
+			ExpectedErrors: []string{"Forbidden::spec.api.loadBalancer.resolveAddresses"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:            g.Class,
+						Type:             kops.LoadBalancerTypePublic,
+						ResolveAddresses: true,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerWaitUntilReady(t *testing.T) {
+	grid := []struct {
+		Class          kops.LoadBalancerClass
+		ExpectedErrors []string
+	}{
+		{
+			Class: kops.LoadBalancerClassClassic,
+		},
+		{
+			Class:          kops.LoadBalancerClassNetwork,
+			ExpectedErrors: []string{"Forbidden::spec.api.loadBalancer.waitUntilReady"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:          g.Class,
+						Type:           kops.LoadBalancerTypePublic,
+						WaitUntilReady: true,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerAllowReplaceOnNameChange(t *testing.T) {
+	grid := []struct {
+		Class          kops.LoadBalancerClass
+		ExpectedErrors []string
+	}{
+		{
+			Class: kops.LoadBalancerClassClassic,
+		},
+		{
+			Class:          kops.LoadBalancerClassNetwork,
+			ExpectedErrors: []string{"Forbidden::spec.api.loadBalancer.allowReplaceOnNameChange"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:                    g.Class,
+						Type:                     kops.LoadBalancerTypePublic,
+						AllowReplaceOnNameChange: true,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerDesyncMitigationMode(t *testing.T) {
+	grid := []struct {
+		Class                kops.LoadBalancerClass
+		DesyncMitigationMode string
+		ExpectedErrors       []string
+	}{
+		{
+			Class:                kops.LoadBalancerClassClassic,
+			DesyncMitigationMode: "strictest",
+		},
+		{
+			Class:                kops.LoadBalancerClassClassic,
+			DesyncMitigationMode: "paranoid",
+			ExpectedErrors:       []string{"Unsupported value::spec.api.loadBalancer.desyncMitigationMode"},
+		},
+		{
+			Class:                kops.LoadBalancerClassNetwork,
+			DesyncMitigationMode: "strictest",
+			ExpectedErrors:       []string{"Forbidden::spec.api.loadBalancer.desyncMitigationMode"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:                g.Class,
+						Type:                 kops.LoadBalancerTypePublic,
+						DesyncMitigationMode: fi.PtrTo(g.DesyncMitigationMode),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerSSLPolicy(t *testing.T) {
+	grid := []struct {
+		Class          kops.LoadBalancerClass
+		SSLCertificate string
+		ExpectedErrors []string
+	}{
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			SSLCertificate: "arn:aws:acm:us-east-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+		},
+		{
+			Class:          kops.LoadBalancerClassNetwork,
+			SSLCertificate: "arn:aws:acm:us-east-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+		},
+		{
+			Class:          kops.LoadBalancerClassClassic,
+			ExpectedErrors: []string{"Forbidden::spec.api.loadBalancer.sslPolicy"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:          g.Class,
+						Type:           kops.LoadBalancerTypePublic,
+						SSLCertificate: g.SSLCertificate,
+						SSLPolicy:      fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01"),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateLoadBalancerListenerProtocols(t *testing.T) {
+	certificate := "arn:aws:acm:us-east-1:000000000000:certificate/123456789012-1234-1234-1234-12345678"
+	grid := []struct {
+		Class            kops.LoadBalancerClass
+		SSLCertificate   string
+		ListenerProtocol *string
+		InstanceProtocol *string
+		ExpectedErrors   []string
+	}{
+		{
+			Class:            kops.LoadBalancerClassClassic,
+			SSLCertificate:   certificate,
+			ListenerProtocol: fi.PtrTo("HTTPS"),
+			InstanceProtocol: fi.PtrTo("HTTPS"),
+		},
+		{
+			Class:            kops.LoadBalancerClassClassic,
+			SSLCertificate:   certificate,
+			InstanceProtocol: fi.PtrTo("TCP"),
+		},
+		{
+			Class:            kops.LoadBalancerClassClassic,
+			ListenerProtocol: fi.PtrTo("UDP"),
+			ExpectedErrors:   []string{"Unsupported value::spec.api.loadBalancer.listenerProtocol"},
+		},
+		{
+			Class:            kops.LoadBalancerClassClassic,
+			ListenerProtocol: fi.PtrTo("HTTPS"),
+			ExpectedErrors:   []string{"Forbidden::spec.api.loadBalancer.listenerProtocol"},
+		},
+		{
+			Class:            kops.LoadBalancerClassClassic,
+			SSLCertificate:   certificate,
+			ListenerProtocol: fi.PtrTo("TCP"),
+			ExpectedErrors:   []string{"Forbidden::spec.api.loadBalancer.listenerProtocol"},
+		},
+		{
+			Class:            kops.LoadBalancerClassClassic,
+			SSLCertificate:   certificate,
+			ListenerProtocol: fi.PtrTo("HTTPS"),
+			InstanceProtocol: fi.PtrTo("SSL"),
+			ExpectedErrors:   []string{"Unsupported value::spec.api.loadBalancer.instanceProtocol"},
+		},
+		{
+			Class:            kops.LoadBalancerClassNetwork,
+			ListenerProtocol: fi.PtrTo("TCP"),
+			InstanceProtocol: fi.PtrTo("TCP"),
+			ExpectedErrors: []string{
+				"Forbidden::spec.api.loadBalancer.listenerProtocol",
+				"Forbidden::spec.api.loadBalancer.instanceProtocol",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:            g.Class,
+						Type:             kops.LoadBalancerTypePublic,
+						SSLCertificate:   g.SSLCertificate,
+						ListenerProtocol: g.ListenerProtocol,
+						InstanceProtocol: g.InstanceProtocol,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
+
+func TestAWSValidateTerraformSSLCertificateDomain(t *testing.T) {
+	grid := []struct {
+		SSLCertificate string
+		ExpectedErrors []string
+	}{
+		{
+			SSLCertificate: "arn:aws:acm:us-east-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+		},
+		{
+			ExpectedErrors: []string{"Forbidden::spec.target.terraform.apiLoadBalancer.sslCertificateDomain"},
+		},
+		{
+			SSLCertificate: "arn:aws:iam::000000000000:server-certificate/api",
+			ExpectedErrors: []string{"Forbidden::spec.target.terraform.apiLoadBalancer.sslCertificateDomain"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:          kops.LoadBalancerClassNetwork,
+						Type:           kops.LoadBalancerTypePublic,
+						SSLCertificate: g.SSLCertificate,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+				Target: &kops.TargetSpec{
+					Terraform: &kops.TerraformSpec{
+						APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+							SSLCertificateDomain: "api.example.com",
+						},
+					},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, false)
+		testErrors(t, g, errs, g.ExpectedErrors)
+	}
+}
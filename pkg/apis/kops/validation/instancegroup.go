@@ -295,6 +295,57 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		allErrs = append(allErrs, validateContainerdConfig(&cluster.Spec, g.Spec.Containerd, field.NewPath("spec", "containerd"), false)...)
 	}
 
+	if value, found := g.Annotations[kops.AnnotationNameClusterAutoscalerExternalBounds]; found {
+		allErrs = append(allErrs, validateClusterAutoscalerExternalBounds(g, cluster, value)...)
+	}
+
+	if g.Spec.AutoscalePriority != 0 {
+		allErrs = append(allErrs, validateAutoscalePriority(g)...)
+	}
+
+	return allErrs
+}
+
+// validateAutoscalePriority checks that an InstanceGroup with a priority for the cluster autoscaler
+// priority expander is listed in the generated priority ConfigMap.
+func validateAutoscalePriority(g *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec", "autoscalePriority")
+
+	if g.Spec.Role != kops.InstanceGroupRoleNode {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only allowed on instance groups with role Node"))
+	} else if !fi.ValueOf(g.Spec.Autoscale) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "requires autoscale to be set to true"))
+	}
+
+	return allErrs
+}
+
+// validateClusterAutoscalerExternalBounds checks that an InstanceGroup asking for externally-managed
+// cluster autoscaler bounds is actually managed by the cluster autoscaler.
+func validateClusterAutoscalerExternalBounds(g *kops.InstanceGroup, cluster *kops.Cluster, value string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("metadata", "annotations").Key(kops.AnnotationNameClusterAutoscalerExternalBounds)
+
+	if value != "true" && value != "false" {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be \"true\" or \"false\""))
+		return allErrs
+	}
+	if value != "true" {
+		return allErrs
+	}
+
+	ca := cluster.Spec.ClusterAutoscaler
+	if ca == nil || !fi.ValueOf(ca.Enabled) || !fi.ValueOf(ca.ExternalNodeGroupBounds) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "requires spec.clusterAutoscaler.externalNodeGroupBounds to be enabled in the cluster"))
+	}
+	if g.Spec.Role != kops.InstanceGroupRoleNode {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only allowed on instance groups with role Node"))
+	}
+	if g.Spec.Autoscale != nil && !*g.Spec.Autoscale {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be used on instance groups with autoscale disabled"))
+	}
+
 	return allErrs
 }
 
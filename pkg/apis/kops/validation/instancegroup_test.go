@@ -384,6 +384,119 @@ func TestIGUpdatePolicy(t *testing.T) {
 	}
 }
 
+func TestIGClusterAutoscalerExternalBounds(t *testing.T) {
+	const field = "metadata.annotations[cluster-autoscaler.kops.k8s.io/external-bounds]"
+	for _, test := range []struct {
+		label          string
+		value          string
+		externalBounds *bool
+		role           kops.InstanceGroupRole
+		autoscale      *bool
+		expected       []string
+	}{
+		{
+			label:          "enabled",
+			value:          "true",
+			externalBounds: fi.PtrTo(true),
+		},
+		{
+			label: "disabled annotation",
+			value: "false",
+		},
+		{
+			label:          "invalid value",
+			value:          "yes",
+			externalBounds: fi.PtrTo(true),
+			expected:       []string{"Invalid value::" + field},
+		},
+		{
+			label:    "cluster option not enabled",
+			value:    "true",
+			expected: []string{"Forbidden::" + field},
+		},
+		{
+			label:          "control plane role",
+			value:          "true",
+			externalBounds: fi.PtrTo(true),
+			role:           kops.InstanceGroupRoleControlPlane,
+			expected:       []string{"Forbidden::" + field},
+		},
+		{
+			label:          "autoscale disabled",
+			value:          "true",
+			externalBounds: fi.PtrTo(true),
+			autoscale:      fi.PtrTo(false),
+			expected:       []string{"Forbidden::" + field},
+		},
+	} {
+		t.Run(test.label, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+					ClusterAutoscaler: &kops.ClusterAutoscalerConfig{
+						Enabled:                 fi.PtrTo(true),
+						ExternalNodeGroupBounds: test.externalBounds,
+					},
+				},
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Annotations = map[string]string{
+				kops.AnnotationNameClusterAutoscalerExternalBounds: test.value,
+			}
+			if test.role != "" {
+				ig.Spec.Role = test.role
+			}
+			ig.Spec.Autoscale = test.autoscale
+
+			errs := validateClusterAutoscalerExternalBounds(ig, cluster, test.value)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGAutoscalePriority(t *testing.T) {
+	for _, test := range []struct {
+		label     string
+		role      kops.InstanceGroupRole
+		autoscale *bool
+		expected  []string
+	}{
+		{
+			label:     "autoscaled node group",
+			autoscale: fi.PtrTo(true),
+		},
+		{
+			label:    "autoscale not set",
+			expected: []string{"Forbidden::spec.autoscalePriority"},
+		},
+		{
+			label:     "autoscale disabled",
+			autoscale: fi.PtrTo(false),
+			expected:  []string{"Forbidden::spec.autoscalePriority"},
+		},
+		{
+			label:     "control plane role",
+			role:      kops.InstanceGroupRoleControlPlane,
+			autoscale: fi.PtrTo(true),
+			expected:  []string{"Forbidden::spec.autoscalePriority"},
+		},
+	} {
+		t.Run(test.label, func(t *testing.T) {
+			ig := createMinimalInstanceGroup()
+			if test.role != "" {
+				ig.Spec.Role = test.role
+			}
+			ig.Spec.Autoscale = test.autoscale
+			ig.Spec.AutoscalePriority = 100
+
+			errs := validateAutoscalePriority(ig)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
 func TestValidInstanceGroup(t *testing.T) {
 	grid := []struct {
 		IG             *kops.InstanceGroup
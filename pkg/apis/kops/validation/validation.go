@@ -23,10 +23,12 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/blang/semver/v4"
+	"github.com/google/go-containerregistry/pkg/name"
 	"golang.org/x/net/ipv4"
 	"golang.org/x/net/ipv6"
 	"k8s.io/apimachinery/pkg/api/validation"
@@ -293,6 +295,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateCertManager(c, spec.CertManager, fieldPath.Child("certManager"))...)
 	}
 
+	if spec.Target != nil && spec.Target.Terraform != nil {
+		allErrs = append(allErrs, validateTerraformSpec(spec.Target.Terraform, fieldPath.Child("target", "terraform"))...)
+	}
+
 	return allErrs
 }
 
@@ -1860,6 +1866,22 @@ func validateNodeLocalDNS(spec *kops.ClusterSpec, fldpath *field.Path) field.Err
 	return allErrs
 }
 
+func validateClusterAutoscalerProbe(probe *kops.ClusterAutoscalerProbeSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	if probe.TimeoutSeconds != nil && *probe.TimeoutSeconds < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *probe.TimeoutSeconds, "must be at least 1"))
+	}
+	if probe.PeriodSeconds != nil && *probe.PeriodSeconds < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("periodSeconds"), *probe.PeriodSeconds, "must be at least 1"))
+	}
+	if probe.FailureThreshold != nil && *probe.FailureThreshold < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("failureThreshold"), *probe.FailureThreshold, "must be at least 1"))
+	}
+	return allErrs
+}
+
+// clusterAutoscalerFeatureGateName matches the names of cluster autoscaler feature gates, which are in upper camel case.
+var clusterAutoscalerFeatureGateName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
 func validateClusterAutoscaler(cluster *kops.Cluster, spec *kops.ClusterAutoscalerConfig, fldPath *field.Path) (allErrs field.ErrorList) {
 	if spec.Expander != "" {
 		allErrs = append(allErrs, IsValidValue(fldPath.Child("expander"), &spec.Expander, []string{"least-waste", "random", "most-pods", "price", "priority"})...)
@@ -1873,6 +1895,73 @@ func validateClusterAutoscaler(cluster *kops.Cluster, spec *kops.ClusterAutoscal
 		allErrs = append(allErrs, field.Forbidden(fldPath, "Cluster autoscaler is not supported on OpenStack"))
 	}
 
+	if spec.Namespace != nil {
+		for _, msg := range utilvalidation.IsDNS1123Label(*spec.Namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), *spec.Namespace, msg))
+		}
+	}
+
+	if len(spec.BalancingLabels) > 0 && !fi.ValueOf(spec.BalanceSimilarNodeGroups) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("balancingLabels"), "balancingLabels requires balanceSimilarNodeGroups to be enabled"))
+	}
+	for i, label := range spec.BalancingLabels {
+		for _, msg := range utilvalidation.IsQualifiedName(label) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("balancingLabels").Index(i), label, msg))
+		}
+	}
+
+	if spec.LivenessProbe != nil {
+		allErrs = append(allErrs, validateClusterAutoscalerProbe(spec.LivenessProbe, fldPath.Child("livenessProbe"))...)
+	}
+	if spec.ReadinessProbe != nil {
+		allErrs = append(allErrs, validateClusterAutoscalerProbe(spec.ReadinessProbe, fldPath.Child("readinessProbe"))...)
+	}
+	if spec.TerminationGracePeriodSeconds != nil && *spec.TerminationGracePeriodSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("terminationGracePeriodSeconds"), *spec.TerminationGracePeriodSeconds, "must not be negative"))
+	}
+	for _, name := range sets.List(sets.KeySet(spec.FeatureGates)) {
+		if !clusterAutoscalerFeatureGateName.MatchString(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("featureGates").Key(name), name, "must be a feature gate name, such as ExampleFeature"))
+		}
+	}
+	if fi.ValueOf(spec.ExternalNodeGroupBounds) && cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("externalNodeGroupBounds"), "externalNodeGroupBounds is only supported on AWS"))
+	}
+
+	// The priority expander reads its ConfigMap from the autoscaler's own namespace, so kOps must create it there
+	if spec.Expander == "priority" && spec.CreatePriorityExpenderConfig != nil && !*spec.CreatePriorityExpenderConfig {
+		namespace := fi.ValueOf(spec.Namespace)
+		if namespace == "" {
+			namespace = "kube-system"
+		}
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("createPriorityExpanderConfig"), fmt.Sprintf("the priority expander requires the priority ConfigMap to be created in namespace %q", namespace)))
+	}
+
+	for _, priority := range sets.List(sets.KeySet(spec.CustomPriorityExpanderConfig)) {
+		priorityPath := fldPath.Child("customPriorityExpanderConfig").Key(priority)
+		if _, err := strconv.Atoi(priority); err != nil {
+			allErrs = append(allErrs, field.Invalid(priorityPath, priority, "priority must be an integer"))
+		}
+		groups := spec.CustomPriorityExpanderConfig[priority]
+		if len(groups) == 0 {
+			allErrs = append(allErrs, field.Required(priorityPath, "at least one node group pattern is required"))
+		}
+		for i, group := range groups {
+			if group == "" {
+				allErrs = append(allErrs, field.Required(priorityPath.Index(i), ""))
+			} else if _, err := regexp.Compile(group); err != nil {
+				allErrs = append(allErrs, field.Invalid(priorityPath.Index(i), group, fmt.Sprintf("must be a valid regular expression: %v", err)))
+			}
+		}
+	}
+
+	// Images pinned by digest must carry a well-formed digest
+	if image := fi.ValueOf(spec.Image); strings.Contains(image, "@") {
+		if _, err := name.NewDigest(image); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("image"), image, fmt.Sprintf("invalid image digest reference: %v", err)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -1980,3 +2069,58 @@ func validateCertManager(cluster *kops.Cluster, spec *kops.CertManagerConfig, fl
 	}
 	return allErrs
 }
+
+// terraformIdentifier matches the names terraform accepts for variables.
+var terraformIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// classicLoadBalancerName matches the names AWS accepts for Classic load balancers.
+var classicLoadBalancerName = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,30}[A-Za-z0-9])?$`)
+
+func validateTerraformSpec(spec *kops.TerraformSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if lb := spec.APILoadBalancer; lb != nil {
+		lbPath := fldPath.Child("apiLoadBalancer")
+		if lb.CreateVariable != "" && !terraformIdentifier.MatchString(lb.CreateVariable) {
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("createVariable"), lb.CreateVariable, "must be a valid terraform variable name"))
+		}
+		if lb.TagsVariable != "" && !terraformIdentifier.MatchString(lb.TagsVariable) {
+			allErrs = append(allErrs, field.Invalid(lbPath.Child("tagsVariable"), lb.TagsVariable, "must be a valid terraform variable name"))
+		}
+		if timeouts := lb.Timeouts; timeouts != nil {
+			if timeouts.Create != nil && timeouts.Create.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("timeouts", "create"), timeouts.Create.Duration.String(), "must be positive"))
+			}
+			if timeouts.Delete != nil && timeouts.Delete.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("timeouts", "delete"), timeouts.Delete.Duration.String(), "must be positive"))
+			}
+		}
+		if lb.LocalExecCommand != "" && !spec.AllowLocalExecProvisioners {
+			allErrs = append(allErrs, field.Forbidden(lbPath.Child("localExecCommand"), "localExecCommand requires allowLocalExecProvisioners, because it runs on the machine that applies the terraform configuration"))
+		}
+		if lb.ImportName != "" {
+			if !classicLoadBalancerName.MatchString(lb.ImportName) {
+				allErrs = append(allErrs, field.Invalid(lbPath.Child("importName"), lb.ImportName, "must be a valid Classic load balancer name"))
+			}
+			if lb.CreateVariable != "" {
+				allErrs = append(allErrs, field.Forbidden(lbPath.Child("importName"), "importName cannot be used with createVariable"))
+			}
+		}
+	}
+
+	if spec.Syntax != "" {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("syntax"), &spec.Syntax, kops.SupportedTerraformSyntaxes)...)
+	}
+
+	if indentation := spec.Indentation; indentation != nil {
+		indentationPath := fldPath.Child("indentation")
+		if indentation.Width < 0 || indentation.Width > 8 {
+			allErrs = append(allErrs, field.Invalid(indentationPath.Child("width"), indentation.Width, "must be between 0 and 8"))
+		}
+		if indentation.UseTabs && indentation.Width != 0 {
+			allErrs = append(allErrs, field.Forbidden(indentationPath.Child("width"), "width cannot be set when indenting with tabs"))
+		}
+	}
+
+	return allErrs
+}
@@ -1407,6 +1407,179 @@ func Test_Validate_NodeLocalDNS(t *testing.T) {
 	}
 }
 
+func Test_Validate_ClusterAutoscaler(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterAutoscalerConfig
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Image: fi.PtrTo("registry.k8s.io/autoscaling/cluster-autoscaler:v1.30.0"),
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Image: fi.PtrTo("registry.k8s.io/autoscaling/cluster-autoscaler@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"),
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Image: fi.PtrTo("registry.k8s.io/autoscaling/cluster-autoscaler:v1.30.0@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"),
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Image: fi.PtrTo("registry.k8s.io/autoscaling/cluster-autoscaler@sha256:0123456789abcdef"),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.clusterAutoscaler.image"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Image: fi.PtrTo("registry.k8s.io/autoscaling/cluster-autoscaler@md5:0123456789abcdef0123456789abcdef"),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.clusterAutoscaler.image"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Expander:  "priority",
+				Namespace: fi.PtrTo("autoscaling"),
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Namespace: fi.PtrTo("Autoscaling.System"),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.clusterAutoscaler.namespace"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Expander:                     "priority",
+				Namespace:                    fi.PtrTo("autoscaling"),
+				CreatePriorityExpenderConfig: fi.PtrTo(false),
+			},
+			ExpectedErrors: []string{"Forbidden::spec.clusterAutoscaler.createPriorityExpanderConfig"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Expander: "priority",
+				CustomPriorityExpanderConfig: map[string][]string{
+					"10": {".*"},
+					"50": {"spot-.*"},
+				},
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Expander: "priority",
+				CustomPriorityExpanderConfig: map[string][]string{
+					"high": {"spot-.*"},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.clusterAutoscaler.customPriorityExpanderConfig[high]"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				Expander: "priority",
+				CustomPriorityExpanderConfig: map[string][]string{
+					"10": {},
+					"50": {"spot-(", ""},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::spec.clusterAutoscaler.customPriorityExpanderConfig[10]",
+				"Invalid value::spec.clusterAutoscaler.customPriorityExpanderConfig[50][0]",
+				"Required value::spec.clusterAutoscaler.customPriorityExpanderConfig[50][1]",
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				BalanceSimilarNodeGroups: fi.PtrTo(true),
+				BalancingLabels:          []string{"example.com/pool", "topology.kubernetes.io/zone"},
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				BalancingLabels: []string{"example.com/pool"},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.clusterAutoscaler.balancingLabels"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				BalanceSimilarNodeGroups: fi.PtrTo(true),
+				BalancingLabels:          []string{"example.com/pool", "not a label"},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.clusterAutoscaler.balancingLabels[1]"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				LivenessProbe: &kops.ClusterAutoscalerProbeSpec{
+					TimeoutSeconds:   fi.PtrTo(int32(5)),
+					PeriodSeconds:    fi.PtrTo(int32(30)),
+					FailureThreshold: fi.PtrTo(int32(6)),
+				},
+				ReadinessProbe: &kops.ClusterAutoscalerProbeSpec{
+					TimeoutSeconds: fi.PtrTo(int32(2)),
+				},
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				LivenessProbe: &kops.ClusterAutoscalerProbeSpec{
+					TimeoutSeconds:   fi.PtrTo(int32(0)),
+					FailureThreshold: fi.PtrTo(int32(-1)),
+				},
+				ReadinessProbe: &kops.ClusterAutoscalerProbeSpec{
+					PeriodSeconds: fi.PtrTo(int32(0)),
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.clusterAutoscaler.livenessProbe.timeoutSeconds",
+				"Invalid value::spec.clusterAutoscaler.livenessProbe.failureThreshold",
+				"Invalid value::spec.clusterAutoscaler.readinessProbe.periodSeconds",
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				TerminationGracePeriodSeconds: fi.PtrTo(int64(0)),
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				TerminationGracePeriodSeconds: fi.PtrTo(int64(-1)),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.clusterAutoscaler.terminationGracePeriodSeconds"},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				ExternalNodeGroupBounds: fi.PtrTo(true),
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				FeatureGates: map[string]bool{"ExampleFeature": true, "OtherFeature2": false},
+			},
+		},
+		{
+			Input: kops.ClusterAutoscalerConfig{
+				FeatureGates: map[string]bool{"example-feature": true},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.clusterAutoscaler.featureGates[example-feature]"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := validateClusterAutoscaler(cluster, &g.Input, field.NewPath("spec", "clusterAutoscaler"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
 func Test_Validate_CloudConfiguration(t *testing.T) {
 	grid := []struct {
 		Description    string
@@ -1772,3 +1945,125 @@ func Test_Validate_NriConfig(t *testing.T) {
 		testErrors(t, g.Input.Containerd, errs, g.ExpectedErrors)
 	}
 }
+
+func Test_Validate_TerraformSpec(t *testing.T) {
+	grid := []struct {
+		Input          kops.TerraformSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					CreateVariable: "create_api_elb",
+				},
+			},
+		},
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					CreateVariable: "var.create_api_elb",
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.apiLoadBalancer.createVariable"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					TagsVariable: "common tags",
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.apiLoadBalancer.tagsVariable"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					Timeouts: &kops.TerraformTimeoutsSpec{
+						Create: &metav1.Duration{Duration: 30 * time.Minute},
+						Delete: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.apiLoadBalancer.timeouts.delete"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					LocalExecCommand: "./warm-dns.sh ${self.dns_name}",
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.target.terraform.apiLoadBalancer.localExecCommand"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				AllowLocalExecProvisioners: true,
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					LocalExecCommand: "./warm-dns.sh ${self.dns_name}",
+				},
+			},
+		},
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					ImportName: "api-example-com-1a2b3c",
+				},
+			},
+		},
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					ImportName: "api.example.com",
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.apiLoadBalancer.importName"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				APILoadBalancer: &kops.TerraformAPILoadBalancerSpec{
+					ImportName:     "api-example-com",
+					CreateVariable: "create_api_elb",
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.target.terraform.apiLoadBalancer.importName"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				Indentation: &kops.TerraformIndentationSpec{
+					UseTabs: true,
+				},
+			},
+		},
+		{
+			Input: kops.TerraformSpec{
+				Indentation: &kops.TerraformIndentationSpec{
+					Width: 12,
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.target.terraform.indentation.width"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				Indentation: &kops.TerraformIndentationSpec{
+					UseTabs: true,
+					Width:   4,
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.target.terraform.indentation.width"},
+		},
+		{
+			Input: kops.TerraformSpec{
+				Syntax: kops.TerraformSyntaxJSON,
+			},
+		},
+		{
+			Input: kops.TerraformSpec{
+				Syntax: "YAML",
+			},
+			ExpectedErrors: []string{"Unsupported value::spec.target.terraform.syntax"},
+		},
+	}
+
+	for _, g := range grid {
+		errs := validateTerraformSpec(&g.Input, field.NewPath("spec", "target", "terraform"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
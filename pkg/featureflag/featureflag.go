@@ -98,6 +98,10 @@ var (
 	Metal = new("Metal", Bool(false))
 	// AWSSingleNodesInstanceGroup enables the creation of a single node instance group instead of one per availability zone.
 	AWSSingleNodesInstanceGroup = new("AWSSingleNodesInstanceGroup", Bool(false))
+	// StrictAPILoadBalancerAccess rejects public API load balancers that are reachable from the whole internet.
+	StrictAPILoadBalancerAccess = new("StrictAPILoadBalancerAccess", Bool(false))
+	// ELBAllowDetachingZonesWithInstances allows detaching a Classic ELB from the last subnet of a zone with registered instances.
+	ELBAllowDetachingZonesWithInstances = new("ELBAllowDetachingZonesWithInstances", Bool(false))
 )
 
 // FeatureFlag defines a feature flag
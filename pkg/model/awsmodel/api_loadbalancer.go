@@ -19,9 +19,11 @@ package awsmodel
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -31,7 +33,8 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 )
 
-// LoadBalancerDefaultIdleTimeout is the default idle time for the ELB
+// LoadBalancerDefaultIdleTimeout is the default idle time for the API ELB.
+// There is no default for the bastion, which is fronted by an NLB with the fixed AWS TCP idle timeout of 350 seconds.
 const LoadBalancerDefaultIdleTimeout = 5 * time.Minute
 
 // APILoadBalancerBuilder builds a LoadBalancer for accessing the API
@@ -67,6 +70,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 	var elbSubnets []*awstasks.Subnet
 	var nlbSubnetMappings []*awstasks.SubnetMapping
+	elbZones := sets.New[string]()
 	if len(lbSpec.Subnets) != 0 {
 		// Subnets have been explicitly set
 		for _, subnet := range lbSpec.Subnets {
@@ -74,6 +78,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				if subnet.Name == clusterSubnet.Name {
 					elbSubnet := b.LinkToSubnet(&clusterSubnet)
 					elbSubnets = append(elbSubnets, elbSubnet)
+					elbZones.Insert(clusterSubnet.Zone)
 
 					nlbSubnetMapping := &awstasks.SubnetMapping{
 						Subnet: elbSubnet,
@@ -118,6 +123,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 			elbSubnet := b.LinkToSubnet(subnet)
 			elbSubnets = append(elbSubnets, elbSubnet)
+			elbZones.Insert(zone)
 			nlbSubnetMappings = append(nlbSubnetMappings, &awstasks.SubnetMapping{Subnet: elbSubnet})
 		}
 	}
@@ -125,13 +131,14 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	var clb *awstasks.ClassicLoadBalancer
 	var nlb *awstasks.NetworkLoadBalancer
 	{
-		idleTimeout := LoadBalancerDefaultIdleTimeout
-		if lbSpec.IdleTimeoutSeconds != nil {
-			idleTimeout = time.Second * time.Duration(*lbSpec.IdleTimeoutSeconds)
-		}
+		idleTimeout := apiLoadBalancerIdleTimeout(lbSpec)
 
 		listeners := map[string]*awstasks.ClassicLoadBalancerListener{
-			"443": {InstancePort: 443},
+			"443": {
+				InstancePort:     443,
+				Protocol:         lbSpec.ListenerProtocol,
+				InstanceProtocol: lbSpec.InstanceProtocol,
+			},
 		}
 		var nlbListeners []*awstasks.NetworkLoadBalancerListener
 
@@ -158,6 +165,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 			// The primary listener _does_ use the custom certificate.
 			listeners["443"].SSLCertificateID = lbSpec.SSLCertificate
+			listeners["443"].SSLPolicy = lbSpec.SSLPolicy
 			listener443 := &awstasks.NetworkLoadBalancerListener{
 				Name:                fi.PtrTo(b.NLBListenerName("api", 443)),
 				Lifecycle:           b.Lifecycle,
@@ -189,12 +197,12 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			klog.V(1).Infof("WARNING: You are overwriting the Load Balancers, Security Group. When this is done you are responsible for ensure the correct rules!")
 		}
 
-		tags := b.CloudTags("", false)
-		for k, v := range b.Cluster.Spec.CloudLabels {
-			tags[k] = v
+		tags := b.apiLoadBalancerTags()
+
+		healthCheck, err := b.buildClassicHealthCheck(lbSpec.HealthCheck, listeners["443"])
+		if err != nil {
+			return err
 		}
-		// Override the returned name to be the expected ELB name
-		tags["Name"] = "api." + b.ClusterName()
 
 		nlb = &awstasks.NetworkLoadBalancer{
 			Name:      fi.PtrTo(b.NLBName("api")),
@@ -222,21 +230,14 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			Name:      fi.PtrTo("api." + b.ClusterName()),
 			Lifecycle: b.Lifecycle,
 
-			LoadBalancerName: fi.PtrTo(b.LBName32("api")),
+			LoadBalancerName: fi.PtrTo(b.CLBName32("api")),
 			SecurityGroups: []*awstasks.SecurityGroup{
 				b.LinkToELBSecurityGroup("api"),
 			},
 			Subnets:   elbSubnets,
 			Listeners: listeners,
 
-			// Configure fast-recovery health-checks
-			HealthCheck: &awstasks.ClassicLoadBalancerHealthCheck{
-				Target:             fi.PtrTo("SSL:443"),
-				Timeout:            fi.PtrTo(int32(5)),
-				Interval:           fi.PtrTo(int32(10)),
-				HealthyThreshold:   fi.PtrTo(int32(2)),
-				UnhealthyThreshold: fi.PtrTo(int32(2)),
-			},
+			HealthCheck: healthCheck,
 
 			ConnectionSettings: &awstasks.ClassicLoadBalancerConnectionSettings{
 				IdleTimeout: fi.PtrTo(int32(idleTimeout.Seconds())),
@@ -251,10 +252,35 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			WellKnownServices: []wellknownservices.WellKnownService{wellknownservices.KubeAPIServer},
 		}
 
+		if tfSpec := b.terraformAPILoadBalancerSpec(); tfSpec != nil {
+			clb.SetTerraformResourceNamePrefix(tfSpec.ResourceNamePrefix)
+			clb.SetTerraformCreateVariable(tfSpec.CreateVariable)
+			clb.SetTerraformImportName(tfSpec.ImportName)
+		}
+		clb.SetRetainUnmanagedTags(lbSpec.RetainUnmanagedTags)
+		if lbSpec.AllowReplaceOnNameChange {
+			clb.AllowReplaceOnNameChange = fi.PtrTo(true)
+		}
+		clb.DesyncMitigationMode = lbSpec.DesyncMitigationMode
+		clb.SetResolveAddresses(lbSpec.ResolveAddresses)
+		if lbSpec.WaitUntilReady {
+			clb.SetReadinessGate(443)
+		}
+		if port, ok := readinessEndpointPort(lbSpec.HealthCheck); ok {
+			clb.SetHealthCheckSidecarPort(port)
+		}
+		nodePortRange, err := b.healthCheckNodePortRange(lbSpec.HealthCheck)
+		if err != nil {
+			return err
+		}
+		if nodePortRange != nil {
+			clb.SetNodePortRange(*nodePortRange)
+		}
+
 		if b.Cluster.UsesNoneDNS() {
 			lbSpec.CrossZoneLoadBalancing = fi.PtrTo(true)
 		} else if lbSpec.CrossZoneLoadBalancing == nil {
-			lbSpec.CrossZoneLoadBalancing = fi.PtrTo(false)
+			lbSpec.CrossZoneLoadBalancing = fi.PtrTo(defaultCrossZoneLoadBalancing(lbSpec.Class, elbZones))
 		}
 
 		clb.CrossZoneLoadBalancing = &awstasks.ClassicLoadBalancerCrossZoneLoadBalancing{
@@ -296,6 +322,9 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		}
 
 		if b.APILoadBalancerClass() == kops.LoadBalancerClassClassic {
+			if err := validateClassicListeners(clb.Listeners, b.apiServerPort()); err != nil {
+				return err
+			}
 			c.AddTask(clb)
 		} else if b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork {
 			groupAttrs := map[string]string{
@@ -374,6 +403,9 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				secondaryTG.CreateNewRevisionsWith(nlb)
 				c.AddTask(secondaryTG)
 			}
+			if err := validateNetworkListenerPorts(nlbListeners); err != nil {
+				return err
+			}
 			for _, nlbListener := range nlbListeners {
 				c.AddTask(nlbListener)
 			}
@@ -391,7 +423,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			RemoveExtraRules: []string{"port=443"},
 			VPC:              b.LinkToVPC(),
 		}
-		lbSG.Tags = b.CloudTags(*lbSG.Name, false)
+		lbSG.Tags = b.apiLoadBalancerSecurityGroupTags(*lbSG.Name)
 
 		if lbSpec.SecurityGroupOverride != nil {
 			lbSG.ID = fi.PtrTo(*lbSpec.SecurityGroupOverride)
@@ -575,6 +607,17 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				SourceGroup:   masterGroup.Task,
 				ToPort:        fi.PtrTo(int32(4)),
 			})
+			if port, ok := readinessEndpointPort(lbSpec.HealthCheck); ok && b.APILoadBalancerClass() == kops.LoadBalancerClassClassic {
+				c.AddTask(&awstasks.SecurityGroupRule{
+					Name:          fi.PtrTo(fmt.Sprintf("readiness-elb-to-cp%s", suffix)),
+					Lifecycle:     b.SecurityLifecycle,
+					FromPort:      fi.PtrTo(port),
+					Protocol:      fi.PtrTo("tcp"),
+					SecurityGroup: masterGroup.Task,
+					SourceGroup:   lbSG,
+					ToPort:        fi.PtrTo(port),
+				})
+			}
 			if b.Cluster.UsesNoneDNS() {
 				nlb.WellKnownServices = append(nlb.WellKnownServices, wellknownservices.KopsController)
 				clb.WellKnownServices = append(clb.WellKnownServices, wellknownservices.KopsController)
@@ -662,3 +705,219 @@ func (b *APILoadBalancerBuilder) chooseBestSubnetForELB(zone string, subnets []*
 
 	return scoredSubnets[0].subnet
 }
+
+// classicHealthCheckTiers lengthens the health check interval and unhealthy threshold of the API ELB
+// as the cluster grows, ordered from the largest cluster size.
+var classicHealthCheckTiers = []struct {
+	minNodes           int
+	interval           int32
+	unhealthyThreshold int32
+}{
+	{minNodes: 500, interval: 30, unhealthyThreshold: 3},
+	{minNodes: 100, interval: 20, unhealthyThreshold: 3},
+}
+
+// apiLoadBalancerTags returns the tags for the API load balancer.
+// They are merged in a fixed order, so that the result doesn't depend on map iteration:
+// the cluster tags first, then the user's cloud labels, which win over the cluster tags,
+// and finally the Name tag, which always wins as it is how the load balancer is found.
+func (b *APILoadBalancerBuilder) apiLoadBalancerTags() map[string]string {
+	tags := b.CloudTags("", false)
+	for k, v := range b.Cluster.Spec.CloudLabels {
+		tags[k] = v
+	}
+	tags["Name"] = "api." + b.ClusterName()
+	return tags
+}
+
+// apiLoadBalancerSecurityGroupTags returns the tags for the security group kOps creates for the API load balancer.
+// These are the load balancer's tags with the group's own Name, so that both are attributed to the cluster alike.
+func (b *APILoadBalancerBuilder) apiLoadBalancerSecurityGroupTags(name string) map[string]string {
+	tags := b.apiLoadBalancerTags()
+	tags["Name"] = name
+	return tags
+}
+
+// buildClassicHealthCheck returns the health check for the API ELB, applying auto-tuning and any explicit settings.
+// The parts of the target that are not set explicitly are taken from the primary listener.
+func (b *APILoadBalancerBuilder) buildClassicHealthCheck(spec *kops.LoadBalancerHealthCheckSpec, primary *awstasks.ClassicLoadBalancerListener) (*awstasks.ClassicLoadBalancerHealthCheck, error) {
+	// Configure fast-recovery health-checks
+	healthCheck := &awstasks.ClassicLoadBalancerHealthCheck{
+		Target:             fi.PtrTo("SSL:443"),
+		Timeout:            fi.PtrTo(int32(5)),
+		Interval:           fi.PtrTo(int32(10)),
+		HealthyThreshold:   fi.PtrTo(int32(2)),
+		UnhealthyThreshold: fi.PtrTo(int32(2)),
+	}
+	if spec == nil {
+		return healthCheck, nil
+	}
+
+	if spec.AutoTune {
+		nodeCount := b.maxNodeCount()
+		for _, tier := range classicHealthCheckTiers {
+			if nodeCount >= tier.minNodes {
+				healthCheck.Interval = fi.PtrTo(tier.interval)
+				healthCheck.UnhealthyThreshold = fi.PtrTo(tier.unhealthyThreshold)
+				break
+			}
+		}
+	}
+
+	if spec.IntervalSeconds != nil {
+		healthCheck.Interval = spec.IntervalSeconds
+	}
+	if spec.HealthyThreshold != nil {
+		healthCheck.HealthyThreshold = spec.HealthyThreshold
+	}
+	if spec.UnhealthyThreshold != nil {
+		healthCheck.UnhealthyThreshold = spec.UnhealthyThreshold
+	}
+
+	if port, ok := readinessEndpointPort(spec); ok {
+		target := &awstasks.ClassicLoadBalancerHealthCheckTarget{Protocol: "HTTP", Port: port, Path: "/readyz"}
+		healthCheck.Target = fi.PtrTo(target.String())
+	} else if spec.Protocol != nil || spec.Port != nil || spec.Path != nil {
+		target := composeClassicHealthCheckTarget(spec, primary)
+		if err := target.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid API load balancer health check target %q: %w", target.String(), err)
+		}
+		healthCheck.Target = fi.PtrTo(target.String())
+	}
+
+	return healthCheck, nil
+}
+
+// composeClassicHealthCheckTarget merges the health check target settings with the primary listener.
+// The port defaults to the instance port of the listener, and the protocol to its instance protocol;
+// the API server terminates TLS itself, so SSL is assumed when the listener doesn't set one.
+// When only a path is set, the protocol is switched to its HTTP equivalent, as ELB only requests paths over HTTP and HTTPS.
+func composeClassicHealthCheckTarget(spec *kops.LoadBalancerHealthCheckSpec, primary *awstasks.ClassicLoadBalancerListener) *awstasks.ClassicLoadBalancerHealthCheckTarget {
+	target := &awstasks.ClassicLoadBalancerHealthCheckTarget{
+		Protocol: "SSL",
+		Port:     primary.InstancePort,
+		Path:     fi.ValueOf(spec.Path),
+	}
+	if primary.InstanceProtocol != nil {
+		target.Protocol = strings.ToUpper(*primary.InstanceProtocol)
+	} else if primary.Protocol != nil {
+		target.Protocol = strings.ToUpper(*primary.Protocol)
+	}
+
+	if spec.Protocol != nil {
+		target.Protocol = strings.ToUpper(*spec.Protocol)
+	} else if target.Path != "" {
+		switch target.Protocol {
+		case "SSL":
+			target.Protocol = "HTTPS"
+		case "TCP":
+			target.Protocol = "HTTP"
+		}
+	}
+	if spec.Port != nil {
+		target.Port = *spec.Port
+	}
+	return target
+}
+
+// apiServerPort returns the port kube-apiserver listens on.
+func (b *APILoadBalancerBuilder) apiServerPort() int32 {
+	if b.Cluster.Spec.KubeAPIServer != nil && b.Cluster.Spec.KubeAPIServer.SecurePort != 0 {
+		return b.Cluster.Spec.KubeAPIServer.SecurePort
+	}
+	return 443
+}
+
+// validateClassicListeners checks that one of the listeners forwards to the API server port,
+// as otherwise the API is unreachable through the load balancer.
+func validateClassicListeners(listeners map[string]*awstasks.ClassicLoadBalancerListener, apiServerPort int32) error {
+	var ports []string
+	for port, listener := range listeners {
+		if listener.InstancePort == apiServerPort {
+			return nil
+		}
+		ports = append(ports, fmt.Sprintf("%s->%d", port, listener.InstancePort))
+	}
+	sort.Strings(ports)
+	return fmt.Errorf("none of the API load balancer listeners %v forward to the API server port %d", ports, apiServerPort)
+}
+
+// apiLoadBalancerIdleTimeout returns the idle timeout of the API ELB, defaulting to LoadBalancerDefaultIdleTimeout.
+func apiLoadBalancerIdleTimeout(lbSpec *kops.LoadBalancerAccessSpec) time.Duration {
+	if lbSpec.IdleTimeoutSeconds != nil {
+		return time.Second * time.Duration(*lbSpec.IdleTimeoutSeconds)
+	}
+	return LoadBalancerDefaultIdleTimeout
+}
+
+// defaultCrossZoneLoadBalancing returns whether cross-zone load balancing should be enabled when it is not set explicitly.
+// A Classic ELB spanning several zones otherwise only balances across the instances of the zone a client is routed to.
+// NLBs keep it disabled by default, as cross-zone traffic is charged for them.
+func defaultCrossZoneLoadBalancing(class kops.LoadBalancerClass, zones sets.Set[string]) bool {
+	return class == kops.LoadBalancerClassClassic && zones.Len() > 1
+}
+
+// validateNetworkListenerPorts checks that no two of the API load balancer listeners use the same port.
+// Listener tasks are named after their port, so a duplicate would otherwise replace the first listener.
+func validateNetworkListenerPorts(listeners []*awstasks.NetworkLoadBalancerListener) error {
+	ports := make(map[int]string, len(listeners))
+	for _, listener := range listeners {
+		if other, found := ports[listener.Port]; found {
+			return fmt.Errorf("API load balancer listeners %q and %q both use port %d", other, fi.ValueOf(listener.Name), listener.Port)
+		}
+		ports[listener.Port] = fi.ValueOf(listener.Name)
+	}
+	return nil
+}
+
+// terraformAPILoadBalancerSpec returns the terraform configuration of the API load balancer, if any.
+func (b *APILoadBalancerBuilder) terraformAPILoadBalancerSpec() *kops.TerraformAPILoadBalancerSpec {
+	if target := b.Cluster.Spec.Target; target != nil && target.Terraform != nil {
+		return target.Terraform.APILoadBalancer
+	}
+	return nil
+}
+
+// healthCheckNodePortRange returns the cluster's NodePort range if the API ELB health check targets a NodePort,
+// meaning the ELB fronts a NodePort service such as a proxy in front of the API servers, or nil otherwise.
+func (b *APILoadBalancerBuilder) healthCheckNodePortRange(spec *kops.LoadBalancerHealthCheckSpec) (*utilnet.PortRange, error) {
+	if spec == nil || spec.Port == nil {
+		return nil, nil
+	}
+	nodePortRange, err := b.NodePortRange()
+	if err != nil {
+		return nil, err
+	}
+	if !nodePortRange.Contains(int(*spec.Port)) {
+		return nil, nil
+	}
+	return &nodePortRange, nil
+}
+
+// readinessEndpointPort returns the port of the readiness endpoint targeted by the API ELB health check, if enabled.
+// The kube-apiserver-healthcheck sidecar proxies /readyz to the API server, which includes the etcd checks.
+func readinessEndpointPort(spec *kops.LoadBalancerHealthCheckSpec) (int32, bool) {
+	if spec == nil || !spec.ReadinessEndpoint {
+		return 0, false
+	}
+	if spec.ReadinessEndpointPort != nil {
+		return *spec.ReadinessEndpointPort, true
+	}
+	return wellknownports.KubeAPIServerHealthCheck, true
+}
+
+// maxNodeCount returns the maximum number of nodes the cluster can scale to.
+func (b *APILoadBalancerBuilder) maxNodeCount() int {
+	count := 0
+	for _, ig := range b.InstanceGroups {
+		if ig.Spec.Role != kops.InstanceGroupRoleNode {
+			continue
+		}
+		if ig.Spec.MaxSize != nil {
+			count += int(fi.ValueOf(ig.Spec.MaxSize))
+		} else {
+			count += int(fi.ValueOf(ig.Spec.MinSize))
+		}
+	}
+	return count
+}
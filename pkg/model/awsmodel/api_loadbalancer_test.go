@@ -0,0 +1,540 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsmodel
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+func TestValidateClassicListeners(t *testing.T) {
+	listeners := map[string]*awstasks.ClassicLoadBalancerListener{
+		"443": {InstancePort: 443},
+	}
+
+	cluster := buildMinimalCluster()
+	b := &APILoadBalancerBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+			},
+		},
+	}
+	if err := validateClassicListeners(listeners, b.apiServerPort()); err != nil {
+		t.Errorf("unexpected error for the default API server port: %v", err)
+	}
+
+	cluster.Spec.KubeAPIServer = &kops.KubeAPIServerConfig{SecurePort: 6443}
+	err := validateClassicListeners(listeners, b.apiServerPort())
+	if err == nil {
+		t.Fatalf("expected an error for API server port 6443")
+	}
+	if expected := "none of the API load balancer listeners [443->443] forward to the API server port 6443"; err.Error() != expected {
+		t.Errorf("unexpected error, expected %q got %q", expected, err.Error())
+	}
+}
+
+func TestValidateNetworkListenerPorts(t *testing.T) {
+	listeners := []*awstasks.NetworkLoadBalancerListener{
+		{Name: fi.PtrTo("api-tcp-443"), Port: 443},
+		{Name: fi.PtrTo("api-tls-8443"), Port: 8443},
+	}
+	if err := validateNetworkListenerPorts(listeners); err != nil {
+		t.Errorf("unexpected error for distinct ports: %v", err)
+	}
+
+	listeners = append(listeners, &awstasks.NetworkLoadBalancerListener{Name: fi.PtrTo("kops-controller"), Port: 443})
+	err := validateNetworkListenerPorts(listeners)
+	if err == nil {
+		t.Fatalf("expected an error for duplicate port 443")
+	}
+	if expected := `API load balancer listeners "api-tcp-443" and "kops-controller" both use port 443`; err.Error() != expected {
+		t.Errorf("unexpected error, expected %q got %q", expected, err.Error())
+	}
+}
+
+func TestBuildClassicHealthCheckTarget(t *testing.T) {
+	grid := []struct {
+		name          string
+		primary       *awstasks.ClassicLoadBalancerListener
+		spec          *kops.LoadBalancerHealthCheckSpec
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "defaults",
+			expected: "SSL:443",
+		},
+		{
+			name:     "readiness endpoint",
+			spec:     &kops.LoadBalancerHealthCheckSpec{ReadinessEndpoint: true},
+			expected: "HTTP:3990/readyz",
+		},
+		{
+			name:     "readiness endpoint with port",
+			spec:     &kops.LoadBalancerHealthCheckSpec{ReadinessEndpoint: true, ReadinessEndpointPort: fi.PtrTo(int32(3991))},
+			expected: "HTTP:3991/readyz",
+		},
+		{
+			name:     "readiness endpoint disabled",
+			spec:     &kops.LoadBalancerHealthCheckSpec{ReadinessEndpointPort: fi.PtrTo(int32(3991))},
+			expected: "SSL:443",
+		},
+		{
+			name:     "path only",
+			spec:     &kops.LoadBalancerHealthCheckSpec{Path: fi.PtrTo("/healthz")},
+			expected: "HTTPS:443/healthz",
+		},
+		{
+			name:     "path only with TCP listener",
+			primary:  &awstasks.ClassicLoadBalancerListener{InstancePort: 443, InstanceProtocol: fi.PtrTo("tcp")},
+			spec:     &kops.LoadBalancerHealthCheckSpec{Path: fi.PtrTo("/healthz")},
+			expected: "HTTP:443/healthz",
+		},
+		{
+			name:     "port only",
+			spec:     &kops.LoadBalancerHealthCheckSpec{Port: fi.PtrTo(int32(8443))},
+			expected: "SSL:8443",
+		},
+		{
+			name:     "protocol and path",
+			spec:     &kops.LoadBalancerHealthCheckSpec{Protocol: fi.PtrTo("HTTP"), Path: fi.PtrTo("/healthz")},
+			expected: "HTTP:443/healthz",
+		},
+		{
+			name:          "protocol without path",
+			spec:          &kops.LoadBalancerHealthCheckSpec{Protocol: fi.PtrTo("HTTPS")},
+			expectedError: `invalid API load balancer health check target "HTTPS:443": health check path must start with / for protocol HTTPS`,
+		},
+		{
+			name:          "path with TCP protocol",
+			spec:          &kops.LoadBalancerHealthCheckSpec{Protocol: fi.PtrTo("TCP"), Path: fi.PtrTo("/healthz")},
+			expectedError: `invalid API load balancer health check target "TCP:443/healthz": health check path is not allowed for protocol TCP`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			b := &APILoadBalancerBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: buildMinimalCluster()},
+					},
+				},
+			}
+
+			primary := g.primary
+			if primary == nil {
+				primary = &awstasks.ClassicLoadBalancerListener{InstancePort: 443}
+			}
+			healthCheck, err := b.buildClassicHealthCheck(g.spec, primary)
+			if g.expectedError != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got target %q", g.expectedError, fi.ValueOf(healthCheck.Target))
+				}
+				if err.Error() != g.expectedError {
+					t.Errorf("unexpected error, expected %q got %q", g.expectedError, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := fi.ValueOf(healthCheck.Target); actual != g.expected {
+				t.Errorf("expected target %q, got %q", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHealthCheckNodePortRange(t *testing.T) {
+	grid := []struct {
+		name                 string
+		serviceNodePortRange string
+		spec                 *kops.LoadBalancerHealthCheckSpec
+		expected             string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "API server port",
+			spec: &kops.LoadBalancerHealthCheckSpec{Port: fi.PtrTo(int32(443))},
+		},
+		{
+			name:     "NodePort",
+			spec:     &kops.LoadBalancerHealthCheckSpec{Port: fi.PtrTo(int32(30443))},
+			expected: "30000-32767",
+		},
+		{
+			name:                 "NodePort in custom range",
+			serviceNodePortRange: "28000-28999",
+			spec:                 &kops.LoadBalancerHealthCheckSpec{Port: fi.PtrTo(int32(28443))},
+			expected:             "28000-28999",
+		},
+		{
+			name:                 "outside custom range",
+			serviceNodePortRange: "28000-28999",
+			spec:                 &kops.LoadBalancerHealthCheckSpec{Port: fi.PtrTo(int32(30443))},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := buildMinimalCluster()
+			if g.serviceNodePortRange != "" {
+				cluster.Spec.KubeAPIServer = &kops.KubeAPIServerConfig{ServiceNodePortRange: g.serviceNodePortRange}
+			}
+			b := &APILoadBalancerBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+					},
+				},
+			}
+
+			nodePortRange, err := b.healthCheckNodePortRange(g.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actual := ""
+			if nodePortRange != nil {
+				actual = nodePortRange.String()
+			}
+			if actual != g.expected {
+				t.Errorf("expected NodePort range %q, got %q", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBuildClassicHealthCheck(t *testing.T) {
+	grid := []struct {
+		name                       string
+		nodes                      int32
+		spec                       *kops.LoadBalancerHealthCheckSpec
+		expectedInterval           int32
+		expectedUnhealthyThreshold int32
+	}{
+		{
+			name:                       "defaults",
+			nodes:                      1000,
+			expectedInterval:           10,
+			expectedUnhealthyThreshold: 2,
+		},
+		{
+			name:                       "small cluster",
+			nodes:                      20,
+			spec:                       &kops.LoadBalancerHealthCheckSpec{AutoTune: true},
+			expectedInterval:           10,
+			expectedUnhealthyThreshold: 2,
+		},
+		{
+			name:                       "large cluster",
+			nodes:                      200,
+			spec:                       &kops.LoadBalancerHealthCheckSpec{AutoTune: true},
+			expectedInterval:           20,
+			expectedUnhealthyThreshold: 3,
+		},
+		{
+			name:                       "very large cluster",
+			nodes:                      1000,
+			spec:                       &kops.LoadBalancerHealthCheckSpec{AutoTune: true},
+			expectedInterval:           30,
+			expectedUnhealthyThreshold: 3,
+		},
+		{
+			name:                       "explicit interval",
+			nodes:                      1000,
+			spec:                       &kops.LoadBalancerHealthCheckSpec{AutoTune: true, IntervalSeconds: fi.PtrTo(int32(15))},
+			expectedInterval:           15,
+			expectedUnhealthyThreshold: 3,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			// Split the nodes across two groups, to check they are added up
+			igs := []*kops.InstanceGroup{
+				buildNodeInstanceGroup("subnet-us-test-1a"),
+				buildNodeInstanceGroup("subnet-us-test-1b"),
+			}
+			igs[0].Spec.MaxSize = fi.PtrTo(g.nodes / 2)
+			igs[1].Spec.MinSize = fi.PtrTo(g.nodes - g.nodes/2)
+
+			b := &APILoadBalancerBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: buildMinimalCluster()},
+						InstanceGroups:  igs,
+					},
+				},
+			}
+
+			healthCheck, err := b.buildClassicHealthCheck(g.spec, &awstasks.ClassicLoadBalancerListener{InstancePort: 443})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := fi.ValueOf(healthCheck.Interval); actual != g.expectedInterval {
+				t.Errorf("expected interval %d, got %d", g.expectedInterval, actual)
+			}
+			if actual := fi.ValueOf(healthCheck.UnhealthyThreshold); actual != g.expectedUnhealthyThreshold {
+				t.Errorf("expected unhealthy threshold %d, got %d", g.expectedUnhealthyThreshold, actual)
+			}
+			if actual := fi.ValueOf(healthCheck.Timeout); actual >= fi.ValueOf(healthCheck.Interval) {
+				t.Errorf("expected timeout %d to be shorter than the interval", actual)
+			}
+		})
+	}
+}
+
+func TestAPILoadBalancerSecurityGroupTags(t *testing.T) {
+	b := &APILoadBalancerBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{
+					Cluster: &kops.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+						Spec: kops.ClusterSpec{
+							CloudProvider: kops.CloudProviderSpec{
+								AWS: &kops.AWSSpec{},
+							},
+							CloudLabels: map[string]string{
+								"cost-center": "platform",
+								"team":        "infra",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	lbTags := b.apiLoadBalancerTags()
+	sgName := b.ELBSecurityGroupName("api")
+	sgTags := b.apiLoadBalancerSecurityGroupTags(sgName)
+
+	if sgTags["Name"] != sgName {
+		t.Errorf("expected security group Name tag %q, got %q", sgName, sgTags["Name"])
+	}
+	delete(sgTags, "Name")
+	delete(lbTags, "Name")
+	if !reflect.DeepEqual(sgTags, lbTags) {
+		t.Errorf("expected security group tags %v to match load balancer tags %v", sgTags, lbTags)
+	}
+	if lbTags["cost-center"] != "platform" || lbTags["kubernetes.io/cluster/minimal.example.com"] != "owned" {
+		t.Errorf("expected cluster and cost tags on the load balancer, got %v", lbTags)
+	}
+}
+
+func TestAPILoadBalancerTagsConflict(t *testing.T) {
+	b := &APILoadBalancerBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{
+					Cluster: &kops.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+						Spec: kops.ClusterSpec{
+							CloudProvider: kops.CloudProviderSpec{
+								AWS: &kops.AWSSpec{},
+							},
+							CloudLabels: map[string]string{
+								"KubernetesCluster": "other.example.com",
+								"Name":              "my-load-balancer",
+								"team":              "infra",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := map[string]string{
+		"KubernetesCluster":                         "other.example.com",
+		"kubernetes.io/cluster/minimal.example.com": "owned",
+		"Name": "api.minimal.example.com",
+		"team": "infra",
+	}
+	for i := 0; i < 10; i++ {
+		if actual := b.apiLoadBalancerTags(); !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected tags %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestAPILoadBalancerCrossZoneDefault(t *testing.T) {
+	grid := []struct {
+		name     string
+		zones    []string
+		explicit *bool
+		expected bool
+	}{
+		{
+			name:     "multiple zones",
+			zones:    []string{"us-test-1a", "us-test-1b", "us-test-1c"},
+			expected: true,
+		},
+		{
+			name:     "single zone",
+			zones:    []string{"us-test-1a"},
+			expected: false,
+		},
+		{
+			name:     "explicitly disabled across multiple zones",
+			zones:    []string{"us-test-1a", "us-test-1b"},
+			explicit: fi.PtrTo(false),
+			expected: false,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := buildMinimalCluster()
+			cluster.Spec.Networking.Subnets = nil
+			for _, zone := range g.zones {
+				cluster.Spec.Networking.Subnets = append(cluster.Spec.Networking.Subnets, kops.ClusterSubnetSpec{
+					Name: zone,
+					Zone: zone,
+					Type: kops.SubnetTypePublic,
+				})
+			}
+			cluster.Spec.API.LoadBalancer = &kops.LoadBalancerAccessSpec{
+				Class:                  kops.LoadBalancerClassClassic,
+				Type:                   kops.LoadBalancerTypePublic,
+				CrossZoneLoadBalancing: g.explicit,
+			}
+
+			b := &APILoadBalancerBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+					},
+				},
+				Lifecycle:         fi.LifecycleSync,
+				SecurityLifecycle: fi.LifecycleSync,
+			}
+			c := &fi.CloudupModelBuilderContext{
+				Tasks: make(map[string]fi.CloudupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error building API load balancer: %v", err)
+			}
+
+			clb, ok := c.Tasks["ClassicLoadBalancer/api.testcluster.test.com"].(*awstasks.ClassicLoadBalancer)
+			if !ok {
+				t.Fatalf("expected a ClassicLoadBalancer task, got tasks %v", c.Tasks)
+			}
+			if actual := fi.ValueOf(clb.CrossZoneLoadBalancing.Enabled); actual != g.expected {
+				t.Errorf("expected cross-zone load balancing %v, got %v", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAPILoadBalancerIdleTimeout(t *testing.T) {
+	grid := []struct {
+		name     string
+		explicit *int64
+		expected int32
+	}{
+		{
+			name:     "default",
+			expected: 300,
+		},
+		{
+			name:     "explicit",
+			explicit: fi.PtrTo(int64(1200)),
+			expected: 1200,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := buildMinimalCluster()
+			cluster.Spec.API.LoadBalancer = &kops.LoadBalancerAccessSpec{
+				Class:              kops.LoadBalancerClassClassic,
+				Type:               kops.LoadBalancerTypePublic,
+				IdleTimeoutSeconds: g.explicit,
+			}
+
+			b := &APILoadBalancerBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+					},
+				},
+				Lifecycle:         fi.LifecycleSync,
+				SecurityLifecycle: fi.LifecycleSync,
+			}
+			c := &fi.CloudupModelBuilderContext{
+				Tasks: make(map[string]fi.CloudupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error building API load balancer: %v", err)
+			}
+
+			clb, ok := c.Tasks["ClassicLoadBalancer/api.testcluster.test.com"].(*awstasks.ClassicLoadBalancer)
+			if !ok {
+				t.Fatalf("expected a ClassicLoadBalancer task, got tasks %v", c.Tasks)
+			}
+			if actual := fi.ValueOf(clb.ConnectionSettings.IdleTimeout); actual != g.expected {
+				t.Errorf("expected idle timeout %d, got %d", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAPILoadBalancerAllowReplaceOnNameChange(t *testing.T) {
+	for _, allowReplace := range []bool{false, true} {
+		t.Run(fmt.Sprintf("allowReplaceOnNameChange=%v", allowReplace), func(t *testing.T) {
+			cluster := buildMinimalCluster()
+			cluster.Spec.API.LoadBalancer = &kops.LoadBalancerAccessSpec{
+				Class:                    kops.LoadBalancerClassClassic,
+				Type:                     kops.LoadBalancerTypePublic,
+				AllowReplaceOnNameChange: allowReplace,
+			}
+
+			b := &APILoadBalancerBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+					},
+				},
+				Lifecycle:         fi.LifecycleSync,
+				SecurityLifecycle: fi.LifecycleSync,
+			}
+			c := &fi.CloudupModelBuilderContext{
+				Tasks: make(map[string]fi.CloudupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error building API load balancer: %v", err)
+			}
+
+			clb, ok := c.Tasks["ClassicLoadBalancer/api.testcluster.test.com"].(*awstasks.ClassicLoadBalancer)
+			if !ok {
+				t.Fatalf("expected a ClassicLoadBalancer task, got tasks %v", c.Tasks)
+			}
+			if actual := fi.ValueOf(clb.AllowReplaceOnNameChange); actual != allowReplace {
+				t.Errorf("expected AllowReplaceOnNameChange %v, got %v", allowReplace, actual)
+			}
+		})
+	}
+}
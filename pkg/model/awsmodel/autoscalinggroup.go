@@ -18,7 +18,9 @@ package awsmodel
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/defaults"
+	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
@@ -58,6 +61,7 @@ type AutoscalingGroupModelBuilder struct {
 	Lifecycle              fi.Lifecycle
 	SecurityLifecycle      fi.Lifecycle
 	Cluster                *kops.Cluster
+	Cloud                  awsup.AWSCloud
 }
 
 var _ fi.CloudupModelBuilder = &AutoscalingGroupModelBuilder{}
@@ -452,6 +456,16 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 	}
 	t.Tags = tags
 
+	if ig.Spec.MixedInstancesPolicy != nil && ig.Spec.Manager == kops.InstanceManagerCloudGroup && b.IsClusterAutoscalerNodeGroup(ig) {
+		resources, err := b.buildMixedInstancesNodeTemplateResources(ig)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range resources {
+			t.Tags[k] = v
+		}
+	}
+
 	processes := []string{}
 	processes = append(processes, ig.Spec.SuspendProcesses...)
 	t.SuspendProcesses = &processes
@@ -499,6 +513,7 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 				LoadBalancerName: extLB.LoadBalancerName,
 				Shared:           fi.PtrTo(true),
 			}
+			lb.SetClusterZones(b.clusterZones())
 			t.LoadBalancers = append(t.LoadBalancers, lb)
 			c.EnsureTask(lb)
 		}
@@ -587,3 +602,50 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 	}
 	return t, nil
 }
+
+// buildMixedInstancesNodeTemplateResources returns the cluster autoscaler node-template resource tags for a mixed instances
+// InstanceGroup. The autoscaler builds its template node from a single instance type, so we advertise the smallest cpu and
+// memory of all the instance types in the policy; any pod that fits the template then fits whichever type the ASG launches.
+func (b *AutoscalingGroupModelBuilder) buildMixedInstancesNodeTemplateResources(ig *kops.InstanceGroup) (map[string]string, error) {
+	spec := ig.Spec.MixedInstancesPolicy
+	resources := make(map[string]string)
+
+	if len(spec.Instances) == 0 {
+		// Without a list of instance types, the lower bounds of the instance requirements are the best we can do
+		if ir := spec.InstanceRequirements; ir != nil {
+			if ir.CPU != nil && ir.CPU.Min != nil {
+				resources[nodeidentityaws.ClusterAutoscalerNodeTemplateResources+"cpu"] = ir.CPU.Min.String()
+			}
+			if ir.Memory != nil && ir.Memory.Min != nil {
+				resources[nodeidentityaws.ClusterAutoscalerNodeTemplateResources+"memory"] = ir.Memory.Min.String()
+			}
+		}
+		return resources, nil
+	}
+
+	var cpu int32
+	var memoryMiB int64
+	for _, instanceTypes := range spec.Instances {
+		for _, instanceType := range strings.Split(instanceTypes, ",") {
+			info, err := awsup.GetMachineTypeInfo(b.Cloud, ec2types.InstanceType(instanceType))
+			if err != nil {
+				return nil, fmt.Errorf("error getting machine type info for %q in InstanceGroup %q: %w", instanceType, ig.Name, err)
+			}
+			if cpu == 0 || info.Cores < cpu {
+				cpu = info.Cores
+			}
+			mib := int64(math.Round(float64(info.MemoryGB) * 1024))
+			if memoryMiB == 0 || mib < memoryMiB {
+				memoryMiB = mib
+			}
+		}
+	}
+
+	if cpu > 0 {
+		resources[nodeidentityaws.ClusterAutoscalerNodeTemplateResources+"cpu"] = strconv.Itoa(int(cpu))
+	}
+	if memoryMiB > 0 {
+		resources[nodeidentityaws.ClusterAutoscalerNodeTemplateResources+"memory"] = fmt.Sprintf("%dMi", memoryMiB)
+	}
+	return resources, nil
+}
@@ -18,8 +18,13 @@ package awsmodel
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/model"
@@ -27,6 +32,7 @@ import (
 	"k8s.io/kops/pkg/testutils"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/fitasks"
 )
 
@@ -122,6 +128,118 @@ func TestRootVolumeOptimizationFlag(t *testing.T) {
 	}
 }
 
+// instanceTypesCloud is a mock cloud which describes instance types from a fixed table
+type instanceTypesCloud struct {
+	*awsup.MockAWSCloud
+	instanceTypes map[string]*ec2types.InstanceTypeInfo
+}
+
+func (c *instanceTypesCloud) DescribeInstanceType(instanceType string) (*ec2types.InstanceTypeInfo, error) {
+	info, ok := c.instanceTypes[instanceType]
+	if !ok {
+		return nil, fmt.Errorf("invalid instance type %q specified", instanceType)
+	}
+	return info, nil
+}
+
+func TestMixedInstancesNodeTemplateResources(t *testing.T) {
+	instanceType := func(vcpus int32, memoryMiB int64) *ec2types.InstanceTypeInfo {
+		return &ec2types.InstanceTypeInfo{
+			NetworkInfo: &ec2types.NetworkInfo{
+				MaximumNetworkInterfaces:  aws.Int32(1),
+				Ipv4AddressesPerInterface: aws.Int32(1),
+			},
+			MemoryInfo: &ec2types.MemoryInfo{SizeInMiB: aws.Int64(memoryMiB)},
+			VCpuInfo:   &ec2types.VCpuInfo{DefaultVCpus: aws.Int32(vcpus)},
+		}
+	}
+	cloud := &instanceTypesCloud{
+		MockAWSCloud: awsup.BuildMockAWSCloud("us-test-1", "a"),
+		instanceTypes: map[string]*ec2types.InstanceTypeInfo{
+			"mixed.large":   instanceType(2, 8192),
+			"mixed.xlarge":  instanceType(4, 16384),
+			"mixed.compute": instanceType(4, 4096),
+		},
+	}
+
+	grid := []struct {
+		name                 string
+		clusterAutoscaler    bool
+		instances            []string
+		instanceRequirements *kops.InstanceRequirementsSpec
+		expected             map[string]string
+	}{
+		{
+			name:              "smallest cpu and memory of the instance types",
+			clusterAutoscaler: true,
+			instances:         []string{"mixed.xlarge", "mixed.large", "mixed.compute"},
+			expected: map[string]string{
+				"k8s.io/cluster-autoscaler/node-template/resources/cpu":    "2",
+				"k8s.io/cluster-autoscaler/node-template/resources/memory": "4096Mi",
+			},
+		},
+		{
+			name:              "lower bounds of the instance requirements",
+			clusterAutoscaler: true,
+			instanceRequirements: &kops.InstanceRequirementsSpec{
+				CPU:    &kops.MinMaxSpec{Min: resource.NewQuantity(2, resource.DecimalSI)},
+				Memory: &kops.MinMaxSpec{Min: fi.PtrTo(resource.MustParse("4G"))},
+			},
+			expected: map[string]string{
+				"k8s.io/cluster-autoscaler/node-template/resources/cpu":    "2",
+				"k8s.io/cluster-autoscaler/node-template/resources/memory": "4G",
+			},
+		},
+		{
+			name:      "cluster autoscaler disabled",
+			instances: []string{"mixed.xlarge", "mixed.large"},
+			expected:  map[string]string{},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := buildMinimalCluster()
+			if g.clusterAutoscaler {
+				cluster.Spec.ClusterAutoscaler = &kops.ClusterAutoscalerConfig{Enabled: fi.PtrTo(true)}
+			}
+
+			ig := buildNodeInstanceGroup("subnet-us-test-1a")
+			ig.Spec.Manager = kops.InstanceManagerCloudGroup
+			ig.Spec.MixedInstancesPolicy = &kops.MixedInstancesPolicySpec{
+				Instances:            g.instances,
+				InstanceRequirements: g.instanceRequirements,
+			}
+
+			b := AutoscalingGroupModelBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+						InstanceGroups:  []*kops.InstanceGroup{ig},
+					},
+				},
+				Cluster: cluster,
+				Cloud:   cloud,
+			}
+
+			asg, err := b.buildAutoScalingGroupTask(&fi.CloudupModelBuilderContext{}, b.AutoscalingGroupName(ig), ig)
+			if err != nil {
+				t.Fatalf("error building autoscaling group: %v", err)
+			}
+
+			actual := make(map[string]string)
+			for k, v := range asg.Tags {
+				if strings.HasPrefix(k, "k8s.io/cluster-autoscaler/node-template/resources/") && k != "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage" {
+					actual[k] = v
+				}
+			}
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("unexpected node-template resource tags, expected %v, got %v", g.expected, actual)
+			}
+		})
+	}
+}
+
 func TestAPIServerAdditionalSecurityGroupsWithNLB(t *testing.T) {
 	const sgIDAPIServer = "sg-01234567890abcdef"
 
@@ -328,6 +328,8 @@ func (b *BastionModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		// Override the returned name to be the expected ELB name
 		tags["Name"] = "bastion." + b.ClusterName()
 
+		// NLB listeners have a fixed TCP idle timeout of 350 seconds, so unlike the API ELB there is no idle timeout
+		// to default here; long-lived SSH sessions should rely on keepalives.
 		nlbListener := &awstasks.NetworkLoadBalancerListener{
 			Name:                fi.PtrTo(b.NLBListenerName("bastion", 22)),
 			Lifecycle:           b.Lifecycle,
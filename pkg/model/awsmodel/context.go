@@ -19,6 +19,7 @@ package awsmodel
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
@@ -35,6 +36,17 @@ func (b *AWSModelContext) LinkToSubnet(z *kops.ClusterSubnetSpec) *awstasks.Subn
 	return &awstasks.Subnet{Name: &name}
 }
 
+// clusterZones returns the sorted, de-duplicated zones of the cluster subnets.
+func (b *AWSModelContext) clusterZones() []string {
+	zones := sets.New[string]()
+	for _, subnet := range b.Cluster.Spec.Networking.Subnets {
+		if subnet.Zone != "" {
+			zones.Insert(subnet.Zone)
+		}
+	}
+	return sets.List(zones)
+}
+
 func (b *AWSModelContext) LinkToPublicSubnetInZone(zoneName string) (*awstasks.Subnet, error) {
 	var matches []*kops.ClusterSubnetSpec
 	for i := range b.Cluster.Spec.Networking.Subnets {
@@ -24,7 +24,10 @@ import (
 
 // ServiceAccount represents the service account used by the cluster autoscaler.
 // It implements iam.Subject to get AWS IAM permissions.
-type ServiceAccount struct{}
+type ServiceAccount struct {
+	// Namespace is the namespace the cluster autoscaler runs in; defaults to kube-system.
+	Namespace string
+}
 
 var _ iam.Subject = &ServiceAccount{}
 
@@ -44,8 +47,12 @@ func (r *ServiceAccount) BuildAWSPolicy(b *iam.PolicyBuilder) (*iam.Policy, erro
 
 // ServiceAccount returns the kubernetes service account used.
 func (r *ServiceAccount) ServiceAccount() (types.NamespacedName, bool) {
+	namespace := r.Namespace
+	if namespace == "" {
+		namespace = "kube-system"
+	}
 	return types.NamespacedName{
-		Namespace: "kube-system",
+		Namespace: namespace,
 		Name:      "cluster-autoscaler",
 	}, true
 }
@@ -98,6 +98,22 @@ func (b *ClusterAutoscalerOptionsBuilder) BuildOptions(o interface{}) error {
 	if cas.ScaleDownUnreadyTime == nil {
 		cas.ScaleDownUnreadyTime = fi.PtrTo("20m0s")
 	}
+	if cas.WriteStatusConfigMap == nil {
+		cas.WriteStatusConfigMap = fi.PtrTo(true)
+	}
+	if cas.StatusConfigMapName == nil {
+		cas.StatusConfigMapName = fi.PtrTo("cluster-autoscaler-status")
+	}
+	if cas.Namespace == nil {
+		cas.Namespace = fi.PtrTo("kube-system")
+	}
+	if cas.LivenessProbe == nil {
+		cas.LivenessProbe = &kops.ClusterAutoscalerProbeSpec{}
+	}
+	setClusterAutoscalerProbeDefaults(cas.LivenessProbe)
+	if cas.ReadinessProbe != nil {
+		setClusterAutoscalerProbeDefaults(cas.ReadinessProbe)
+	}
 	if cas.MaxNodeProvisionTime == "" {
 		cas.MaxNodeProvisionTime = "15m0s"
 	}
@@ -107,3 +123,16 @@ func (b *ClusterAutoscalerOptionsBuilder) BuildOptions(o interface{}) error {
 
 	return nil
 }
+
+// setClusterAutoscalerProbeDefaults fills in any unset probe values with the defaults used by the addon.
+func setClusterAutoscalerProbeDefaults(probe *kops.ClusterAutoscalerProbeSpec) {
+	if probe.TimeoutSeconds == nil {
+		probe.TimeoutSeconds = fi.PtrTo(int32(1))
+	}
+	if probe.PeriodSeconds == nil {
+		probe.PeriodSeconds = fi.PtrTo(int32(10))
+	}
+	if probe.FailureThreshold == nil {
+		probe.FailureThreshold = fi.PtrTo(int32(3))
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestClusterAutoscalerImageDigest(t *testing.T) {
+	image := "registry.k8s.io/autoscaling/cluster-autoscaler@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	c := buildCluster()
+	c.Spec.ClusterAutoscaler = &kops.ClusterAutoscalerConfig{
+		Enabled: fi.PtrTo(true),
+		Image:   fi.PtrTo(image),
+	}
+
+	b := &ClusterAutoscalerOptionsBuilder{
+		OptionsContext: &OptionsContext{},
+	}
+	if err := b.BuildOptions(&c.Spec); err != nil {
+		t.Fatalf("unexpected error from BuildOptions: %v", err)
+	}
+	if actual := fi.ValueOf(c.Spec.ClusterAutoscaler.Image); actual != image {
+		t.Errorf("expected image %q, got %q", image, actual)
+	}
+
+	assetBuilder := assets.NewAssetBuilder(vfs.Context, c.Spec.Assets, c.Spec.KubernetesVersion, false)
+	remapped, err := assetBuilder.RemapImage(image)
+	if err != nil {
+		t.Fatalf("unexpected error from RemapImage: %v", err)
+	}
+	if remapped != image {
+		t.Errorf("expected image %q to pass through unmodified, got %q", image, remapped)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model/components"
+	"k8s.io/kops/pkg/model/defaults"
 	"k8s.io/kops/pkg/model/iam"
 	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	"k8s.io/kops/pkg/nodelabels"
@@ -40,10 +41,6 @@ import (
 	"k8s.io/klog/v2"
 )
 
-const (
-	clusterAutoscalerNodeTemplateTaint = "k8s.io/cluster-autoscaler/node-template/taint/"
-)
-
 // KopsModelContext is the kops model
 type KopsModelContext struct {
 	iam.IAMModelContext
@@ -169,7 +166,28 @@ func (b *KopsModelContext) CloudTagsForInstanceGroup(ig *kops.InstanceGroup) (ma
 	for _, v := range ig.Spec.Taints {
 		splits := strings.SplitN(v, "=", 2)
 		if len(splits) > 1 {
-			labels[clusterAutoscalerNodeTemplateTaint+splits[0]] = splits[1]
+			labels[nodeidentityaws.ClusterAutoscalerNodeTemplateTaint+splits[0]] = splits[1]
+		} else if key, effect, found := strings.Cut(v, ":"); found {
+			// Taints without a value, e.g. "dedicated:NoSchedule"
+			labels[nodeidentityaws.ClusterAutoscalerNodeTemplateTaint+key] = ":" + effect
+		}
+	}
+
+	// Apply labels for cluster autoscaler node resources, so that groups can be scaled up from zero
+	if b.IsClusterAutoscalerNodeGroup(ig) && b.Cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		rootVolumeSize, err := defaults.DefaultInstanceGroupVolumeSize(ig.Spec.Role)
+		if err != nil {
+			return nil, err
+		}
+		if ig.Spec.RootVolume != nil && fi.ValueOf(ig.Spec.RootVolume.Size) > 0 {
+			rootVolumeSize = fi.ValueOf(ig.Spec.RootVolume.Size)
+		}
+		labels[nodeidentityaws.ClusterAutoscalerNodeTemplateResources+"ephemeral-storage"] = fmt.Sprintf("%dGi", rootVolumeSize)
+
+		// Groups with externally-managed bounds are auto-discovered by the cluster autoscaler, rather than passed with --nodes
+		if b.HasClusterAutoscalerExternalBounds(ig) {
+			labels[nodeidentityaws.ClusterAutoscalerAutoDiscoveryEnabled] = "true"
+			labels[nodeidentityaws.ClusterAutoscalerAutoDiscoveryClusterPrefix+b.Cluster.ObjectMeta.Name] = "owned"
 		}
 	}
 
@@ -197,6 +215,27 @@ func (b *KopsModelContext) CloudTagsForInstanceGroup(ig *kops.InstanceGroup) (ma
 	return labels, nil
 }
 
+// IsClusterAutoscalerNodeGroup returns true if the cluster autoscaler is enabled and manages the InstanceGroup
+func (b *KopsModelContext) IsClusterAutoscalerNodeGroup(ig *kops.InstanceGroup) bool {
+	ca := b.Cluster.Spec.ClusterAutoscaler
+	if ca == nil || !fi.ValueOf(ca.Enabled) {
+		return false
+	}
+	return ig.Spec.Role == kops.InstanceGroupRoleNode && (ig.Spec.Autoscale == nil || fi.ValueOf(ig.Spec.Autoscale))
+}
+
+// HasClusterAutoscalerExternalBounds returns true if the cluster autoscaler should honor the externally-managed
+// min/max bounds of the InstanceGroup, instead of the ones set in the InstanceGroup spec
+func (b *KopsModelContext) HasClusterAutoscalerExternalBounds(ig *kops.InstanceGroup) bool {
+	if !b.IsClusterAutoscalerNodeGroup(ig) {
+		return false
+	}
+	if !fi.ValueOf(b.Cluster.Spec.ClusterAutoscaler.ExternalNodeGroupBounds) {
+		return false
+	}
+	return ig.Annotations[kops.AnnotationNameClusterAutoscalerExternalBounds] == "true"
+}
+
 func (b *KopsModelContext) CloudTagsForServiceAccount(name string, sa types.NamespacedName) map[string]string {
 	tags := b.CloudTags(name, false)
 	tags[awstasks.CloudTagServiceAccountName] = sa.Name
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestCloudTagsForInstanceGroupClusterAutoscaler(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			CloudProvider:     kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			ClusterAutoscaler: &kops.ClusterAutoscalerConfig{Enabled: fi.PtrTo(true)},
+		},
+	}
+	ig := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+		Spec: kops.InstanceGroupSpec{
+			Role:       kops.InstanceGroupRoleNode,
+			MinSize:    fi.PtrTo(int32(0)),
+			MaxSize:    fi.PtrTo(int32(5)),
+			NodeLabels: map[string]string{"example.com/gpu": "true"},
+			Taints:     []string{"dedicated=gpu:NoSchedule", "nvidia.com/gpu:NoExecute"},
+			RootVolume: &kops.InstanceRootVolumeSpec{Size: fi.PtrTo(int32(200))},
+		},
+	}
+
+	b := &KopsModelContext{
+		IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+		InstanceGroups:  []*kops.InstanceGroup{ig},
+	}
+
+	tags, err := b.CloudTagsForInstanceGroup(ig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"k8s.io/cluster-autoscaler/node-template/label/example.com/gpu":              "true",
+		"k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node": "",
+		"k8s.io/cluster-autoscaler/node-template/taint/dedicated":                    "gpu:NoSchedule",
+		"k8s.io/cluster-autoscaler/node-template/taint/nvidia.com/gpu":               ":NoExecute",
+		"k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage":        "200Gi",
+	}
+	for k, v := range expected {
+		actual, found := tags[k]
+		if !found {
+			t.Errorf("expected tag %q to be set, got %v", k, tags)
+		} else if actual != v {
+			t.Errorf("unexpected value for tag %q, expected %q got %q", k, v, actual)
+		}
+	}
+
+	ig.Spec.Autoscale = fi.PtrTo(false)
+	tags, err = b.CloudTagsForInstanceGroup(ig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, found := tags["k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"]; found {
+		t.Errorf("expected no node-template resources for a group not managed by the autoscaler, got %v", tags)
+	}
+}
+
+func TestCloudTagsForInstanceGroupSpotAllocationStrategy(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			CloudProvider:     kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			ClusterAutoscaler: &kops.ClusterAutoscalerConfig{Enabled: fi.PtrTo(true)},
+		},
+	}
+
+	grid := []struct {
+		strategy string
+		expected string
+	}{
+		{strategy: kops.SpotAllocationStrategyCapacityOptimized, expected: "capacity-optimized"},
+		{strategy: kops.SpotAllocationStrategyPriceCapacityOptimized, expected: "price-capacity-optimized"},
+		{strategy: kops.SpotAllocationStrategyLowestPrices},
+	}
+	for _, g := range grid {
+		t.Run(g.strategy, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "spot"},
+				Spec: kops.InstanceGroupSpec{
+					Role:    kops.InstanceGroupRoleNode,
+					Manager: kops.InstanceManagerCloudGroup,
+					MinSize: fi.PtrTo(int32(0)),
+					MaxSize: fi.PtrTo(int32(5)),
+					MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+						Instances:              []string{"m5.large", "m5a.large"},
+						SpotAllocationStrategy: fi.PtrTo(g.strategy),
+					},
+				},
+			}
+
+			b := &KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+				InstanceGroups:  []*kops.InstanceGroup{ig},
+			}
+
+			tags, err := b.CloudTagsForInstanceGroup(ig)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			actual, found := tags["k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/spot-allocation-strategy"]
+			if g.expected == "" {
+				if found {
+					t.Errorf("expected no spot allocation strategy tag, got %q", actual)
+				}
+			} else if actual != g.expected {
+				t.Errorf("unexpected spot allocation strategy tag, expected %q got %q (found=%v)", g.expected, actual, found)
+			}
+		})
+	}
+}
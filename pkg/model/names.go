@@ -93,6 +93,16 @@ func (b *KopsModelContext) LBName32(prefix string) string {
 	return awsup.GetResourceName32(b.Cluster.ObjectMeta.Name, prefix)
 }
 
+// CLBName32 will calculate the name for a classic ELB given a prefix, honoring the configured naming strategy.
+// Will never return a string longer than 32 chars
+func (b *KopsModelContext) CLBName32(prefix string) string {
+	var strategy kops.LoadBalancerNamingStrategy
+	if b.Cluster.Spec.API.LoadBalancer != nil {
+		strategy = b.Cluster.Spec.API.LoadBalancer.NamingStrategy
+	}
+	return awsup.GetLoadBalancerName32(strategy, b.Cluster.ObjectMeta.Name, prefix)
+}
+
 // CLBName returns CLB name plus cluster name
 func (b *KopsModelContext) CLBName(prefix string) string {
 	return prefix + "." + b.ClusterName()
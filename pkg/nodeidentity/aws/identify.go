@@ -41,6 +41,14 @@ const (
 	CloudTagInstanceGroupName = "kops.k8s.io/instancegroup"
 	// ClusterAutoscalerNodeTemplateLabel is the prefix used on node labels when copying to cloud tags.
 	ClusterAutoscalerNodeTemplateLabel = "k8s.io/cluster-autoscaler/node-template/label/"
+	// ClusterAutoscalerNodeTemplateTaint is the prefix used on node taints when copying to cloud tags.
+	ClusterAutoscalerNodeTemplateTaint = "k8s.io/cluster-autoscaler/node-template/taint/"
+	// ClusterAutoscalerNodeTemplateResources is the prefix used on node resources when copying to cloud tags.
+	ClusterAutoscalerNodeTemplateResources = "k8s.io/cluster-autoscaler/node-template/resources/"
+	// ClusterAutoscalerAutoDiscoveryEnabled is the tag used by the cluster autoscaler to auto-discover node groups.
+	ClusterAutoscalerAutoDiscoveryEnabled = "k8s.io/cluster-autoscaler/enabled"
+	// ClusterAutoscalerAutoDiscoveryClusterPrefix is the prefix of the tag scoping auto-discovered node groups to a cluster.
+	ClusterAutoscalerAutoDiscoveryClusterPrefix = "k8s.io/cluster-autoscaler/"
 	// The expiration time of nodeidentity.Info cache.
 	cacheTTL           = 60 * time.Minute
 	KarpenterNodeLabel = "karpenter.sh/"
@@ -29,6 +29,10 @@ const (
 	RoleLabelNode16      = "node-role.kubernetes.io/node"
 
 	RoleLabelControlPlane20 = "node-role.kubernetes.io/control-plane"
+
+	// LabelSpotAllocationStrategy is set to the spot allocation strategy of nodes in capacity-optimized groups.
+	// It is also copied to the cluster autoscaler node template, so that expanders can prefer these groups.
+	LabelSpotAllocationStrategy = "kops.k8s.io/spot-allocation-strategy"
 )
 
 // BuildNodeLabels returns the node labels for the specified instance group
@@ -93,6 +97,12 @@ func BuildNodeLabels(cluster *api.Cluster, instanceGroup *api.InstanceGroup) (ma
 		}
 	}
 
+	if isNode && instanceGroup.Spec.Manager == api.InstanceManagerCloudGroup {
+		if strategy, ok := capacityOptimizedSpotAllocationStrategy(instanceGroup); ok {
+			nodeLabels[LabelSpotAllocationStrategy] = strategy
+		}
+	}
+
 	for k, v := range instanceGroup.Spec.NodeLabels {
 		if nodeLabels == nil {
 			nodeLabels = make(map[string]string)
@@ -107,6 +117,21 @@ func BuildNodeLabels(cluster *api.Cluster, instanceGroup *api.InstanceGroup) (ma
 	return nodeLabels, nil
 }
 
+// capacityOptimizedSpotAllocationStrategy returns the spot allocation strategy of the instance group,
+// if it launches spot instances from the pools with the most spare capacity.
+func capacityOptimizedSpotAllocationStrategy(instanceGroup *api.InstanceGroup) (string, bool) {
+	policy := instanceGroup.Spec.MixedInstancesPolicy
+	if policy == nil || policy.SpotAllocationStrategy == nil {
+		return "", false
+	}
+	switch strategy := *policy.SpotAllocationStrategy; strategy {
+	case api.SpotAllocationStrategyCapacityOptimized, api.SpotAllocationStrategyCapacityOptimizedPrioritized, api.SpotAllocationStrategyPriceCapacityOptimized:
+		return strategy, true
+	default:
+		return "", false
+	}
+}
+
 // BuildMandatoryControlPlaneLabels returns the list of labels all CP nodes must have
 func BuildMandatoryControlPlaneLabels() map[string]string {
 	nodeLabels := make(map[string]string)
@@ -1451,6 +1451,11 @@ func DeleteELB(cloud fi.Cloud, r *resources.Resource) error {
 	_, err := c.ELB().DeleteLoadBalancer(ctx, request)
 	if err != nil {
 		if IsDependencyViolation(err) {
+			if deps, derr := DescribeELBDependencies(cloud, id); derr != nil {
+				klog.Warningf("unable to list dependencies of ELB %q: %v", id, derr)
+			} else if !deps.IsEmpty() {
+				klog.Infof("ELB %q is still referenced by %s", id, deps)
+			}
 			return err
 		}
 		return fmt.Errorf("error deleting LoadBalancer %q: %v", id, err)
@@ -1458,6 +1463,72 @@ func DeleteELB(cloud fi.Cloud, r *resources.Resource) error {
 	return nil
 }
 
+// ELBDependencies lists the resources that still reference a classic load balancer.
+type ELBDependencies struct {
+	// NetworkInterfaces are the IDs of the network interfaces the load balancer still holds.
+	NetworkInterfaces []string
+	// AutoScalingGroups are the names of the autoscaling groups attached to the load balancer.
+	AutoScalingGroups []string
+}
+
+// IsEmpty returns true if nothing references the load balancer.
+func (d *ELBDependencies) IsEmpty() bool {
+	return len(d.NetworkInterfaces) == 0 && len(d.AutoScalingGroups) == 0
+}
+
+func (d *ELBDependencies) String() string {
+	var parts []string
+	for _, id := range d.NetworkInterfaces {
+		parts = append(parts, string(ec2types.ResourceTypeNetworkInterface)+":"+id)
+	}
+	for _, name := range d.AutoScalingGroups {
+		parts = append(parts, "autoscaling-group:"+name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DescribeELBDependencies reports the network interfaces and cluster autoscaling groups
+// that still reference the named classic load balancer, without deleting anything.
+func DescribeELBDependencies(cloud fi.Cloud, name string) (*ELBDependencies, error) {
+	ctx := context.TODO()
+	c := cloud.(awsup.AWSCloud)
+
+	deps := &ELBDependencies{}
+
+	klog.V(2).Infof("Listing network interfaces of ELB %q", name)
+	request := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []ec2types.Filter{
+			awsup.NewEC2Filter("requester-id", "amazon-elb"),
+			awsup.NewEC2Filter("description", "ELB "+name),
+		},
+	}
+	paginator := ec2.NewDescribeNetworkInterfacesPaginator(c.EC2(), request)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing network interfaces of ELB %q: %v", name, err)
+		}
+		for _, eni := range page.NetworkInterfaces {
+			deps.NetworkInterfaces = append(deps.NetworkInterfaces, aws.ToString(eni.NetworkInterfaceId))
+		}
+	}
+
+	asgs, err := awsup.FindAutoscalingGroups(c, c.Tags())
+	if err != nil {
+		return nil, err
+	}
+	for _, asg := range asgs {
+		for _, lbName := range asg.LoadBalancerNames {
+			if lbName == name {
+				deps.AutoScalingGroups = append(deps.AutoScalingGroups, aws.ToString(asg.AutoScalingGroupName))
+				break
+			}
+		}
+	}
+
+	return deps, nil
+}
+
 func DeleteELBV2(cloud fi.Cloud, r *resources.Resource) error {
 	ctx := context.TODO()
 	c := cloud.(awsup.AWSCloud)
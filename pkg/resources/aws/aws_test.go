@@ -23,10 +23,12 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
 	"k8s.io/kops/cloudmock/aws/mockec2"
 	"k8s.io/kops/cloudmock/aws/mockiam"
 	"k8s.io/kops/pkg/resources"
@@ -402,3 +404,88 @@ func TestMatchesElbTags(t *testing.T) {
 		}
 	}
 }
+
+// eniMockEC2 serves network interfaces filtered by description, which the shared mock does not implement.
+type eniMockEC2 struct {
+	*mockec2.MockEC2
+	networkInterfaces []ec2types.NetworkInterface
+}
+
+func (m *eniMockEC2) DescribeNetworkInterfaces(ctx context.Context, request *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	output := &ec2.DescribeNetworkInterfacesOutput{}
+	for _, eni := range m.networkInterfaces {
+		match := true
+		for _, filter := range request.Filters {
+			if aws.ToString(filter.Name) == "description" && filter.Values[0] != aws.ToString(eni.Description) {
+				match = false
+			}
+		}
+		if match {
+			output.NetworkInterfaces = append(output.NetworkInterfaces, eni)
+		}
+	}
+	return output, nil
+}
+
+func TestDescribeELBDependencies(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockEC2 = &eniMockEC2{
+		MockEC2: &mockec2.MockEC2{},
+		networkInterfaces: []ec2types.NetworkInterface{
+			{
+				NetworkInterfaceId: aws.String("eni-lingering"),
+				Description:        aws.String("ELB api-me-example-com"),
+			},
+			{
+				NetworkInterfaceId: aws.String("eni-other"),
+				Description:        aws.String("ELB other"),
+			},
+		},
+	}
+	cloud.MockAutoscaling = &mockautoscaling.MockAutoscaling{
+		Groups: map[string]*autoscalingtypes.AutoScalingGroup{
+			"master-us-east-1a": {
+				AutoScalingGroupName: aws.String("master-us-east-1a"),
+				LoadBalancerNames:    []string{"api-me-example-com"},
+				Tags: []autoscalingtypes.TagDescription{
+					{
+						Key:          aws.String(awsup.TagClusterName),
+						Value:        aws.String("me.example.com"),
+						ResourceId:   aws.String("master-us-east-1a"),
+						ResourceType: aws.String("auto-scaling-group"),
+					},
+				},
+			},
+			"nodes-us-east-1a": {
+				AutoScalingGroupName: aws.String("nodes-us-east-1a"),
+				Tags: []autoscalingtypes.TagDescription{
+					{
+						Key:          aws.String(awsup.TagClusterName),
+						Value:        aws.String("me.example.com"),
+						ResourceId:   aws.String("nodes-us-east-1a"),
+						ResourceType: aws.String("auto-scaling-group"),
+					},
+				},
+			},
+		},
+	}
+
+	deps, err := DescribeELBDependencies(cloud, "api-me-example-com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &ELBDependencies{
+		NetworkInterfaces: []string{"eni-lingering"},
+		AutoScalingGroups: []string{"master-us-east-1a"},
+	}
+	if !reflect.DeepEqual(expected, deps) {
+		t.Fatalf("expected=%v, actual=%v", expected, deps)
+	}
+	if deps.IsEmpty() {
+		t.Fatalf("expected dependencies to be reported")
+	}
+	if actual := deps.String(); actual != "network-interface:eni-lingering, autoscaling-group:master-us-east-1a" {
+		t.Fatalf("unexpected report %q", actual)
+	}
+}
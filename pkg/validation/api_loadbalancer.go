@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// instanceOutOfServiceState is the state of an instance that is failing the ELB health check
+const instanceOutOfServiceState = "OutOfService"
+
+// collectAPILoadBalancerFailures reports the instances that the Classic API load balancer has taken out of service,
+// with the reason given by AWS, so that an unhealthy API load balancer can be diagnosed from the validation output.
+func (v *ValidationCluster) collectAPILoadBalancerFailures(ctx context.Context, cloud awsup.AWSCloud, cluster *kops.Cluster) error {
+	lb, err := cloud.FindELBByNameTag("api." + cluster.ObjectMeta.Name)
+	if err != nil {
+		return fmt.Errorf("error finding API load balancer: %w", err)
+	}
+	if lb == nil {
+		return nil
+	}
+	loadBalancerName := aws.ToString(lb.LoadBalancerName)
+
+	response, err := cloud.ELB().DescribeInstanceHealth(ctx, &elb.DescribeInstanceHealthInput{
+		LoadBalancerName: lb.LoadBalancerName,
+	})
+	if err != nil {
+		return fmt.Errorf("error describing instance health of load balancer %q: %w", loadBalancerName, err)
+	}
+
+	instanceStates := response.InstanceStates
+	sort.Slice(instanceStates, func(i, j int) bool {
+		return aws.ToString(instanceStates[i].InstanceId) < aws.ToString(instanceStates[j].InstanceId)
+	})
+	for _, state := range instanceStates {
+		if aws.ToString(state.State) != instanceOutOfServiceState {
+			continue
+		}
+		v.addError(&ValidationError{
+			Kind:    "LoadBalancer",
+			Name:    loadBalancerName,
+			Message: fmt.Sprintf("instance %q is out of service in API load balancer %q: %s", aws.ToString(state.InstanceId), loadBalancerName, aws.ToString(state.Description)),
+		})
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kops/cloudmock/aws/mockelb"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// instanceHealthMockELB reports fixed instance states, as mockelb does not track instance health
+type instanceHealthMockELB struct {
+	*mockelb.MockELB
+
+	instanceStates []elbtypes.InstanceState
+}
+
+func (m *instanceHealthMockELB) DescribeInstanceHealth(ctx context.Context, request *elb.DescribeInstanceHealthInput, optFns ...func(*elb.Options)) (*elb.DescribeInstanceHealthOutput, error) {
+	return &elb.DescribeInstanceHealthOutput{InstanceStates: m.instanceStates}, nil
+}
+
+func Test_ValidateAPILoadBalancerInstanceOutOfService(t *testing.T) {
+	cluster := &kopsapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "testcluster.k8s.local"},
+		Spec: kopsapi.ClusterSpec{
+			API: kopsapi.APISpec{
+				LoadBalancer: &kopsapi.LoadBalancerAccessSpec{
+					Class: kopsapi.LoadBalancerClassClassic,
+				},
+			},
+		},
+	}
+
+	instanceGroups := []kopsapi.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "master-1",
+			},
+			Spec: kopsapi.InstanceGroupSpec{
+				Role: kopsapi.InstanceGroupRoleControlPlane,
+			},
+		},
+	}
+	groups := map[string]*cloudinstances.CloudInstanceGroup{
+		"master-1": {
+			InstanceGroup: &instanceGroups[0],
+			MinSize:       0,
+		},
+	}
+
+	elbClient := &instanceHealthMockELB{
+		MockELB: &mockelb.MockELB{},
+		instanceStates: []elbtypes.InstanceState{
+			{
+				InstanceId:  aws.String("i-00002"),
+				State:       aws.String("OutOfService"),
+				ReasonCode:  aws.String("Instance"),
+				Description: aws.String("Instance has failed at least the UnhealthyThreshold number of health checks consecutively."),
+			},
+			{
+				InstanceId: aws.String("i-00001"),
+				State:      aws.String("InService"),
+			},
+		},
+	}
+	_, err := elbClient.CreateLoadBalancer(context.TODO(), &elb.CreateLoadBalancerInput{
+		LoadBalancerName: aws.String("api-testcluster-k8s-local"),
+	})
+	require.NoError(t, err)
+	_, err = elbClient.AddTags(context.TODO(), &elb.AddTagsInput{
+		LoadBalancerNames: []string{"api-testcluster-k8s-local"},
+		Tags: []elbtypes.Tag{
+			{Key: aws.String("Name"), Value: aws.String("api.testcluster.k8s.local")},
+		},
+	})
+	require.NoError(t, err)
+
+	mockcloud := BuildMockCloud(t, groups, cluster, instanceGroups)
+	mockcloud.MockELB = elbClient
+
+	validator, err := NewClusterValidator(cluster, mockcloud, &kopsapi.InstanceGroupList{Items: instanceGroups}, "https://api.testcluster.k8s.local", fake.NewSimpleClientset())
+	require.NoError(t, err)
+	v, err := validator.Validate()
+	require.NoError(t, err)
+	if !assert.Len(t, v.Failures, 1) ||
+		!assert.Equal(t, &ValidationError{
+			Kind:    "LoadBalancer",
+			Name:    "api-testcluster-k8s-local",
+			Message: "instance \"i-00002\" is out of service in API load balancer \"api-testcluster-k8s-local\": Instance has failed at least the UnhealthyThreshold number of health checks consecutively.",
+		}, v.Failures[0]) {
+		printDebug(t, v)
+	}
+}
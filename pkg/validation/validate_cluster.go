@@ -29,6 +29,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -170,6 +171,15 @@ func (v *clusterValidatorImpl) Validate() (*ValidationCluster, error) {
 		return nil, fmt.Errorf("cannot get pod health for %q: %v", v.cluster.Name, err)
 	}
 
+	if lbSpec := v.cluster.Spec.API.LoadBalancer; lbSpec != nil && lbSpec.Class == kops.LoadBalancerClassClassic {
+		if awsCloud, ok := v.cloud.(awsup.AWSCloud); ok {
+			// The load balancer health is only a diagnostic, so failing to query it doesn't fail validation
+			if err := validation.collectAPILoadBalancerFailures(ctx, awsCloud, v.cluster); err != nil {
+				klog.Warningf("cannot get API load balancer health for %q: %v", v.cluster.Name, err)
+			}
+		}
+	}
+
 	return validation, nil
 }
 
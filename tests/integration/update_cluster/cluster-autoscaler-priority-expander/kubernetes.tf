@@ -192,6 +192,11 @@ resource "aws_autoscaling_group" "nodes-cas-priority-expander-example-com" {
     propagate_at_launch = true
     value               = ""
   }
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"
+    propagate_at_launch = true
+    value               = "128Gi"
+  }
   tag {
     key                 = "k8s.io/role/node"
     propagate_at_launch = true
@@ -242,6 +247,11 @@ resource "aws_autoscaling_group" "nodes-high-priority-cas-priority-expander-exam
     propagate_at_launch = true
     value               = ""
   }
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"
+    propagate_at_launch = true
+    value               = "128Gi"
+  }
   tag {
     key                 = "k8s.io/role/node"
     propagate_at_launch = true
@@ -292,6 +302,11 @@ resource "aws_autoscaling_group" "nodes-low-priority-cas-priority-expander-examp
     propagate_at_launch = true
     value               = ""
   }
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"
+    propagate_at_launch = true
+    value               = "128Gi"
+  }
   tag {
     key                 = "k8s.io/role/node"
     propagate_at_launch = true
@@ -637,6 +652,7 @@ resource "aws_launch_template" "nodes-cas-priority-expander-example-com" {
       "Name"                                                                       = "nodes.cas-priority-expander.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -649,6 +665,7 @@ resource "aws_launch_template" "nodes-cas-priority-expander-example-com" {
       "Name"                                                                       = "nodes.cas-priority-expander.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -659,6 +676,7 @@ resource "aws_launch_template" "nodes-cas-priority-expander-example-com" {
     "Name"                                                                       = "nodes.cas-priority-expander.example.com"
     "aws-node-termination-handler/managed"                                       = ""
     "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
     "k8s.io/role/node"                                                           = "1"
     "kops.k8s.io/instancegroup"                                                  = "nodes"
     "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -710,6 +728,7 @@ resource "aws_launch_template" "nodes-high-priority-cas-priority-expander-exampl
       "Name"                                                                       = "nodes-high-priority.cas-priority-expander.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes-high-priority"
       "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -722,6 +741,7 @@ resource "aws_launch_template" "nodes-high-priority-cas-priority-expander-exampl
       "Name"                                                                       = "nodes-high-priority.cas-priority-expander.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes-high-priority"
       "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -732,6 +752,7 @@ resource "aws_launch_template" "nodes-high-priority-cas-priority-expander-exampl
     "Name"                                                                       = "nodes-high-priority.cas-priority-expander.example.com"
     "aws-node-termination-handler/managed"                                       = ""
     "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
     "k8s.io/role/node"                                                           = "1"
     "kops.k8s.io/instancegroup"                                                  = "nodes-high-priority"
     "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -783,6 +804,7 @@ resource "aws_launch_template" "nodes-low-priority-cas-priority-expander-example
       "Name"                                                                       = "nodes-low-priority.cas-priority-expander.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes-low-priority"
       "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -795,6 +817,7 @@ resource "aws_launch_template" "nodes-low-priority-cas-priority-expander-example
       "Name"                                                                       = "nodes-low-priority.cas-priority-expander.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes-low-priority"
       "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -805,6 +828,7 @@ resource "aws_launch_template" "nodes-low-priority-cas-priority-expander-example
     "Name"                                                                       = "nodes-low-priority.cas-priority-expander.example.com"
     "aws-node-termination-handler/managed"                                       = ""
     "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
     "k8s.io/role/node"                                                           = "1"
     "kops.k8s.io/instancegroup"                                                  = "nodes-low-priority"
     "kubernetes.io/cluster/cas-priority-expander.example.com"                    = "owned"
@@ -653,7 +653,7 @@ resource "aws_lb" "api-complex-example-com" {
 }
 
 resource "aws_lb_listener" "api-complex-example-com-443" {
-  certificate_arn = "arn:aws-test:acm:us-test-1:000000000000:certificate/123456789012-1234-1234-1234-12345678"
+  certificate_arn = data.aws_acm_certificate.acm-123456789012-1234-1234-1234-12345678.arn
   default_action {
     target_group_arn = aws_lb_target_group.tls-complex-example-com-5nursn.id
     type             = "forward"
@@ -1395,6 +1395,11 @@ resource "aws_vpc_ipv4_cidr_block_association" "cidr-10-2-0-0--16" {
   vpc_id     = aws_vpc.complex-example-com.id
 }
 
+data "aws_acm_certificate" "acm-123456789012-1234-1234-1234-12345678" {
+  domain      = "api.complex.example.com"
+  most_recent = true
+}
+
 terraform {
   required_version = ">= 0.15.0"
   required_providers {
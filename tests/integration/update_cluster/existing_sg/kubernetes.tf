@@ -550,7 +550,7 @@ resource "aws_ebs_volume" "c-etcd-main-existingsg-example-com" {
 resource "aws_elb" "api-existingsg-example-com" {
   connection_draining         = true
   connection_draining_timeout = 300
-  cross_zone_load_balancing   = false
+  cross_zone_load_balancing   = true
   health_check {
     healthy_threshold   = 2
     interval            = 10
@@ -262,6 +262,11 @@ resource "aws_autoscaling_group" "nodes-minimal-example-com" {
     propagate_at_launch = true
     value               = ""
   }
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"
+    propagate_at_launch = true
+    value               = "128Gi"
+  }
   tag {
     key                 = "k8s.io/role/node"
     propagate_at_launch = true
@@ -710,6 +715,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
       "Name"                                                                       = "nodes.minimal.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
@@ -722,6 +728,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
       "Name"                                                                       = "nodes.minimal.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
@@ -732,6 +739,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
     "Name"                                                                       = "nodes.minimal.example.com"
     "aws-node-termination-handler/managed"                                       = ""
     "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
     "k8s.io/role/node"                                                           = "1"
     "kops.k8s.io/instancegroup"                                                  = "nodes"
     "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
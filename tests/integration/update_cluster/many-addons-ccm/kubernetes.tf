@@ -192,6 +192,11 @@ resource "aws_autoscaling_group" "nodes-minimal-example-com" {
     propagate_at_launch = true
     value               = ""
   }
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"
+    propagate_at_launch = true
+    value               = "128Gi"
+  }
   tag {
     key                 = "k8s.io/role/node"
     propagate_at_launch = true
@@ -536,6 +541,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
       "Name"                                                                       = "nodes.minimal.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
@@ -548,6 +554,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
       "Name"                                                                       = "nodes.minimal.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
@@ -558,6 +565,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
     "Name"                                                                       = "nodes.minimal.example.com"
     "aws-node-termination-handler/managed"                                       = ""
     "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
     "k8s.io/role/node"                                                           = "1"
     "kops.k8s.io/instancegroup"                                                  = "nodes"
     "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
@@ -192,6 +192,11 @@ resource "aws_autoscaling_group" "nodes-many-addons-example-com" {
     propagate_at_launch = true
     value               = ""
   }
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"
+    propagate_at_launch = true
+    value               = "128Gi"
+  }
   tag {
     key                 = "k8s.io/role/node"
     propagate_at_launch = true
@@ -521,6 +526,7 @@ resource "aws_launch_template" "nodes-many-addons-example-com" {
       "Name"                                                                       = "nodes.many-addons.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/many-addons.example.com"                              = "owned"
@@ -533,6 +539,7 @@ resource "aws_launch_template" "nodes-many-addons-example-com" {
       "Name"                                                                       = "nodes.many-addons.example.com"
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/many-addons.example.com"                              = "owned"
@@ -543,6 +550,7 @@ resource "aws_launch_template" "nodes-many-addons-example-com" {
     "Name"                                                                       = "nodes.many-addons.example.com"
     "aws-node-termination-handler/managed"                                       = ""
     "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"        = "128Gi"
     "k8s.io/role/node"                                                           = "1"
     "kops.k8s.io/instancegroup"                                                  = "nodes"
     "kubernetes.io/cluster/many-addons.example.com"                              = "owned"
@@ -197,6 +197,11 @@ resource "aws_autoscaling_group" "nodes-minimal-example-com" {
     propagate_at_launch = true
     value               = ""
   }
+  tag {
+    key                 = "k8s.io/cluster-autoscaler/node-template/taint/nvidia.com/gpu"
+    propagate_at_launch = true
+    value               = ":NoSchedule"
+  }
   tag {
     key                 = "k8s.io/role/node"
     propagate_at_launch = true
@@ -527,6 +532,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/gpu"              = "1"
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/taint/nvidia.com/gpu"               = ":NoSchedule"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
@@ -540,6 +546,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
       "aws-node-termination-handler/managed"                                       = ""
       "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/gpu"              = "1"
       "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/cluster-autoscaler/node-template/taint/nvidia.com/gpu"               = ":NoSchedule"
       "k8s.io/role/node"                                                           = "1"
       "kops.k8s.io/instancegroup"                                                  = "nodes"
       "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
@@ -551,6 +558,7 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
     "aws-node-termination-handler/managed"                                       = ""
     "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/gpu"              = "1"
     "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/cluster-autoscaler/node-template/taint/nvidia.com/gpu"               = ":NoSchedule"
     "k8s.io/role/node"                                                           = "1"
     "kops.k8s.io/instancegroup"                                                  = "nodes"
     "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
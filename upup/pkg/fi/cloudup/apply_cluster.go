@@ -517,7 +517,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 			return fmt.Errorf("error loading templates: %v", err)
 		}
 
-		err = tf.AddTo(templates.TemplateFunctions, secretStore)
+		err = tf.AddTo(ctx, templates.TemplateFunctions, secretStore)
 		if err != nil {
 			return err
 		}
@@ -585,6 +585,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 				Lifecycle:              clusterLifecycle,
 				SecurityLifecycle:      securityLifecycle,
 				Cluster:                cluster,
+				Cloud:                  cloud.(awsup.AWSCloud),
 			}
 
 			if featureflag.Spotinst.Enabled() {
@@ -723,6 +724,15 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		outDir := c.OutDir
 		tf := terraform.NewTerraformTarget(cloud, project, outDir, cluster.Spec.Target)
 
+		// The API certificate is looked up by domain if it is managed outside kOps
+		if lb := cluster.Spec.API.LoadBalancer; lb != nil && lb.SSLCertificate != "" {
+			if target := cluster.Spec.Target; target != nil && target.Terraform != nil && target.Terraform.APILoadBalancer != nil {
+				if domain := target.Terraform.APILoadBalancer.SSLCertificateDomain; domain != "" {
+					tf.ACMCertificateDomains = map[string]string{lb.SSLCertificate: domain}
+				}
+			}
+		}
+
 		// We include a few "util" variables in the TF output
 		if err := tf.AddOutputVariable("region", terraformWriter.LiteralFromStringValue(cloud.Region())); err != nil {
 			return err
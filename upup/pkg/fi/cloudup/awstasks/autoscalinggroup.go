@@ -972,11 +972,12 @@ type terraformAutoscalingGroup struct {
 	EnabledMetrics          []*string                                        `cty:"enabled_metrics"`
 	SuspendedProcesses      []*string                                        `cty:"suspended_processes"`
 	InstanceProtection      *bool                                            `cty:"protect_from_scale_in"`
-	LoadBalancers           []*terraformWriter.Literal                       `cty:"load_balancers"`
-	TargetGroupARNs         []*terraformWriter.Literal                       `cty:"target_group_arns"`
-	MaxInstanceLifetime     *int32                                           `cty:"max_instance_lifetime"`
-	CapacityRebalance       *bool                                            `cty:"capacity_rebalance"`
-	WarmPool                *terraformWarmPool                               `cty:"warm_pool"`
+	// LoadBalancers is either a []*terraformWriter.Literal, or a *terraformWriter.Literal when an ELB may not be created.
+	LoadBalancers       interface{}                `cty:"load_balancers"`
+	TargetGroupARNs     []*terraformWriter.Literal `cty:"target_group_arns"`
+	MaxInstanceLifetime *int32                     `cty:"max_instance_lifetime"`
+	CapacityRebalance   *bool                      `cty:"capacity_rebalance"`
+	WarmPool            *terraformWarmPool         `cty:"warm_pool"`
 }
 
 // RenderTerraform is responsible for rendering the terraform codebase
@@ -1005,10 +1006,21 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 		})
 	}
 
+	var loadBalancers []*terraformWriter.Literal
+	conditionalLoadBalancers := false
 	for _, k := range e.LoadBalancers {
-		tf.LoadBalancers = append(tf.LoadBalancers, k.TerraformLink())
+		loadBalancers = append(loadBalancers, k.TerraformLink())
+		if k.terraformCount() != nil {
+			conditionalLoadBalancers = true
+		}
+	}
+	terraformWriter.SortLiterals(loadBalancers)
+	if conditionalLoadBalancers {
+		// The link to an ELB that is not created is null, which the list must not contain
+		tf.LoadBalancers = terraformWriter.LiteralFunctionExpression("compact", terraformWriter.LiteralListExpression(loadBalancers...))
+	} else if len(loadBalancers) > 0 {
+		tf.LoadBalancers = loadBalancers
 	}
-	terraformWriter.SortLiterals(tf.LoadBalancers)
 
 	for _, tg := range e.TargetGroups {
 		tf.TargetGroupARNs = append(tf.TargetGroupARNs, tg.TerraformLink())
@@ -403,6 +403,53 @@ resource "aws_autoscaling_group" "test1" {
   }
 }
 
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			// The ASG registers its instances with the ELB, so the ELB itself never lists them
+			Resource: &AutoscalingGroup{
+				Name:           fi.PtrTo("master-us-test-1a"),
+				LaunchTemplate: &LaunchTemplate{Name: fi.PtrTo("master-us-test-1a")},
+				LoadBalancers: []*ClassicLoadBalancer{
+					{
+						Name:             fi.PtrTo("api.example.com"),
+						LoadBalancerName: fi.PtrTo("api-example-com"),
+					},
+				},
+				MaxSize: fi.PtrTo(int32(1)),
+				MinSize: fi.PtrTo(int32(1)),
+				Subnets: []*Subnet{
+					{
+						Name: fi.PtrTo("us-test-1a"),
+						ID:   fi.PtrTo("subnet-1111"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_group" "master-us-test-1a" {
+  launch_template {
+    id      = aws_launch_template.master-us-test-1a.id
+    version = aws_launch_template.master-us-test-1a.latest_version
+  }
+  load_balancers      = [aws_elb.api-example-com.id]
+  max_size            = 1
+  min_size            = 1
+  name                = "master-us-test-1a"
+  vpc_zone_identifier = [aws_subnet.us-test-1a.id]
+}
+
 terraform {
   required_version = ">= 0.15.0"
   required_providers {
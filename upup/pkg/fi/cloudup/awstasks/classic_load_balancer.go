@@ -19,15 +19,24 @@ package awstasks
 import (
 	"context"
 	"fmt"
+	"net"
+	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
 	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
@@ -37,6 +46,9 @@ import (
 )
 
 // LoadBalancer manages an ELB.  We find the existing ELB using the Name tag.
+// We never register instances with the ELB ourselves; instance registration is owned
+// by the AutoscalingGroup attachment (AutoscalingGroup.LoadBalancers), and registering
+// instances from both places causes them to flap in and out of service.
 
 var _ DNSTarget = &ClassicLoadBalancer{}
 
@@ -69,14 +81,132 @@ type ClassicLoadBalancer struct {
 	CrossZoneLoadBalancing *ClassicLoadBalancerCrossZoneLoadBalancing
 	SSLCertificateID       string
 
+	// DesyncMitigationMode is how the ELB handles HTTP requests that could be used for desync attacks:
+	// monitor, defensive or strictest. When unset, the mode of the ELB is left alone.
+	DesyncMitigationMode *string
+
 	Tags map[string]string
 
 	// Shared is set if this is an external LB (one we don't create or own)
 	Shared *bool
 
+	// AllowReplaceOnNameChange allows the ELB to be replaced when its LoadBalancerName changes,
+	// as ELBs cannot be renamed. By default the existing ELB is reused under its old name.
+	// The new ELB is created and configured before the old one is deleted, so a failed create
+	// leaves the old ELB in place. If configuring the new ELB or deleting the old one fails,
+	// the new ELB is deleted again.
+	// The new ELB has a new DNS name: DNS records that alias the ELB are updated to it in the same run,
+	// but clients that cached the old name fail until the TTL of their records expires, and anything
+	// outside kOps that references the old DNS name or LoadBalancerName has to be updated by hand.
+	// Instances are only served again once their autoscaling groups have been attached to the new ELB.
+	AllowReplaceOnNameChange *bool
+
 	// WellKnownServices indicates which services are supported by this resource.
 	// This field is internal and is not rendered to the cloud.
 	WellKnownServices []wellknownservices.WellKnownService
+
+	// clusterZones are the zones the cluster spans; used to validate shared load balancers.
+	clusterZones []string
+
+	// availabilityZones are the zones the load balancer is attached to, as reported by AWS.
+	availabilityZones []string
+
+	// terraformResourceNamePrefix is prepended to the terraform resource name of the ELB.
+	terraformResourceNamePrefix string
+
+	// retainUnmanagedTags disables removal of tags that are not in Tags.
+	retainUnmanagedTags bool
+
+	// terraformCreateVariable is the name of a boolean terraform variable that controls whether the ELB is created.
+	terraformCreateVariable string
+	// terraformImportName is the name of an existing ELB that is adopted into the terraform state.
+	terraformImportName string
+
+	// nodePortRange is the cluster's NodePort range, set when the ELB fronts NodePort services.
+	nodePortRange *utilnet.PortRange
+
+	// resolveAddresses makes FindAddresses also return the addresses the ELB DNS name resolves to.
+	resolveAddresses bool
+	// resolver resolves the ELB DNS name; if nil, net.DefaultResolver is used.
+	resolver hostResolver
+
+	// readinessPort is the port on which the ELB must accept connections before the task is complete; 0 disables the readiness gate.
+	readinessPort int
+	// dialer connects to the ELB for the readiness gate; if nil, a net.Dialer is used.
+	dialer contextDialer
+
+	// healthCheckSidecarPort is an instance port the health check targets on purpose, though no listener forwards to it.
+	healthCheckSidecarPort int32
+}
+
+// hostResolver looks up the addresses of a host; it is implemented by net.Resolver.
+type hostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// contextDialer opens network connections; it is implemented by net.Dialer.
+type contextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// SetClusterZones records the zones the cluster spans,
+// so that we can warn when a shared load balancer does not cover them.
+func (e *ClassicLoadBalancer) SetClusterZones(zones []string) {
+	e.clusterZones = zones
+}
+
+// SetTerraformResourceNamePrefix sets a prefix for the terraform resource name of the ELB.
+// It is applied both when rendering and when linking, so references stay consistent.
+func (e *ClassicLoadBalancer) SetTerraformResourceNamePrefix(prefix string) {
+	e.terraformResourceNamePrefix = prefix
+}
+
+// SetRetainUnmanagedTags controls whether tags that we don't manage are left on the ELB.
+// Tags in Tags are still added and updated.
+func (e *ClassicLoadBalancer) SetRetainUnmanagedTags(retain bool) {
+	e.retainUnmanagedTags = retain
+}
+
+// SetTerraformCreateVariable makes the creation of the ELB conditional on the named terraform variable,
+// which is declared with a default of true. References to a gated ELB evaluate to null when it is not created.
+func (e *ClassicLoadBalancer) SetTerraformCreateVariable(name string) {
+	e.terraformCreateVariable = name
+}
+
+// SetTerraformImportName adopts the existing ELB with the supplied name into the terraform state with an import block.
+// The ELB is rendered with that name, so that terraform does not replace it.
+func (e *ClassicLoadBalancer) SetTerraformImportName(name string) {
+	e.terraformImportName = name
+	if name != "" {
+		e.LoadBalancerName = fi.PtrTo(name)
+	}
+}
+
+// SetNodePortRange marks the ELB as fronting NodePort services in the supplied range,
+// so that we can warn when its instance ports are not NodePorts.
+func (e *ClassicLoadBalancer) SetNodePortRange(portRange utilnet.PortRange) {
+	e.nodePortRange = &portRange
+}
+
+// SetResolveAddresses makes FindAddresses also return the IP addresses the ELB DNS name currently resolves to,
+// so callers can connect before the cluster's public DNS records have propagated.
+// Resolution is best-effort; the ELB DNS name is always returned.
+func (e *ClassicLoadBalancer) SetResolveAddresses(resolve bool) {
+	e.resolveAddresses = resolve
+}
+
+// SetHealthCheckSidecarPort records that the health check targets port on purpose, e.g. a sidecar
+// serving the readiness of the backend, so that no warning is given for the lack of a listener forwarding to it.
+func (e *ClassicLoadBalancer) SetHealthCheckSidecarPort(port int32) {
+	e.healthCheckSidecarPort = port
+}
+
+// SetReadinessGate makes the task wait, after the ELB has been applied, until the DNS name of the ELB
+// resolves and the ELB accepts connections on port; until then the task asks to be tried again later.
+// Route53 aliases for the ELB are created by tasks that depend on this one, so they cannot be waited for here.
+// The gate only applies when applying directly to AWS.
+func (e *ClassicLoadBalancer) SetReadinessGate(port int) {
+	e.readinessPort = port
 }
 
 var _ fi.CompareWithID = &ClassicLoadBalancer{}
@@ -86,29 +216,103 @@ func (e *ClassicLoadBalancer) CompareWithID() *string {
 	return e.Name
 }
 
+// ClassicLoadBalancerListener is a listener on the ELB.
+// Unless Protocol is set, a listener is SSL when SSLCertificateID is set and TCP otherwise.
+// CheckChanges rejects SSL and HTTPS listeners without a certificate, as AWS refuses to create them.
+//
+// Backend server policies, such as PROXY protocol, are not managed. If they are added, note that the
+// ProxyProtocolPolicyType of a Classic ELB only sends PROXY protocol v1; v2 requires an NLB target group.
 type ClassicLoadBalancerListener struct {
 	InstancePort     int32
 	SSLCertificateID string
+	// Protocol is the front-end protocol of the listener: TCP, SSL, HTTP or HTTPS.
+	// HTTP and HTTPS listeners terminate HTTP on the ELB, which adds the X-Forwarded headers.
+	Protocol *string
+	// InstanceProtocol is the protocol used between the ELB and the instances, e.g. TCP to terminate TLS on the ELB.
+	// It defaults to the front-end protocol.
+	InstanceProtocol *string
+	// SSLPolicy is the predefined ELB security policy used to negotiate SSL connections, e.g. ELBSecurityPolicy-TLS-1-2-2017-01.
+	// It is only allowed on SSL listeners; when unset, the listener keeps the policy ELB assigned to it.
+	SSLPolicy *string
+}
+
+// acmCertificateID returns the ID of a certificate managed by ACM, from its ARN,
+// e.g. arn:aws:acm:us-east-1:123456789012:certificate/<id>.
+// Server certificates uploaded to IAM are in the iam service instead, and are not recognized.
+func acmCertificateID(certificateARN string) (string, bool) {
+	parsed, err := arn.Parse(certificateARN)
+	if err != nil || parsed.Service != "acm" {
+		return "", false
+	}
+	id, found := strings.CutPrefix(parsed.Resource, "certificate/")
+	if !found || id == "" {
+		return "", false
+	}
+	return id, true
+}
+
+// protocol returns the front-end protocol of the listener.
+func (e *ClassicLoadBalancerListener) protocol() string {
+	if e.Protocol != nil {
+		return *e.Protocol
+	}
+	return e.defaultProtocol()
+}
+
+// defaultProtocol returns the front-end protocol of the listener when Protocol is not set.
+func (e *ClassicLoadBalancerListener) defaultProtocol() string {
+	if e.SSLCertificateID != "" {
+		return "SSL"
+	}
+	return "TCP"
+}
+
+// instanceProtocol returns the back-end protocol of the listener.
+func (e *ClassicLoadBalancerListener) instanceProtocol() string {
+	if e.InstanceProtocol != nil {
+		return *e.InstanceProtocol
+	}
+	return e.protocol()
 }
 
 func (e *ClassicLoadBalancerListener) mapToAWS(loadBalancerPort int32) elbtypes.Listener {
 	l := elbtypes.Listener{
 		LoadBalancerPort: loadBalancerPort,
 		InstancePort:     aws.Int32(e.InstancePort),
+		Protocol:         aws.String(e.protocol()),
+		InstanceProtocol: aws.String(e.instanceProtocol()),
 	}
 
 	if e.SSLCertificateID != "" {
-		l.Protocol = aws.String("SSL")
-		l.InstanceProtocol = aws.String("SSL")
 		l.SSLCertificateId = aws.String(e.SSLCertificateID)
-	} else {
-		l.Protocol = aws.String("TCP")
-		l.InstanceProtocol = aws.String("TCP")
 	}
 
 	return l
 }
 
+// normalizeProtocols upper-cases the protocols, and clears them when they match their defaults,
+// so that an explicit default compares equal to the unset protocols that Find reads back.
+func (e *ClassicLoadBalancerListener) normalizeProtocols() {
+	if e.Protocol != nil {
+		protocol := strings.ToUpper(*e.Protocol)
+		if protocol == e.defaultProtocol() {
+			e.Protocol = nil
+		} else {
+			e.Protocol = fi.PtrTo(protocol)
+		}
+	}
+
+	if e.InstanceProtocol == nil {
+		return
+	}
+	instanceProtocol := strings.ToUpper(*e.InstanceProtocol)
+	if instanceProtocol == e.protocol() {
+		e.InstanceProtocol = nil
+	} else {
+		e.InstanceProtocol = fi.PtrTo(instanceProtocol)
+	}
+}
+
 var _ fi.CloudupHasDependencies = &ClassicLoadBalancerListener{}
 
 func (e *ClassicLoadBalancerListener) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
@@ -217,7 +421,8 @@ func (e *ClassicLoadBalancer) Find(c *fi.CloudupContext) (*ClassicLoadBalancer,
 	ctx := c.Context()
 	cloud := c.T.Cloud.(awsup.AWSCloud)
 
-	lb, err := cloud.FindELBByNameTag(fi.ValueOf(e.Name))
+	// The tags were described to find the ELB, so they aren't described again
+	lb, tags, err := cloud.FindELBAndTagsByNameTag(fi.ValueOf(e.Name))
 	if err != nil {
 		return nil, err
 	}
@@ -230,21 +435,25 @@ func (e *ClassicLoadBalancer) Find(c *fi.CloudupContext) (*ClassicLoadBalancer,
 	actual.LoadBalancerName = lb.LoadBalancerName
 	actual.DNSName = lb.DNSName
 	actual.HostedZoneId = lb.CanonicalHostedZoneNameID
-	actual.Scheme = lb.Scheme
+	actual.Scheme = normalizeScheme(lb.Scheme)
+	actual.availabilityZones = lb.AvailabilityZones
 
 	// Ignore system fields
 	actual.Lifecycle = e.Lifecycle
 	actual.WellKnownServices = e.WellKnownServices
+	actual.AllowReplaceOnNameChange = e.AllowReplaceOnNameChange
 
-	tagMap, err := cloud.DescribeELBTags([]string{*lb.LoadBalancerName})
-	if err != nil {
-		return nil, err
-	}
 	actual.Tags = make(map[string]string)
-	for _, tag := range tagMap[*e.LoadBalancerName] {
+	for _, tag := range tags {
 		if strings.HasPrefix(aws.ToString(tag.Key), "aws:cloudformation:") {
 			continue
 		}
+		if e.retainUnmanagedTags {
+			// Ignore tags we would never remove, so they don't show up as changes
+			if _, found := e.Tags[aws.ToString(tag.Key)]; !found {
+				continue
+			}
+		}
 		actual.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
 	}
 
@@ -265,9 +474,29 @@ func (e *ClassicLoadBalancer) Find(c *fi.CloudupContext) (*ClassicLoadBalancer,
 		actualListener := &ClassicLoadBalancerListener{}
 		actualListener.InstancePort = aws.ToInt32(l.InstancePort)
 		actualListener.SSLCertificateID = aws.ToString(l.SSLCertificateId)
+		actualListener.Protocol = l.Protocol
+		actualListener.InstanceProtocol = l.InstanceProtocol
+		actualListener.normalizeProtocols()
 		actual.Listeners[loadBalancerPort] = actualListener
 	}
 
+	// Only read back the security policies we manage; ELB assigns a default policy to SSL listeners without one
+	if hasListenerSSLPolicies(e.Listeners) {
+		sslPolicies, err := findListenerSSLPolicies(ctx, cloud, lb)
+		if err != nil {
+			return nil, err
+		}
+		for loadBalancerPort, actualListener := range actual.Listeners {
+			if expected := e.Listeners[loadBalancerPort]; expected == nil || expected.SSLPolicy == nil {
+				continue
+			}
+			port, _ := strconv.ParseInt(loadBalancerPort, 10, 32)
+			if sslPolicy, found := sslPolicies[int32(port)]; found {
+				actualListener.SSLPolicy = fi.PtrTo(sslPolicy)
+			}
+		}
+	}
+
 	healthcheck, err := findHealthCheck(lb)
 	if err != nil {
 		return nil, err
@@ -285,21 +514,20 @@ func (e *ClassicLoadBalancer) Find(c *fi.CloudupContext) (*ClassicLoadBalancer,
 		actual.AccessLog = &ClassicLoadBalancerAccessLog{
 			Enabled: aws.Bool(lbAttributes.AccessLog.Enabled),
 		}
-		if lbAttributes.AccessLog.EmitInterval != nil {
+		// AWS keeps reporting the destination of disabled access logs, which we don't manage, so ignore it
+		if lbAttributes.AccessLog.Enabled {
 			actual.AccessLog.EmitInterval = lbAttributes.AccessLog.EmitInterval
-		}
-		if lbAttributes.AccessLog.S3BucketName != nil {
 			actual.AccessLog.S3BucketName = lbAttributes.AccessLog.S3BucketName
-		}
-		if lbAttributes.AccessLog.S3BucketPrefix != nil {
 			actual.AccessLog.S3BucketPrefix = lbAttributes.AccessLog.S3BucketPrefix
 		}
 
-		actual.ConnectionDraining = &ClassicLoadBalancerConnectionDraining{}
-		if lbAttributes.ConnectionDraining.Enabled {
-			actual.ConnectionDraining.Enabled = aws.Bool(lbAttributes.ConnectionDraining.Enabled)
+		// Enabled is always reported, so that disabling draining is detected as a change
+		actual.ConnectionDraining = &ClassicLoadBalancerConnectionDraining{
+			Enabled: aws.Bool(lbAttributes.ConnectionDraining.Enabled),
 		}
-		if lbAttributes.ConnectionDraining.Timeout != nil {
+		// AWS keeps reporting the timeout of disabled draining; it only matters then if we set one
+		timeoutExpected := e.ConnectionDraining != nil && e.ConnectionDraining.Timeout != nil
+		if lbAttributes.ConnectionDraining.Timeout != nil && (lbAttributes.ConnectionDraining.Enabled || timeoutExpected) {
 			actual.ConnectionDraining.Timeout = lbAttributes.ConnectionDraining.Timeout
 		}
 
@@ -311,6 +539,8 @@ func (e *ClassicLoadBalancer) Find(c *fi.CloudupContext) (*ClassicLoadBalancer,
 		actual.CrossZoneLoadBalancing = &ClassicLoadBalancerCrossZoneLoadBalancing{
 			Enabled: aws.Bool(lbAttributes.CrossZoneLoadBalancing.Enabled),
 		}
+
+		actual.DesyncMitigationMode = findDesyncMitigationMode(lbAttributes)
 	}
 
 	// Avoid spurious mismatches
@@ -330,9 +560,14 @@ func (e *ClassicLoadBalancer) Find(c *fi.CloudupContext) (*ClassicLoadBalancer,
 	// We allow for the LoadBalancerName to be wrong:
 	// 1. We don't want to force a rename of the ELB, because that is a destructive operation
 	// 2. We were creating ELBs with insufficiently qualified names previously
+	// unless replacing the ELB was explicitly allowed.
 	if fi.ValueOf(e.LoadBalancerName) != fi.ValueOf(actual.LoadBalancerName) {
-		klog.V(2).Infof("Reusing existing load balancer with name: %q", aws.ToString(actual.LoadBalancerName))
-		e.LoadBalancerName = actual.LoadBalancerName
+		if fi.ValueOf(e.AllowReplaceOnNameChange) {
+			klog.V(2).Infof("Load balancer %q will be replaced by %q", aws.ToString(actual.LoadBalancerName), fi.ValueOf(e.LoadBalancerName))
+		} else {
+			klog.V(2).Infof("Reusing existing load balancer with name: %q", aws.ToString(actual.LoadBalancerName))
+			e.LoadBalancerName = actual.LoadBalancerName
+		}
 	}
 
 	_ = actual.Normalize(c)
@@ -365,11 +600,77 @@ func (e *ClassicLoadBalancer) FindAddresses(context *fi.CloudupContext) ([]strin
 	if lbDnsName == "" {
 		return nil, nil
 	}
-	return []string{lbDnsName}, nil
+
+	addresses := []string{lbDnsName}
+	if e.resolveAddresses {
+		addresses = append(addresses, e.lookupAddresses(context.Context(), lbDnsName)...)
+	}
+	return addresses, nil
+}
+
+// lookupAddresses returns the addresses the ELB DNS name resolves to.
+// A newly created ELB may not resolve for several minutes, so failures are only logged.
+func (e *ClassicLoadBalancer) lookupAddresses(ctx context.Context, dnsName string) []string {
+	var resolver hostResolver = net.DefaultResolver
+	if e.resolver != nil {
+		resolver = e.resolver
+	}
+
+	addresses, err := resolver.LookupHost(ctx, dnsName)
+	if err != nil {
+		klog.V(2).Infof("unable to resolve ELB DNS name %q: %v", dnsName, err)
+		return nil
+	}
+	sort.Strings(addresses)
+	return addresses
 }
 
 func (e *ClassicLoadBalancer) Run(c *fi.CloudupContext) error {
-	return fi.CloudupDefaultDeltaRunMethod(e, c)
+	if err := fi.CloudupDefaultDeltaRunMethod(e, c); err != nil {
+		return err
+	}
+
+	if e.readinessPort == 0 || fi.ValueOf(e.Shared) {
+		return nil
+	}
+	if _, ok := c.Target.(*awsup.AWSAPITarget); !ok {
+		return nil
+	}
+	return e.checkReady(c.Context())
+}
+
+// checkReady returns a TryAgainLaterError until the DNS name of the ELB resolves
+// and the ELB accepts connections on the readiness port.
+func (e *ClassicLoadBalancer) checkReady(ctx context.Context) error {
+	var resolver hostResolver = net.DefaultResolver
+	if e.resolver != nil {
+		resolver = e.resolver
+	}
+	var dialer contextDialer = &net.Dialer{Timeout: 10 * time.Second}
+	if e.dialer != nil {
+		dialer = e.dialer
+	}
+
+	dnsName := fi.ValueOf(e.DNSName)
+	if dnsName == "" {
+		return fi.NewTryAgainLaterError(fmt.Sprintf("waiting for the DNS name of ELB %q", fi.ValueOf(e.LoadBalancerName)))
+	}
+	addresses, err := resolver.LookupHost(ctx, dnsName)
+	if err != nil || len(addresses) == 0 {
+		return fi.NewTryAgainLaterError(fmt.Sprintf("waiting for %q to resolve", dnsName)).WithError(err)
+	}
+
+	address := net.JoinHostPort(addresses[0], strconv.Itoa(e.readinessPort))
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fi.NewTryAgainLaterError(fmt.Sprintf("waiting for ELB %q to accept connections on port %d", fi.ValueOf(e.LoadBalancerName), e.readinessPort)).WithError(err)
+	}
+	if err := conn.Close(); err != nil {
+		klog.V(2).Infof("error closing connection to %s: %v", address, err)
+	}
+
+	klog.V(2).Infof("ELB %q is ready at %s", fi.ValueOf(e.LoadBalancerName), address)
+	return nil
 }
 
 func (_ *ClassicLoadBalancer) ShouldCreate(a, e, changes *ClassicLoadBalancer) (bool, error) {
@@ -383,10 +684,84 @@ func (e *ClassicLoadBalancer) Normalize(c *fi.CloudupContext) error {
 	// We need to sort our arrays consistently, so we don't get spurious changes
 	sort.Stable(OrderSubnetsById(e.Subnets))
 	sort.Stable(OrderSecurityGroupsById(e.SecurityGroups))
+
+	// Find normalizes the scheme and target it reads back, so normalize ours to match
+	e.Scheme = normalizeScheme(e.Scheme)
+	if e.HealthCheck != nil && e.HealthCheck.Target != nil {
+		target, warning := fallbackGRPCHealthCheckTarget(*e.HealthCheck.Target)
+		if warning != "" {
+			klog.Warningf("ELB %q: %s", fi.ValueOf(e.Name), warning)
+		}
+		e.HealthCheck.Target = fi.PtrTo(normalizeHealthCheckTarget(target))
+	}
+
+	for _, listener := range e.Listeners {
+		listener.normalizeProtocols()
+	}
+
+	// The destination of disabled access logs is not read back, so it is not compared either
+	if e.AccessLog != nil && e.AccessLog.Enabled != nil && !*e.AccessLog.Enabled {
+		e.AccessLog.EmitInterval = nil
+		e.AccessLog.S3BucketName = nil
+		e.AccessLog.S3BucketPrefix = nil
+	}
+
+	// The access log prefix is a path within the bucket, to which AWS appends its own path
+	if e.AccessLog != nil && e.AccessLog.S3BucketPrefix != nil {
+		e.AccessLog.S3BucketPrefix = fi.PtrTo(strings.TrimRight(*e.AccessLog.S3BucketPrefix, "/"))
+	}
+
+	// AWS applies a default timeout when draining is enabled without one, so set it to match what we read back
+	if e.ConnectionDraining != nil && fi.ValueOf(e.ConnectionDraining.Enabled) && e.ConnectionDraining.Timeout == nil {
+		e.ConnectionDraining.Timeout = fi.PtrTo(int32(defaultConnectionDrainingTimeout))
+	}
 	return nil
 }
 
+const (
+	// schemeInternetFacing is the scheme AWS reports for ELBs that are not internal.
+	schemeInternetFacing = "internet-facing"
+	// schemeInternal is the scheme of ELBs that are only reachable from within the VPC.
+	schemeInternal = "internal"
+)
+
+// normalizeScheme returns nil for the internet-facing scheme, however it is written,
+// and the lower-case scheme otherwise, as AWS reports it.
+// AWS reports "internet-facing" for public ELBs, while we leave the scheme unset for them.
+func normalizeScheme(scheme *string) *string {
+	s := strings.ToLower(fi.ValueOf(scheme))
+	switch s {
+	case "", schemeInternetFacing:
+		return nil
+	}
+	return &s
+}
+
+var _ fi.HasDisruptiveChanges = &ClassicLoadBalancer{}
+
+// IsDisruptiveChange implements fi.HasDisruptiveChanges.
+// The scheme and name of an ELB cannot be changed without replacing it, and changing listeners or subnets
+// drops the connections they carry. Other changes, such as the idle timeout, are applied in place.
+func (*ClassicLoadBalancer) IsDisruptiveChange(fieldName string) bool {
+	switch fieldName {
+	case "LoadBalancerName", "Scheme", "Listeners", "Subnets":
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *ClassicLoadBalancer) CheckChanges(a, e, changes *ClassicLoadBalancer) error {
+	if err := validateSharedLoadBalancerFields(e); err != nil {
+		return err
+	}
+
+	if a != nil && fi.ValueOf(e.Shared) {
+		for _, warning := range validateSharedLoadBalancer(a, e) {
+			klog.Warningf("%s", warning)
+		}
+	}
+
 	if a == nil {
 		if fi.ValueOf(e.Name) == "" {
 			return fi.RequiredField("Name")
@@ -425,16 +800,369 @@ func (s *ClassicLoadBalancer) CheckChanges(a, e, changes *ClassicLoadBalancer) e
 		}
 	}
 
+	if a != nil && changes.Subnets != nil && len(e.Subnets) == 0 && !fi.ValueOf(e.Shared) {
+		return fmt.Errorf("load balancer %q must retain at least one subnet", fi.ValueOf(e.Name))
+	}
+
+	if e.HealthCheck != nil && e.HealthCheck.Target != nil {
+		if _, err := ParseClassicLoadBalancerHealthCheckTarget(*e.HealthCheck.Target); err != nil {
+			return err
+		}
+	}
+
+	if e.AccessLog != nil && fi.ValueOf(e.AccessLog.Enabled) {
+		if prefix := fi.ValueOf(e.AccessLog.S3BucketPrefix); strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("access log bucket prefix %q of load balancer %q must not start with a slash", prefix, fi.ValueOf(e.Name))
+		}
+	}
+
+	if e.nodePortRange != nil {
+		for _, warning := range validateNodePorts(e) {
+			klog.Warningf("%s", warning)
+		}
+	}
+
+	if e.DesyncMitigationMode != nil && !slices.Contains(desyncMitigationModes, *e.DesyncMitigationMode) {
+		return fmt.Errorf("desync mitigation mode %q of load balancer %q must be one of %s", *e.DesyncMitigationMode, fi.ValueOf(e.Name), strings.Join(desyncMitigationModes, ", "))
+	}
+
+	if err := validateListenerPorts(e.Listeners); err != nil {
+		return err
+	}
+	if err := validateListenerProtocols(e.Listeners); err != nil {
+		return err
+	}
+
+	// Only warn when the setting is applied, rather than on every update
+	if a == nil || changes.CrossZoneLoadBalancing != nil {
+		if warning := validateCrossZoneLoadBalancing(e); warning != "" {
+			klog.Warningf("%s", warning)
+		}
+	}
+
+	if a == nil || changes.HealthCheck != nil {
+		if warning := validateHealthCheckProtocol(e); warning != "" {
+			klog.Warningf("%s", warning)
+		}
+	}
+
+	if a == nil || changes.HealthCheck != nil || changes.Listeners != nil {
+		if warning := validateHealthCheckPort(e); warning != "" {
+			klog.Warningf("%s", warning)
+		}
+	}
+
+	return nil
+}
+
+// diffListeners compares the actual and expected listeners, returning the ports of the actual listeners to delete,
+// because they changed or are no longer expected, and the expected listeners to create, because they changed or are new.
+// Unchanged listeners are left alone, so that each listener and its certificate is reconciled independently.
+func diffListeners(actual, expected map[string]*ClassicLoadBalancerListener) ([]int32, []elbtypes.Listener, error) {
+	parse := func(listeners map[string]*ClassicLoadBalancerListener) (map[int32]elbtypes.Listener, error) {
+		byPort := make(map[int32]elbtypes.Listener, len(listeners))
+		for loadBalancerPort, listener := range listeners {
+			port, err := strconv.ParseInt(loadBalancerPort, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing load balancer listener port: %q", loadBalancerPort)
+			}
+			byPort[int32(port)] = listener.mapToAWS(int32(port))
+		}
+		return byPort, nil
+	}
+
+	actualByPort, err := parse(actual)
+	if err != nil {
+		return nil, nil, err
+	}
+	expectedByPort, err := parse(expected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var deletePorts []int32
+	for port, a := range actualByPort {
+		if e, found := expectedByPort[port]; !found || !reflect.DeepEqual(a, e) {
+			deletePorts = append(deletePorts, port)
+		}
+	}
+	slices.Sort(deletePorts)
+
+	var createListeners []elbtypes.Listener
+	for port, e := range expectedByPort {
+		if a, found := actualByPort[port]; !found || !reflect.DeepEqual(a, e) {
+			createListeners = append(createListeners, e)
+		}
+	}
+	sort.Slice(createListeners, func(i, j int) bool {
+		return createListeners[i].LoadBalancerPort < createListeners[j].LoadBalancerPort
+	})
+
+	return deletePorts, createListeners, nil
+}
+
+// validateListenerPorts checks that the listeners are keyed by valid ports, and that no two keys name the same port
+// (e.g. "443" and "0443"), as one listener would then silently replace the other.
+func validateListenerPorts(listeners map[string]*ClassicLoadBalancerListener) error {
+	keys := make([]string, 0, len(listeners))
+	for key := range listeners {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	seen := make(map[int64]string, len(keys))
+	for _, key := range keys {
+		port, err := strconv.ParseInt(key, 10, 32)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid load balancer listener port %q", key)
+		}
+		if other, found := seen[port]; found {
+			return fmt.Errorf("load balancer listeners %q and %q both use port %d", other, key, port)
+		}
+		seen[port] = key
+	}
+	return nil
+}
+
+// validateListenerProtocols checks that the instance protocol of each listener can be used with its front-end protocol.
+// AWS only allows TCP or SSL behind a TCP or SSL front-end, and HTTP or HTTPS behind an HTTP or HTTPS front-end.
+// It also checks that SSL and HTTPS listeners, and only those, have a certificate, and that only they set a security policy.
+func validateListenerProtocols(listeners map[string]*ClassicLoadBalancerListener) error {
+	keys := make([]string, 0, len(listeners))
+	for key := range listeners {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		listener := listeners[key]
+		protocol := strings.ToUpper(listener.protocol())
+		var instanceProtocols []string
+		switch protocol {
+		case "TCP", "SSL":
+			instanceProtocols = []string{"TCP", "SSL"}
+		case "HTTP", "HTTPS":
+			instanceProtocols = []string{"HTTP", "HTTPS"}
+		default:
+			return fmt.Errorf("unsupported protocol %q of load balancer listener %q; must be TCP, SSL, HTTP or HTTPS", listener.protocol(), key)
+		}
+		if !slices.Contains(instanceProtocols, strings.ToUpper(listener.instanceProtocol())) {
+			return fmt.Errorf("instance protocol %q of load balancer listener %q cannot be used with front-end protocol %s; must be %s", listener.instanceProtocol(), key, protocol, strings.Join(instanceProtocols, " or "))
+		}
+
+		secure := protocol == "SSL" || protocol == "HTTPS"
+		if secure && listener.SSLCertificateID == "" {
+			return fmt.Errorf("load balancer listener %q with protocol %s requires an SSL certificate", key, protocol)
+		}
+		if !secure && listener.SSLCertificateID != "" {
+			return fmt.Errorf("load balancer listener %q with protocol %s cannot have an SSL certificate; use SSL or HTTPS", key, protocol)
+		}
+		if listener.SSLPolicy != nil {
+			if !secure {
+				return fmt.Errorf("load balancer listener %q sets an SSL policy, which requires an SSL certificate", key)
+			}
+			if *listener.SSLPolicy == "" {
+				return fmt.Errorf("SSL policy of load balancer listener %q must not be empty", key)
+			}
+		}
+	}
 	return nil
 }
 
-func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *ClassicLoadBalancer) error {
+// validateCrossZoneLoadBalancing warns when cross-zone load balancing is enabled but the subnets are all in one zone,
+// which has no effect and usually means subnets are missing.
+func validateCrossZoneLoadBalancing(e *ClassicLoadBalancer) string {
+	if e.CrossZoneLoadBalancing == nil || !fi.ValueOf(e.CrossZoneLoadBalancing.Enabled) {
+		return ""
+	}
+
+	zones := sets.New[string]()
+	for _, subnet := range e.Subnets {
+		if zone := fi.ValueOf(subnet.AvailabilityZone); zone != "" {
+			zones.Insert(zone)
+		}
+	}
+	if zones.Len() != 1 {
+		return ""
+	}
+	return fmt.Sprintf("ELB %q has cross-zone load balancing enabled, but its subnets are all in zone %s", fi.ValueOf(e.Name), sets.List(zones)[0])
+}
+
+// validateHealthCheckProtocol warns about HTTPS health checks. ELB sends them without SNI and with the
+// instance IP as Host, which cannot be changed, so backends that select a certificate or virtual host by name fail them.
+func validateHealthCheckProtocol(e *ClassicLoadBalancer) string {
+	if e.HealthCheck == nil || e.HealthCheck.Target == nil {
+		return ""
+	}
+	target, err := ParseClassicLoadBalancerHealthCheckTarget(*e.HealthCheck.Target)
+	if err != nil || target.Protocol != "HTTPS" {
+		return ""
+	}
+	return fmt.Sprintf("ELB %q health check %q cannot send SNI or set the Host header; if the backend on port %d relies on either, use an SSL or TCP health check instead", fi.ValueOf(e.Name), target.String(), target.Port)
+}
+
+// validateHealthCheckPort warns when the health check targets an instance port that no listener forwards to.
+// AWS accepts this, but the health of that port then says nothing about the traffic the ELB serves.
+func validateHealthCheckPort(e *ClassicLoadBalancer) string {
+	if fi.ValueOf(e.Shared) || len(e.Listeners) == 0 || e.HealthCheck == nil || e.HealthCheck.Target == nil {
+		return ""
+	}
+	target, err := ParseClassicLoadBalancerHealthCheckTarget(*e.HealthCheck.Target)
+	if err != nil || target.Port == e.healthCheckSidecarPort {
+		return ""
+	}
+
+	var instancePorts []int
+	for _, listener := range e.Listeners {
+		if listener.InstancePort == target.Port {
+			return ""
+		}
+		instancePorts = append(instancePorts, int(listener.InstancePort))
+	}
+	sort.Ints(instancePorts)
+	return fmt.Sprintf("ELB %q health check %q targets port %d, which none of the listener instance ports %v forward to", fi.ValueOf(e.Name), target.String(), target.Port, instancePorts)
+}
+
+// validateNodePorts checks that an ELB fronting NodePort services only forwards to, and health checks, NodePorts.
+// A port outside the range may still be served by something else on the instances, so problems are only reported as warnings.
+func validateNodePorts(e *ClassicLoadBalancer) []string {
+	var warnings []string
+
+	name := fi.ValueOf(e.Name)
+
+	var loadBalancerPorts []string
+	for loadBalancerPort := range e.Listeners {
+		loadBalancerPorts = append(loadBalancerPorts, loadBalancerPort)
+	}
+	sort.Strings(loadBalancerPorts)
+	for _, loadBalancerPort := range loadBalancerPorts {
+		instancePort := e.Listeners[loadBalancerPort].InstancePort
+		if !e.nodePortRange.Contains(int(instancePort)) {
+			warnings = append(warnings, fmt.Sprintf("ELB %q listener %s forwards to instance port %d, which is outside the NodePort range %s", name, loadBalancerPort, instancePort, e.nodePortRange))
+		}
+	}
+
+	if e.HealthCheck != nil && e.HealthCheck.Target != nil {
+		if target, err := ParseClassicLoadBalancerHealthCheckTarget(*e.HealthCheck.Target); err == nil && !e.nodePortRange.Contains(int(target.Port)) {
+			warnings = append(warnings, fmt.Sprintf("ELB %q health check targets instance port %d, which is outside the NodePort range %s", name, target.Port, e.nodePortRange))
+		}
+	}
+
+	return warnings
+}
+
+// validateSharedLoadBalancerFields rejects subnets and security groups on a shared ELB.
+// We never reconcile a shared ELB, so they would be silently ignored.
+func validateSharedLoadBalancerFields(e *ClassicLoadBalancer) error {
+	if !fi.ValueOf(e.Shared) {
+		return nil
+	}
+	if len(e.Subnets) != 0 {
+		return fmt.Errorf("shared load balancer %q must not specify subnets, as kops does not manage them", fi.ValueOf(e.Name))
+	}
+	if len(e.SecurityGroups) != 0 {
+		return fmt.Errorf("shared load balancer %q must not specify security groups, as kops does not manage them", fi.ValueOf(e.Name))
+	}
+	return nil
+}
+
+// sharedLoadBalancerMinIdleTimeout is the idle timeout below which we consider a shared ELB
+// likely to drop long-lived connections; it matches the AWS default.
+const sharedLoadBalancerMinIdleTimeout = 60
+
+// validateSharedLoadBalancer checks the attributes of an existing shared ELB against the cluster topology.
+// We never modify shared ELBs, so problems are only reported as warnings.
+func validateSharedLoadBalancer(a, e *ClassicLoadBalancer) []string {
+	var warnings []string
+
+	name := fi.ValueOf(a.LoadBalancerName)
+
+	crossZone := a.CrossZoneLoadBalancing != nil && fi.ValueOf(a.CrossZoneLoadBalancing.Enabled)
+	if !crossZone && len(e.clusterZones) > 1 {
+		lbZones := sets.New(a.availabilityZones...)
+		var missing []string
+		for _, zone := range e.clusterZones {
+			if !lbZones.Has(zone) {
+				missing = append(missing, zone)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			warnings = append(warnings, fmt.Sprintf("shared ELB %q has cross-zone load balancing disabled and is not attached to zones %v; instances in those zones will not receive traffic", name, missing))
+		}
+	}
+
+	if a.ConnectionSettings != nil && a.ConnectionSettings.IdleTimeout != nil {
+		if idleTimeout := *a.ConnectionSettings.IdleTimeout; idleTimeout < sharedLoadBalancerMinIdleTimeout {
+			warnings = append(warnings, fmt.Sprintf("shared ELB %q has an idle timeout of %ds, which may drop long-lived connections", name, idleTimeout))
+		}
+	}
+
+	return warnings
+}
+
+// reconcileELBTags adds the desired tags that are missing or differ from the actual tags, then removes the actual tags
+// that are not desired. Tags are added before any are removed, so that a failure part way through
+// never leaves the ELB without the tags we expect; the next run removes any leftovers.
+func reconcileELBTags(t *awsup.AWSAPITarget, loadBalancerName string, desired, actual map[string]string) error {
+	missing := map[string]string{}
+	for k, v := range desired {
+		if actualValue, found := actual[k]; !found || actualValue != v {
+			missing[k] = v
+		}
+	}
+	if len(missing) != 0 {
+		klog.V(4).Infof("adding tags to %q: %v", loadBalancerName, missing)
+		if err := t.Cloud.CreateELBTags(loadBalancerName, missing); err != nil {
+			return fmt.Errorf("error adding tags to ELB %q: %v", loadBalancerName, err)
+		}
+	}
+
+	// ELB tags are removed by key, so a tag whose value differs has already been overwritten above
+	extra := map[string]string{}
+	for k, v := range actual {
+		if _, found := desired[k]; !found {
+			extra[k] = v
+		}
+	}
+	if len(extra) != 0 {
+		klog.V(4).Infof("removing tags from %q: %v", loadBalancerName, extra)
+		if err := t.Cloud.RemoveELBTags(loadBalancerName, extra); err != nil {
+			return fmt.Errorf("error removing tags from ELB %q: %v", loadBalancerName, err)
+		}
+	}
+
+	return nil
+}
+
+// managedTags returns the actual tags whose keys are desired, leaving out the tags we don't manage.
+func managedTags(actual, desired map[string]string) map[string]string {
+	managed := make(map[string]string, len(desired))
+	for k, v := range actual {
+		if _, found := desired[k]; found {
+			managed[k] = v
+		}
+	}
+	return managed
+}
+
+func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *ClassicLoadBalancer) (retErr error) {
 	shared := fi.ValueOf(e.Shared)
 	if shared {
 		return nil
 	}
 	ctx := context.TODO()
 
+	// ELBs cannot be renamed, so a new ELB is created in place of the old one.
+	// The old ELB keeps serving until the new one has been fully configured, and is only deleted then.
+	var replaced *ClassicLoadBalancer
+	if a != nil && changes.LoadBalancerName != nil && fi.ValueOf(e.AllowReplaceOnNameChange) {
+		replaced = a
+		klog.Warningf("Replacing ELB %q with %q; clients must resolve the new DNS name to reach it", fi.ValueOf(a.LoadBalancerName), fi.ValueOf(e.LoadBalancerName))
+		a = nil
+		changes = e
+	}
+
 	var loadBalancerName string
 	if a == nil {
 		if e.LoadBalancerName == nil {
@@ -444,11 +1172,13 @@ func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Cl
 
 		request := &elb.CreateLoadBalancerInput{}
 		request.LoadBalancerName = e.LoadBalancerName
-		request.Scheme = e.Scheme
+		request.Scheme = normalizeScheme(e.Scheme)
 
 		for _, subnet := range e.Subnets {
 			request.Subnets = append(request.Subnets, aws.ToString(subnet.ID))
 		}
+		// Subnet IDs may not have been known when we normalized, so sort them again
+		sort.Strings(request.Subnets)
 
 		for _, sg := range e.SecurityGroups {
 			request.SecurityGroups = append(request.SecurityGroups, aws.ToString(sg.ID))
@@ -469,11 +1199,31 @@ func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Cl
 
 		response, err := t.Cloud.ELB().CreateLoadBalancer(ctx, request)
 		if err != nil {
-			return fmt.Errorf("error creating ELB: %v", err)
+			return newELBTaskError("creating ELB", loadBalancerName, err)
 		}
 
 		e.DNSName = response.DNSName
 
+		if replaced != nil {
+			// Both ELBs have the same Name tag until the old one is deleted, so the replacement
+			// is deleted again if it cannot be configured or the old ELB cannot be deleted
+			defer func() {
+				if retErr != nil {
+					retErr = rollbackReplacementELB(ctx, t, loadBalancerName, replaced, e, retErr)
+				}
+			}()
+		}
+
+		// CreateLoadBalancer does not accept attributes, so apply them straight away
+		// to keep the window in which the ELB has the default idle timeout as short as possible
+		if err := e.modifyLoadBalancerAttributes(t, a, e, changes); err != nil {
+			return err
+		}
+
+		if err := setListenerSSLPolicies(ctx, t, loadBalancerName, e.Listeners); err != nil {
+			return err
+		}
+
 		// Requery to get the CanonicalHostedZoneNameID
 		lb, err := findLoadBalancerByLoadBalancerName(ctx, t.Cloud, loadBalancerName)
 		if err != nil {
@@ -499,6 +1249,34 @@ func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Cl
 			}
 
 			oldSubnetIDs := slice.GetUniqueStrings(expectedSubnets, actualSubnets)
+			newSubnetIDs := slice.GetUniqueStrings(actualSubnets, expectedSubnets)
+
+			if err := checkDetachedZones(ctx, t.Cloud, loadBalancerName, oldSubnetIDs, expectedSubnets); err != nil {
+				if !featureflag.ELBAllowDetachingZonesWithInstances.Enabled() {
+					return err
+				}
+				klog.Warningf("%v; detaching anyway because of the ELBAllowDetachingZonesWithInstances feature flag", err)
+			}
+
+			// Attach new subnets before detaching old ones, so the ELB keeps serving every zone it can.
+			// An ELB can only be attached to one subnet per zone though, so subnets replacing an old
+			// subnet in the same zone can only be attached once the old subnet has been detached.
+			attachFirst, attachLast, err := splitSubnetsByZone(ctx, t.Cloud, oldSubnetIDs, newSubnetIDs)
+			if err != nil {
+				return newELBTaskError("ordering subnet changes", loadBalancerName, err)
+			}
+
+			if len(attachFirst) > 0 {
+				request := &elb.AttachLoadBalancerToSubnetsInput{}
+				request.LoadBalancerName = aws.String(loadBalancerName)
+				request.Subnets = attachFirst
+
+				klog.V(2).Infof("Attaching Load Balancer to new subnets")
+				if _, err := t.Cloud.ELB().AttachLoadBalancerToSubnets(ctx, request); err != nil {
+					return newELBTaskError("attaching to new subnets", loadBalancerName, err)
+				}
+			}
+
 			if len(oldSubnetIDs) > 0 {
 				request := &elb.DetachLoadBalancerFromSubnetsInput{}
 				request.LoadBalancerName = aws.String(loadBalancerName)
@@ -506,19 +1284,18 @@ func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Cl
 
 				klog.V(2).Infof("Detaching Load Balancer from old subnets")
 				if _, err := t.Cloud.ELB().DetachLoadBalancerFromSubnets(ctx, request); err != nil {
-					return fmt.Errorf("Error detaching Load Balancer from old subnets: %v", err)
+					return newELBTaskError("detaching from old subnets", loadBalancerName, err)
 				}
 			}
 
-			newSubnetIDs := slice.GetUniqueStrings(actualSubnets, expectedSubnets)
-			if len(newSubnetIDs) > 0 {
+			if len(attachLast) > 0 {
 				request := &elb.AttachLoadBalancerToSubnetsInput{}
 				request.LoadBalancerName = aws.String(loadBalancerName)
-				request.Subnets = newSubnetIDs
+				request.Subnets = attachLast
 
-				klog.V(2).Infof("Attaching Load Balancer to new subnets")
+				klog.V(2).Infof("Attaching Load Balancer to subnets replacing old subnets in the same zone")
 				if _, err := t.Cloud.ELB().AttachLoadBalancerToSubnets(ctx, request); err != nil {
-					return fmt.Errorf("Error attaching Load Balancer to new subnets: %v", err)
+					return newELBTaskError("attaching to new subnets", loadBalancerName, err)
 				}
 			}
 		}
@@ -532,52 +1309,57 @@ func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Cl
 
 			klog.V(2).Infof("Updating Load Balancer Security Groups")
 			if _, err := t.Cloud.ELB().ApplySecurityGroupsToLoadBalancer(ctx, request); err != nil {
-				return fmt.Errorf("Error updating security groups on Load Balancer: %v", err)
+				return newELBTaskError("updating security groups", loadBalancerName, err)
 			}
 		}
 
 		if changes.Listeners != nil {
-
-			elbDescription, err := findLoadBalancerByLoadBalancerName(ctx, t.Cloud, loadBalancerName)
+			deletePorts, createListeners, err := diffListeners(a.Listeners, e.Listeners)
 			if err != nil {
-				return fmt.Errorf("error getting load balancer by name: %v", err)
+				return err
 			}
 
-			if elbDescription != nil {
-				// deleting the listener before recreating it
-				t.Cloud.ELB().DeleteLoadBalancerListeners(ctx, &elb.DeleteLoadBalancerListenersInput{
+			// A listener can't be modified in place, so changed listeners are deleted before being recreated
+			if len(deletePorts) > 0 {
+				klog.V(2).Infof("Deleting LoadBalancer listeners on ports %v", deletePorts)
+				if _, err := t.Cloud.ELB().DeleteLoadBalancerListeners(ctx, &elb.DeleteLoadBalancerListenersInput{
 					LoadBalancerName:  aws.String(loadBalancerName),
-					LoadBalancerPorts: []int32{443},
-				})
+					LoadBalancerPorts: deletePorts,
+				}); err != nil {
+					return newELBTaskError("deleting listeners", loadBalancerName, err)
+				}
 			}
 
-			request := &elb.CreateLoadBalancerListenersInput{}
-			request.LoadBalancerName = aws.String(loadBalancerName)
+			if len(createListeners) > 0 {
+				request := &elb.CreateLoadBalancerListenersInput{}
+				request.LoadBalancerName = aws.String(loadBalancerName)
+				request.Listeners = createListeners
 
-			for loadBalancerPort, listener := range changes.Listeners {
-				loadBalancerPortInt, err := strconv.ParseInt(loadBalancerPort, 10, 32)
-				if err != nil {
-					return fmt.Errorf("error parsing load balancer listener port: %q", loadBalancerPort)
+				klog.V(2).Infof("Creating LoadBalancer listeners")
+
+				if _, err := t.Cloud.ELB().CreateLoadBalancerListeners(ctx, request); err != nil {
+					return newELBTaskError("creating listeners", loadBalancerName, err)
 				}
-				awsListener := listener.mapToAWS(int32(loadBalancerPortInt))
-				request.Listeners = append(request.Listeners, awsListener)
 			}
 
-			klog.V(2).Infof("Creating LoadBalancer listeners")
-
-			_, err = t.Cloud.ELB().CreateLoadBalancerListeners(ctx, request)
-			if err != nil {
-				return fmt.Errorf("error creating LoadBalancerListeners: %v", err)
+			if err := setListenerSSLPolicies(ctx, t, loadBalancerName, e.Listeners); err != nil {
+				return err
 			}
 		}
 	}
 
-	if err := t.AddELBTags(loadBalancerName, e.Tags); err != nil {
-		return err
-	}
-
-	if err := t.RemoveELBTags(loadBalancerName, e.Tags); err != nil {
-		return err
+	if a == nil || changes.Tags != nil {
+		var actualTags map[string]string
+		if a != nil {
+			actualTags = a.Tags
+		}
+		if e.retainUnmanagedTags {
+			klog.V(4).Infof("Not removing unmanaged tags from ELB %q", loadBalancerName)
+			actualTags = managedTags(actualTags, e.Tags)
+		}
+		if err := reconcileELBTags(t, loadBalancerName, e.Tags, actualTags); err != nil {
+			return err
+		}
 	}
 
 	if changes.HealthCheck != nil && e.HealthCheck != nil {
@@ -595,18 +1377,148 @@ func (_ *ClassicLoadBalancer) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Cl
 
 		_, err := t.Cloud.ELB().ConfigureHealthCheck(ctx, request)
 		if err != nil {
-			return fmt.Errorf("error configuring health checks on ELB: %v", err)
+			return newELBTaskError("configuring health checks", loadBalancerName, err)
 		}
 	}
 
-	if err := e.modifyLoadBalancerAttributes(t, a, e, changes); err != nil {
-		klog.Infof("error modifying ELB attributes: %v", err)
-		return err
+	// The attributes of a new ELB were applied when it was created
+	if a != nil {
+		if err := e.modifyLoadBalancerAttributes(t, a, e, changes); err != nil {
+			klog.Infof("error modifying ELB attributes: %v", err)
+			return err
+		}
+	}
+
+	if replaced != nil {
+		replacedName := fi.ValueOf(replaced.LoadBalancerName)
+		klog.V(2).Infof("Deleting ELB %q, replaced by %q", replacedName, loadBalancerName)
+		if _, err := t.Cloud.ELB().DeleteLoadBalancer(ctx, &elb.DeleteLoadBalancerInput{
+			LoadBalancerName: aws.String(replacedName),
+		}); err != nil {
+			return newELBTaskError(fmt.Sprintf("deleting ELB replaced by %q", loadBalancerName), replacedName, err)
+		}
 	}
 
 	return nil
 }
 
+// rollbackReplacementELB deletes the ELB that was created to replace an ELB with a different name, after the
+// replacement failed, so that the replaced ELB is the only one with its Name tag again and DNS keeps pointing at it.
+// If the replacement cannot be deleted either, the error says which ELB has to be deleted by hand.
+func rollbackReplacementELB(ctx context.Context, t *awsup.AWSAPITarget, loadBalancerName string, replaced, e *ClassicLoadBalancer, cause error) error {
+	replacedName := fi.ValueOf(replaced.LoadBalancerName)
+	klog.Warningf("Replacing ELB %q failed, deleting its replacement %q", replacedName, loadBalancerName)
+	if _, err := t.Cloud.ELB().DeleteLoadBalancer(ctx, &elb.DeleteLoadBalancerInput{
+		LoadBalancerName: aws.String(loadBalancerName),
+	}); err != nil {
+		return fmt.Errorf("%w; deleting the replacement ELB %q also failed, so ELBs %q and %q both have the Name tag %q and %q must be deleted by hand: %v",
+			cause, loadBalancerName, replacedName, loadBalancerName, fi.ValueOf(e.Name), loadBalancerName, err)
+	}
+
+	e.LoadBalancerName = replaced.LoadBalancerName
+	e.DNSName = replaced.DNSName
+	e.HostedZoneId = replaced.HostedZoneId
+	return cause
+}
+
+// checkDetachedZones returns an error if detaching oldSubnetIDs would leave the ELB without a subnet in a zone
+// where instances are registered with it, as the ELB would then stop sending traffic to those instances.
+func checkDetachedZones(ctx context.Context, cloud awsup.AWSCloud, loadBalancerName string, oldSubnetIDs, expectedSubnetIDs []string) error {
+	if len(oldSubnetIDs) == 0 {
+		return nil
+	}
+
+	response, err := cloud.EC2().DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: append(append([]string{}, oldSubnetIDs...), expectedSubnetIDs...),
+	})
+	if err != nil {
+		return fmt.Errorf("error describing subnets: %w", err)
+	}
+	zones := make(map[string]string)
+	for _, subnet := range response.Subnets {
+		zones[aws.ToString(subnet.SubnetId)] = aws.ToString(subnet.AvailabilityZone)
+	}
+
+	detachedZones := sets.New[string]()
+	for _, id := range oldSubnetIDs {
+		detachedZones.Insert(zones[id])
+	}
+	for _, id := range expectedSubnetIDs {
+		detachedZones.Delete(zones[id])
+	}
+	if detachedZones.Len() == 0 {
+		return nil
+	}
+
+	lb, err := findLoadBalancerByLoadBalancerName(ctx, cloud, loadBalancerName)
+	if err != nil {
+		return err
+	}
+	if lb == nil || len(lb.Instances) == 0 {
+		return nil
+	}
+	var instanceIDs []string
+	for _, instance := range lb.Instances {
+		instanceIDs = append(instanceIDs, aws.ToString(instance.InstanceId))
+	}
+
+	var stranded []string
+	paginator := ec2.NewDescribeInstancesPaginator(cloud.EC2(), &ec2.DescribeInstancesInput{InstanceIds: instanceIDs})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("error describing instances registered with ELB %q: %w", loadBalancerName, err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				if instance.Placement != nil && detachedZones.Has(aws.ToString(instance.Placement.AvailabilityZone)) {
+					stranded = append(stranded, fmt.Sprintf("%s (%s)", aws.ToString(instance.InstanceId), aws.ToString(instance.Placement.AvailabilityZone)))
+				}
+			}
+		}
+	}
+	if len(stranded) == 0 {
+		return nil
+	}
+	sort.Strings(stranded)
+	return fmt.Errorf("refusing to detach ELB %q from the last subnet of a zone with registered instances %v, which would stop receiving traffic", loadBalancerName, stranded)
+}
+
+// splitSubnetsByZone splits the subnets being attached to an ELB into those that can be attached
+// before the old subnets are detached, and those in the same zone as an old subnet, which must be attached after.
+func splitSubnetsByZone(ctx context.Context, cloud awsup.AWSCloud, oldSubnetIDs, newSubnetIDs []string) ([]string, []string, error) {
+	if len(oldSubnetIDs) == 0 || len(newSubnetIDs) == 0 {
+		return newSubnetIDs, nil, nil
+	}
+
+	request := &ec2.DescribeSubnetsInput{
+		SubnetIds: append(append([]string{}, oldSubnetIDs...), newSubnetIDs...),
+	}
+	response, err := cloud.EC2().DescribeSubnets(ctx, request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error describing subnets: %w", err)
+	}
+	zones := make(map[string]string)
+	for _, subnet := range response.Subnets {
+		zones[aws.ToString(subnet.SubnetId)] = aws.ToString(subnet.AvailabilityZone)
+	}
+
+	oldZones := sets.New[string]()
+	for _, id := range oldSubnetIDs {
+		oldZones.Insert(zones[id])
+	}
+
+	var attachFirst, attachLast []string
+	for _, id := range newSubnetIDs {
+		if zone, found := zones[id]; !found || oldZones.Has(zone) {
+			attachLast = append(attachLast, id)
+		} else {
+			attachFirst = append(attachFirst, id)
+		}
+	}
+	return attachFirst, attachLast, nil
+}
+
 // OrderLoadBalancersByName implements sort.Interface for []OrderLoadBalancersByName, based on name
 type OrderLoadBalancersByName []*ClassicLoadBalancer
 
@@ -616,7 +1528,10 @@ func (a OrderLoadBalancersByName) Less(i, j int) bool {
 	return fi.ValueOf(a[i].Name) < fi.ValueOf(a[j].Name)
 }
 
+// terraformLoadBalancer deliberately has no instances attribute: instances are registered by the autoscaling groups
+// that reference the ELB in their load_balancers, and listing them here as well would fight over the registrations.
 type terraformLoadBalancer struct {
+	Count            *terraformWriter.Literal         `cty:"count"`
 	LoadBalancerName *string                          `cty:"name"`
 	Listener         []*terraformLoadBalancerListener `cty:"listener"`
 	SecurityGroups   []*terraformWriter.Literal       `cty:"security_groups"`
@@ -631,17 +1546,57 @@ type terraformLoadBalancer struct {
 
 	CrossZoneLoadBalancing *bool `cty:"cross_zone_load_balancing"`
 
-	IdleTimeout *int32 `cty:"idle_timeout"`
+	DesyncMitigationMode *string `cty:"desync_mitigation_mode"`
+
+	IdleTimeout *terraformWriter.Literal `cty:"idle_timeout"`
+
+	Timeouts *terraformLoadBalancerTimeouts `cty:"timeouts"`
+
+	// Tags is either a map[string]string, or a *terraformWriter.Literal when merging in the common tags variable.
+	Tags interface{} `cty:"tags"`
+}
 
-	Tags map[string]string `cty:"tags"`
+type terraformLoadBalancerTimeouts struct {
+	Create *string `cty:"create"`
+	Delete *string `cty:"delete"`
 }
 
 type terraformLoadBalancerListener struct {
-	InstancePort     int32   `cty:"instance_port"`
-	InstanceProtocol string  `cty:"instance_protocol"`
-	LBPort           int32   `cty:"lb_port"`
-	LBProtocol       string  `cty:"lb_protocol"`
-	SSLCertificateID *string `cty:"ssl_certificate_id"`
+	InstancePort     int32                    `cty:"instance_port"`
+	InstanceProtocol string                   `cty:"instance_protocol"`
+	LBPort           int32                    `cty:"lb_port"`
+	LBProtocol       string                   `cty:"lb_protocol"`
+	SSLCertificateID *terraformWriter.Literal `cty:"ssl_certificate_id"`
+}
+
+type terraformACMCertificateData struct {
+	Domain     *string `cty:"domain"`
+	MostRecent *bool   `cty:"most_recent"`
+}
+
+// renderTerraformListenerCertificate returns the certificate of a Classic or Network load balancer listener.
+// ACM certificates that are mapped to a domain in ACMCertificateDomains reference an aws_acm_certificate data source,
+// so that the configuration follows the certificate when it is managed outside kOps; other certificates are embedded as is.
+func renderTerraformListenerCertificate(t *terraform.TerraformTarget, certificateARN string) (*terraformWriter.Literal, error) {
+	domain, found := t.ACMCertificateDomains[certificateARN]
+	if !found {
+		return terraformWriter.LiteralFromStringValue(certificateARN), nil
+	}
+
+	certificateID, ok := acmCertificateID(certificateARN)
+	if !ok {
+		return nil, fmt.Errorf("certificate %q is not an ACM certificate, so cannot be looked up by domain", certificateARN)
+	}
+
+	dataName := "acm-" + certificateID
+	tf := &terraformACMCertificateData{
+		Domain:     fi.PtrTo(domain),
+		MostRecent: fi.PtrTo(true),
+	}
+	if err := t.RenderSharedDataSource("aws_acm_certificate", dataName, tf); err != nil {
+		return nil, err
+	}
+	return terraformWriter.LiteralData("aws_acm_certificate", dataName, "arn"), nil
 }
 
 type terraformLoadBalancerHealthCheck struct {
@@ -667,7 +1622,8 @@ func (_ *ClassicLoadBalancer) RenderTerraform(t *terraform.TerraformTarget, a, e
 	tf := &terraformLoadBalancer{
 		LoadBalancerName: e.LoadBalancerName,
 	}
-	if fi.ValueOf(e.Scheme) == "internal" {
+	// Only internal ELBs set internal, so that internet-facing ones render the same whether the scheme is unset or not
+	if fi.ValueOf(normalizeScheme(e.Scheme)) == schemeInternal {
 		tf.Internal = fi.PtrTo(true)
 	}
 
@@ -687,26 +1643,25 @@ func (_ *ClassicLoadBalancer) RenderTerraform(t *terraform.TerraformTarget, a, e
 			return fmt.Errorf("error parsing load balancer listener port: %q", loadBalancerPort)
 		}
 
+		tfListener := &terraformLoadBalancerListener{
+			InstanceProtocol: listener.instanceProtocol(),
+			InstancePort:     listener.InstancePort,
+			LBPort:           int32(loadBalancerPortInt),
+			LBProtocol:       listener.protocol(),
+		}
 		if listener.SSLCertificateID != "" {
-			tf.Listener = append(tf.Listener, &terraformLoadBalancerListener{
-				InstanceProtocol: "SSL",
-				InstancePort:     listener.InstancePort,
-				LBPort:           int32(loadBalancerPortInt),
-				LBProtocol:       "SSL",
-				SSLCertificateID: &listener.SSLCertificateID,
-			})
-		} else {
-			tf.Listener = append(tf.Listener, &terraformLoadBalancerListener{
-				InstanceProtocol: "TCP",
-				InstancePort:     listener.InstancePort,
-				LBPort:           int32(loadBalancerPortInt),
-				LBProtocol:       "TCP",
-			})
+			certificate, err := renderTerraformListenerCertificate(t, listener.SSLCertificateID)
+			if err != nil {
+				return err
+			}
+			tfListener.SSLCertificateID = certificate
 		}
-
+		tf.Listener = append(tf.Listener, tfListener)
 	}
 
 	if e.HealthCheck != nil {
+		// The aws_elb resource has no separate protocol/port/path fields, so the target is rendered
+		// in the packed <protocol>:<port>[<path>] form; see ClassicLoadBalancerHealthCheckTarget.
 		tf.HealthCheck = &terraformLoadBalancerHealthCheck{
 			Target:             e.HealthCheck.Target,
 			HealthyThreshold:   e.HealthCheck.HealthyThreshold,
@@ -730,21 +1685,100 @@ func (_ *ClassicLoadBalancer) RenderTerraform(t *terraform.TerraformTarget, a, e
 		tf.ConnectionDrainingTimeout = e.ConnectionDraining.Timeout
 	}
 
-	if e.ConnectionSettings != nil {
-		tf.IdleTimeout = e.ConnectionSettings.IdleTimeout
+	if e.ConnectionSettings != nil && e.ConnectionSettings.IdleTimeout != nil {
+		tf.IdleTimeout = terraformWriter.LiteralFromIntValue(*e.ConnectionSettings.IdleTimeout)
+		if t.LoadBalancerIdleTimeoutVariables {
+			variable := e.terraformIdleTimeoutVariable()
+			if err := t.AddInputVariable(variable, tf.IdleTimeout); err != nil {
+				return err
+			}
+			tf.IdleTimeout = terraformWriter.LiteralVariable(variable)
+		}
 	}
 
 	if e.CrossZoneLoadBalancing != nil {
 		tf.CrossZoneLoadBalancing = e.CrossZoneLoadBalancing.Enabled
 	}
 
+	tf.DesyncMitigationMode = e.DesyncMitigationMode
+
+	if timeouts := t.LoadBalancerTimeouts; !timeouts.IsEmpty() {
+		if err := timeouts.Validate(); err != nil {
+			return fmt.Errorf("invalid load balancer timeouts: %w", err)
+		}
+		tf.Timeouts = &terraformLoadBalancerTimeouts{}
+		if timeouts.Create != "" {
+			tf.Timeouts.Create = fi.PtrTo(timeouts.Create)
+		}
+		if timeouts.Delete != "" {
+			tf.Timeouts.Delete = fi.PtrTo(timeouts.Delete)
+		}
+	}
+
 	tags := cloud.BuildTags(e.Name)
 	for k, v := range e.Tags {
 		tags[k] = v
 	}
 	tf.Tags = tags
+	if t.CommonTagsVariable != "" {
+		if err := t.AddInputVariable(t.CommonTagsVariable, terraformWriter.LiteralTokens("{}")); err != nil {
+			return err
+		}
+		tf.Tags = terraformWriter.LiteralFunctionExpression("merge",
+			terraformWriter.LiteralVariable(t.CommonTagsVariable),
+			terraformWriter.LiteralMapExpression(tags),
+		)
+	}
+
+	if e.terraformCreateVariable != "" {
+		if err := t.AddInputVariable(e.terraformCreateVariable, terraformWriter.LiteralTokens("true")); err != nil {
+			return err
+		}
+		tf.Count = e.terraformCount()
+	}
+
+	if e.terraformImportName != "" {
+		if e.terraformCreateVariable != "" {
+			return fmt.Errorf("ELB %q cannot be imported, because its creation is controlled by variable %q", e.terraformImportName, e.terraformCreateVariable)
+		}
+		if err := t.AddImport("aws_elb", e.terraformResourceName(), e.terraformImportName); err != nil {
+			return err
+		}
+	}
+
+	if err := t.RenderResource("aws_elb", e.terraformResourceName(), tf); err != nil {
+		return err
+	}
 
-	return t.RenderResource("aws_elb", *e.Name, tf)
+	if command := t.LoadBalancerLocalExecCommand; command != "" {
+		if err := t.AddLocalExecProvisioner("aws_elb", e.terraformResourceName(), command); err != nil {
+			return err
+		}
+	}
+
+	return e.renderTerraformSSLPolicies(t, tf.Count)
+}
+
+// terraformCount returns the count of the ELB when its creation is controlled by a terraform variable, or nil.
+// Resources that only exist for the ELB, such as its DNS records, use the same count.
+func (e *ClassicLoadBalancer) terraformCount() *terraformWriter.Literal {
+	if e.terraformCreateVariable == "" || fi.ValueOf(e.Shared) {
+		return nil
+	}
+	return terraformWriter.LiteralConditionalExpression(
+		terraformWriter.LiteralVariable(e.terraformCreateVariable),
+		terraformWriter.LiteralFromIntValue(1),
+		terraformWriter.LiteralFromIntValue(0),
+	)
+}
+
+func (e *ClassicLoadBalancer) terraformResourceName() string {
+	return e.terraformResourceNamePrefix + fi.ValueOf(e.Name)
+}
+
+// terraformIdleTimeoutVariable is the name of the terraform variable holding the idle timeout of the ELB.
+func (e *ClassicLoadBalancer) terraformIdleTimeoutVariable() string {
+	return strings.NewReplacer(".", "_", "-", "_").Replace(e.terraformResourceName()) + "_idle_timeout"
 }
 
 func (e *ClassicLoadBalancer) TerraformLink(params ...string) *terraformWriter.Literal {
@@ -762,5 +1796,8 @@ func (e *ClassicLoadBalancer) TerraformLink(params ...string) *terraformWriter.L
 	if len(params) > 0 {
 		prop = params[0]
 	}
-	return terraformWriter.LiteralProperty("aws_elb", *e.Name, prop)
+	if e.terraformCreateVariable != "" {
+		return terraformWriter.LiteralFunctionExpression("one", terraformWriter.LiteralSplatProperty("aws_elb", e.terraformResourceName(), prop))
+	}
+	return terraformWriter.LiteralProperty("aws_elb", e.terraformResourceName(), prop)
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// ELBTaskError is returned when an operation on a Classic load balancer fails.
+type ELBTaskError struct {
+	// Operation describes what was being done, e.g. "creating ELB"
+	Operation string
+	// LoadBalancerName is the name of the ELB the operation was performed on
+	LoadBalancerName string
+	// Err is the underlying error, usually returned by the AWS API
+	Err error
+}
+
+var _ fi.RetryableError = &ELBTaskError{}
+
+func newELBTaskError(operation string, loadBalancerName string, err error) *ELBTaskError {
+	return &ELBTaskError{
+		Operation:        operation,
+		LoadBalancerName: loadBalancerName,
+		Err:              err,
+	}
+}
+
+func (e *ELBTaskError) Error() string {
+	return fmt.Sprintf("error %s for ELB %q: %v", e.Operation, e.LoadBalancerName, e.Err)
+}
+
+func (e *ELBTaskError) Unwrap() error {
+	return e.Err
+}
+
+// nonRetryableELBErrorCodes are the ELB API error codes that won't go away by retrying,
+// because they are caused by the request or the account limits rather than eventual consistency.
+// InvalidSecurityGroup, InvalidSubnet, CertificateNotFound and InvalidConfigurationRequest are deliberately not listed:
+// ELB returns them for security groups, subnets and certificates created moments before in the same run,
+// until they have propagated, so they are retried until the task deadline.
+var nonRetryableELBErrorCodes = sets.New[string](
+	"AccessDenied",
+	"DuplicateLoadBalancerName",
+	"DuplicateTagKeys",
+	"InvalidScheme",
+	"OperationNotPermitted",
+	"TooManyLoadBalancers",
+	"TooManyTags",
+	"UnauthorizedOperation",
+	"UnsupportedProtocol",
+	"ValidationError",
+)
+
+// IsRetryable returns false if the underlying error is known to be permanent.
+// Errors without an AWS error code, such as network errors, are considered retryable.
+func (e *ELBTaskError) IsRetryable() bool {
+	return !nonRetryableELBErrorCodes.Has(awsup.AWSErrorCode(e.Err))
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/smithy-go"
+	"k8s.io/kops/cloudmock/aws/mockelb"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestELBTaskErrorIsRetryable(t *testing.T) {
+	grid := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{
+			name:      "throttling",
+			err:       &smithy.GenericAPIError{Code: "Throttling", Message: "Rate exceeded"},
+			retryable: true,
+		},
+		{
+			name:      "network error",
+			err:       fmt.Errorf("dial tcp: i/o timeout"),
+			retryable: true,
+		},
+		{
+			name:      "invalid subnet",
+			err:       &smithy.GenericAPIError{Code: "InvalidSubnet", Message: "subnet-123 does not exist"},
+			retryable: true,
+		},
+		{
+			name:      "invalid security group",
+			err:       &smithy.GenericAPIError{Code: "InvalidSecurityGroup", Message: "sg-123 does not exist"},
+			retryable: true,
+		},
+		{
+			name:      "certificate not found",
+			err:       &smithy.GenericAPIError{Code: "CertificateNotFound", Message: "Certificate not found"},
+			retryable: true,
+		},
+		{
+			name:      "duplicate load balancer name",
+			err:       &smithy.GenericAPIError{Code: "DuplicateLoadBalancerName"},
+			retryable: false,
+		},
+		{
+			name:      "too many load balancers",
+			err:       fmt.Errorf("wrapped: %w", &smithy.GenericAPIError{Code: "TooManyLoadBalancers"}),
+			retryable: false,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			err := fmt.Errorf("running task: %w", newELBTaskError("creating ELB", "api-example-com", g.err))
+
+			var retryableError fi.RetryableError
+			if !errors.As(err, &retryableError) {
+				t.Fatalf("expected %v to be a RetryableError", err)
+			}
+			if actual := retryableError.IsRetryable(); actual != g.retryable {
+				t.Errorf("expected IsRetryable()=%v, got %v", g.retryable, actual)
+			}
+		})
+	}
+}
+
+// propagatingSecurityGroupMockELB rejects the first ELB creations with InvalidSecurityGroup,
+// as ELB does while a security group created moments before is still propagating.
+type propagatingSecurityGroupMockELB struct {
+	*mockelb.MockELB
+	rejections int
+}
+
+func (m *propagatingSecurityGroupMockELB) CreateLoadBalancer(ctx context.Context, request *elb.CreateLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerOutput, error) {
+	if m.rejections > 0 {
+		m.rejections--
+		return nil, &smithy.GenericAPIError{Code: "InvalidSecurityGroup", Message: "One or more security groups are invalid"}
+	}
+	return m.MockELB.CreateLoadBalancer(ctx, request, optFns...)
+}
+
+func TestClassicLoadBalancerConvergesWithNewSecurityGroup(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &propagatingSecurityGroupMockELB{MockELB: &mockelb.MockELB{}, rejections: 2}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	// The security group and subnet were created earlier in the run; they are not touched again here
+	sg := &SecurityGroup{Name: fi.PtrTo("api-elb"), ID: fi.PtrTo("sg-new"), Lifecycle: fi.LifecycleIgnore}
+	subnet := &Subnet{Name: fi.PtrTo("subnet-a"), ID: fi.PtrTo("subnet-a"), Lifecycle: fi.LifecycleIgnore}
+	lb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		Lifecycle:        fi.LifecycleSync,
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		SecurityGroups:   []*SecurityGroup{sg},
+		Subnets:          []*Subnet{subnet},
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	tasks := map[string]fi.CloudupTask{
+		"SecurityGroup/api-elb":               sg,
+		"Subnet/subnet-a":                     subnet,
+		"ClassicLoadBalancer/api.example.com": lb,
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, tasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	if err := c.RunTasks(fi.RunTasksOptions{MaxTaskDuration: time.Minute, WaitAfterAllTasksFailed: time.Millisecond}); err != nil {
+		t.Fatalf("expected the ELB to be created once the security group propagated, got %v", err)
+	}
+
+	if m.rejections != 0 {
+		t.Errorf("expected every rejected creation to be retried, %d left", m.rejections)
+	}
+	if m.LoadBalancers["api-example-com"] == nil {
+		t.Errorf("expected the ELB to have been created")
+	}
+}
@@ -0,0 +1,3359 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/smithy-go"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/cloudmock/aws/mockelb"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/featureflag"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestValidateSharedLoadBalancer(t *testing.T) {
+	grid := []struct {
+		name         string
+		actual       *ClassicLoadBalancer
+		clusterZones []string
+		expected     []string
+	}{
+		{
+			name: "single-AZ shared LB in multi-AZ cluster",
+			actual: &ClassicLoadBalancer{
+				LoadBalancerName:       fi.PtrTo("shared"),
+				CrossZoneLoadBalancing: &ClassicLoadBalancerCrossZoneLoadBalancing{Enabled: fi.PtrTo(false)},
+				availabilityZones:      []string{"us-test-1a"},
+			},
+			clusterZones: []string{"us-test-1a", "us-test-1b", "us-test-1c"},
+			expected:     []string{"not attached to zones [us-test-1b us-test-1c]"},
+		},
+		{
+			name: "single-AZ shared LB with cross-zone enabled",
+			actual: &ClassicLoadBalancer{
+				LoadBalancerName:       fi.PtrTo("shared"),
+				CrossZoneLoadBalancing: &ClassicLoadBalancerCrossZoneLoadBalancing{Enabled: fi.PtrTo(true)},
+				availabilityZones:      []string{"us-test-1a"},
+			},
+			clusterZones: []string{"us-test-1a", "us-test-1b"},
+		},
+		{
+			name: "single-AZ shared LB in single-AZ cluster",
+			actual: &ClassicLoadBalancer{
+				LoadBalancerName:       fi.PtrTo("shared"),
+				CrossZoneLoadBalancing: &ClassicLoadBalancerCrossZoneLoadBalancing{Enabled: fi.PtrTo(false)},
+				availabilityZones:      []string{"us-test-1a"},
+			},
+			clusterZones: []string{"us-test-1a"},
+		},
+		{
+			name: "short idle timeout",
+			actual: &ClassicLoadBalancer{
+				LoadBalancerName:   fi.PtrTo("shared"),
+				ConnectionSettings: &ClassicLoadBalancerConnectionSettings{IdleTimeout: fi.PtrTo(int32(10))},
+			},
+			expected: []string{"idle timeout of 10s"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			e := &ClassicLoadBalancer{Shared: fi.PtrTo(true)}
+			e.SetClusterZones(g.clusterZones)
+
+			warnings := validateSharedLoadBalancer(g.actual, e)
+			if len(warnings) != len(g.expected) {
+				t.Fatalf("unexpected warnings, expected %d got %v", len(g.expected), warnings)
+			}
+			for i, expected := range g.expected {
+				if !strings.Contains(warnings[i], expected) {
+					t.Errorf("expected warning to contain %q, got %q", expected, warnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSharedLoadBalancerFields(t *testing.T) {
+	grid := []struct {
+		name     string
+		elb      *ClassicLoadBalancer
+		expected string
+	}{
+		{
+			name: "shared",
+			elb: &ClassicLoadBalancer{
+				Name:   fi.PtrTo("shared"),
+				Shared: fi.PtrTo(true),
+			},
+		},
+		{
+			name: "shared with subnets",
+			elb: &ClassicLoadBalancer{
+				Name:    fi.PtrTo("shared"),
+				Shared:  fi.PtrTo(true),
+				Subnets: []*Subnet{{Name: fi.PtrTo("us-test-1a.example.com")}},
+			},
+			expected: `shared load balancer "shared" must not specify subnets`,
+		},
+		{
+			name: "shared with security groups",
+			elb: &ClassicLoadBalancer{
+				Name:           fi.PtrTo("shared"),
+				Shared:         fi.PtrTo(true),
+				SecurityGroups: []*SecurityGroup{{Name: fi.PtrTo("api-elb.example.com")}},
+			},
+			expected: `shared load balancer "shared" must not specify security groups`,
+		},
+		{
+			name: "owned with subnets and security groups",
+			elb: &ClassicLoadBalancer{
+				Name:           fi.PtrTo("api.example.com"),
+				Shared:         fi.PtrTo(false),
+				Subnets:        []*Subnet{{Name: fi.PtrTo("us-test-1a.example.com")}},
+				SecurityGroups: []*SecurityGroup{{Name: fi.PtrTo("api-elb.example.com")}},
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			err := g.elb.CheckChanges(nil, g.elb, g.elb)
+			if g.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), g.expected) {
+				t.Errorf("expected error containing %q, got %v", g.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateNodePorts(t *testing.T) {
+	grid := []struct {
+		name     string
+		elb      *ClassicLoadBalancer
+		expected []string
+	}{
+		{
+			name: "in range",
+			elb: &ClassicLoadBalancer{
+				Name:        fi.PtrTo("ingress"),
+				Listeners:   map[string]*ClassicLoadBalancerListener{"80": {InstancePort: 30080}},
+				HealthCheck: &ClassicLoadBalancerHealthCheck{Target: fi.PtrTo("HTTP:30254/healthz")},
+			},
+		},
+		{
+			name: "out-of-range NodePort",
+			elb: &ClassicLoadBalancer{
+				Name:        fi.PtrTo("ingress"),
+				Listeners:   map[string]*ClassicLoadBalancerListener{"80": {InstancePort: 30080}, "443": {InstancePort: 443}},
+				HealthCheck: &ClassicLoadBalancerHealthCheck{Target: fi.PtrTo("TCP:8443")},
+			},
+			expected: []string{
+				"listener 443 forwards to instance port 443, which is outside the NodePort range 30000-32767",
+				"health check targets instance port 8443, which is outside the NodePort range 30000-32767",
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			g.elb.SetNodePortRange(utilnet.PortRange{Base: 30000, Size: 2768})
+
+			warnings := validateNodePorts(g.elb)
+			if len(warnings) != len(g.expected) {
+				t.Fatalf("unexpected warnings, expected %d got %v", len(g.expected), warnings)
+			}
+			for i, expected := range g.expected {
+				if !strings.Contains(warnings[i], expected) {
+					t.Errorf("expected warning to contain %q, got %q", expected, warnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateListenerPorts(t *testing.T) {
+	grid := []struct {
+		name      string
+		listeners map[string]*ClassicLoadBalancerListener
+		expected  string
+	}{
+		{
+			name:      "distinct ports",
+			listeners: map[string]*ClassicLoadBalancerListener{"80": {InstancePort: 30080}, "443": {InstancePort: 30443}},
+		},
+		{
+			name:      "same port written differently",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443}, "0443": {InstancePort: 8443}},
+			expected:  `load balancer listeners "0443" and "443" both use port 443`,
+		},
+		{
+			name:      "invalid port",
+			listeners: map[string]*ClassicLoadBalancerListener{"https": {InstancePort: 443}},
+			expected:  `invalid load balancer listener port "https"`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			err := validateListenerPorts(g.listeners)
+			if g.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != g.expected {
+				t.Errorf("expected error %q, got %v", g.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateListenerProtocols(t *testing.T) {
+	grid := []struct {
+		name      string
+		listeners map[string]*ClassicLoadBalancerListener
+		expected  string
+	}{
+		{
+			name:      "default instance protocols",
+			listeners: map[string]*ClassicLoadBalancerListener{"80": {InstancePort: 80}, "443": {InstancePort: 443, SSLCertificateID: "arn:cert"}},
+		},
+		{
+			name:      "SSL front-end with TCP backend",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, SSLCertificateID: "arn:cert", InstanceProtocol: fi.PtrTo("TCP")}},
+		},
+		{
+			name:      "TCP front-end with SSL backend",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, InstanceProtocol: fi.PtrTo("ssl")}},
+		},
+		{
+			name:      "SSL front-end with HTTP backend",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 80, SSLCertificateID: "arn:cert", InstanceProtocol: fi.PtrTo("HTTP")}},
+			expected:  `instance protocol "HTTP" of load balancer listener "443" cannot be used with front-end protocol SSL; must be TCP or SSL`,
+		},
+		{
+			name:      "HTTP listener",
+			listeners: map[string]*ClassicLoadBalancerListener{"80": {InstancePort: 8080, Protocol: fi.PtrTo("HTTP")}},
+		},
+		{
+			name:      "HTTPS front-end with HTTP backend",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 8080, Protocol: fi.PtrTo("HTTPS"), SSLCertificateID: "arn:cert", InstanceProtocol: fi.PtrTo("HTTP")}},
+		},
+		{
+			name:      "HTTPS front-end with TCP backend",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, Protocol: fi.PtrTo("HTTPS"), SSLCertificateID: "arn:cert", InstanceProtocol: fi.PtrTo("TCP")}},
+			expected:  `instance protocol "TCP" of load balancer listener "443" cannot be used with front-end protocol HTTPS; must be HTTP or HTTPS`,
+		},
+		{
+			name:      "HTTPS listener without certificate",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, Protocol: fi.PtrTo("HTTPS")}},
+			expected:  `load balancer listener "443" with protocol HTTPS requires an SSL certificate`,
+		},
+		{
+			name:      "HTTP listener with certificate",
+			listeners: map[string]*ClassicLoadBalancerListener{"80": {InstancePort: 80, Protocol: fi.PtrTo("HTTP"), SSLCertificateID: "arn:cert"}},
+			expected:  `load balancer listener "80" with protocol HTTP cannot have an SSL certificate; use SSL or HTTPS`,
+		},
+		{
+			name:      "unsupported protocol",
+			listeners: map[string]*ClassicLoadBalancerListener{"53": {InstancePort: 53, Protocol: fi.PtrTo("UDP")}},
+			expected:  `unsupported protocol "UDP" of load balancer listener "53"; must be TCP, SSL, HTTP or HTTPS`,
+		},
+		{
+			name:      "SSL policy on HTTPS listener",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, Protocol: fi.PtrTo("HTTPS"), SSLCertificateID: "arn:cert", SSLPolicy: fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01")}},
+		},
+		{
+			name:      "SSL policy on SSL listener",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, SSLCertificateID: "arn:cert", SSLPolicy: fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01")}},
+		},
+		{
+			name:      "SSL policy on TCP listener",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, SSLPolicy: fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01")}},
+			expected:  `load balancer listener "443" sets an SSL policy, which requires an SSL certificate`,
+		},
+		{
+			name:      "empty SSL policy",
+			listeners: map[string]*ClassicLoadBalancerListener{"443": {InstancePort: 443, SSLCertificateID: "arn:cert", SSLPolicy: fi.PtrTo("")}},
+			expected:  `SSL policy of load balancer listener "443" must not be empty`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			err := validateListenerProtocols(g.listeners)
+			if g.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != g.expected {
+				t.Errorf("expected error %q, got %v", g.expected, err)
+			}
+		})
+	}
+}
+
+func TestValidateCrossZoneLoadBalancing(t *testing.T) {
+	grid := []struct {
+		name      string
+		crossZone *bool
+		zones     []string
+		expected  string
+	}{
+		{
+			name:      "single zone with cross-zone",
+			crossZone: fi.PtrTo(true),
+			zones:     []string{"us-test-1a", "us-test-1a"},
+			expected:  "cross-zone load balancing enabled, but its subnets are all in zone us-test-1a",
+		},
+		{
+			name:      "multiple zones with cross-zone",
+			crossZone: fi.PtrTo(true),
+			zones:     []string{"us-test-1a", "us-test-1b"},
+		},
+		{
+			name:      "single zone without cross-zone",
+			crossZone: fi.PtrTo(false),
+			zones:     []string{"us-test-1a"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			e := &ClassicLoadBalancer{
+				Name:                   fi.PtrTo("api.example.com"),
+				CrossZoneLoadBalancing: &ClassicLoadBalancerCrossZoneLoadBalancing{Enabled: g.crossZone},
+			}
+			for _, zone := range g.zones {
+				e.Subnets = append(e.Subnets, &Subnet{AvailabilityZone: fi.PtrTo(zone)})
+			}
+
+			warning := validateCrossZoneLoadBalancing(e)
+			if g.expected == "" {
+				if warning != "" {
+					t.Errorf("unexpected warning %q", warning)
+				}
+			} else if !strings.Contains(warning, g.expected) {
+				t.Errorf("expected warning to contain %q, got %q", g.expected, warning)
+			}
+		})
+	}
+}
+
+func TestValidateHealthCheckProtocol(t *testing.T) {
+	grid := []struct {
+		target   string
+		expected string
+	}{
+		{
+			target:   "HTTPS:443/healthz",
+			expected: "health check \"HTTPS:443/healthz\" cannot send SNI or set the Host header",
+		},
+		{
+			target: "HTTP:3990/readyz",
+		},
+		{
+			target: "SSL:443",
+		},
+		{
+			target: "TCP:443",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.target, func(t *testing.T) {
+			e := &ClassicLoadBalancer{
+				Name:        fi.PtrTo("api.example.com"),
+				HealthCheck: &ClassicLoadBalancerHealthCheck{Target: fi.PtrTo(g.target)},
+			}
+
+			warning := validateHealthCheckProtocol(e)
+			if g.expected == "" {
+				if warning != "" {
+					t.Errorf("unexpected warning %q", warning)
+				}
+			} else if !strings.Contains(warning, g.expected) {
+				t.Errorf("expected warning to contain %q, got %q", g.expected, warning)
+			}
+		})
+	}
+}
+
+func TestValidateHealthCheckPort(t *testing.T) {
+	grid := []struct {
+		name        string
+		target      string
+		sidecarPort int32
+		expected    string
+	}{
+		{
+			name:   "listener instance port",
+			target: "SSL:443",
+		},
+		{
+			name:     "mismatched port",
+			target:   "TCP:8443",
+			expected: `health check "TCP:8443" targets port 8443, which none of the listener instance ports [443 30080] forward to`,
+		},
+		{
+			name:        "sidecar port",
+			target:      "HTTP:3990/readyz",
+			sidecarPort: 3990,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			e := &ClassicLoadBalancer{
+				Name: fi.PtrTo("api.example.com"),
+				Listeners: map[string]*ClassicLoadBalancerListener{
+					"443": {InstancePort: 443},
+					"80":  {InstancePort: 30080},
+				},
+				HealthCheck: &ClassicLoadBalancerHealthCheck{Target: fi.PtrTo(g.target)},
+			}
+			e.SetHealthCheckSidecarPort(g.sidecarPort)
+
+			warning := validateHealthCheckPort(e)
+			if g.expected == "" {
+				if warning != "" {
+					t.Errorf("unexpected warning %q", warning)
+				}
+			} else if !strings.Contains(warning, g.expected) {
+				t.Errorf("expected warning to contain %q, got %q", g.expected, warning)
+			}
+		})
+	}
+}
+
+func TestClassicLoadBalancerTerraformResourceNamePrefix(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Subnets: []*Subnet{
+			{Name: fi.PtrTo("us-test-1a.example.com")},
+		},
+		SecurityGroups: []*SecurityGroup{
+			{Name: fi.PtrTo("api-elb.example.com")},
+		},
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+	elb.SetTerraformResourceNamePrefix("prod_")
+
+	if actual, expected := elb.TerraformLink().String, "aws_elb.prod_api-example-com.id"; actual != expected {
+		t.Errorf("unexpected terraform link, expected %q got %q", expected, actual)
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "prod_api-example-com" {
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name            = "api-example-com"
+  security_groups = [aws_security_group.api-elb-example-com.id]
+  subnets         = [aws_subnet.us-test-1a-example-com.id]
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerTerraformImport(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+	elb.SetTerraformImportName("api-existing")
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-existing"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+import {
+  id = "api-existing"
+  to = aws_elb.api-example-com
+}
+
+terraform {
+  required_version = ">= 1.5.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+
+	// ELBs without an import name are created, not imported
+	other := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+	target := terraform.NewTerraformTarget(awsup.BuildMockAWSCloud("eu-west-2", "abc"), "test", t.TempDir(), nil)
+	if err := other.RenderTerraform(target, other, other, other); err != nil {
+		t.Fatalf("unexpected error from RenderTerraform: %v", err)
+	}
+	if imports := target.GetImports(); len(imports) != 0 {
+		t.Errorf("expected no imports for an ELB without an import name, got %v", imports)
+	}
+}
+
+func TestClassicLoadBalancerTerraformAccessLogProviderDefaults(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		AccessLog: &ClassicLoadBalancerAccessLog{
+			Enabled:        fi.PtrTo(true),
+			EmitInterval:   fi.PtrTo(int32(60)),
+			S3BucketName:   fi.PtrTo("access-logs"),
+			S3BucketPrefix: fi.PtrTo("api"),
+		},
+	}
+
+	// enabled and interval are the provider defaults, so are not rendered
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  access_logs {
+    bucket        = "access-logs"
+    bucket_prefix = "api"
+  }
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerTerraformCommonTagsVariable(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		Tags: map[string]string{
+			"KubernetesCluster": "example.com",
+		},
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			ConfigureTerraform: func(target *terraform.TerraformTarget) {
+				target.CommonTagsVariable = "common_tags"
+			},
+			Expected: `variable "common_tags" {
+  default = {}
+}
+
+provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = merge(var.common_tags, { "KubernetesCluster" = "example.com", "Name" = "api.example.com" })
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerTerraformACMCertificateDataSource(t *testing.T) {
+	const certificateARN = "arn:aws:acm:eu-west-2:123456789012:certificate/0a1b2c3d-4e5f-6789-abcd-ef0123456789"
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443, SSLCertificateID: certificateARN},
+		},
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			ConfigureTerraform: func(target *terraform.TerraformTarget) {
+				target.ACMCertificateDomains = map[string]string{certificateARN: "api.example.com"}
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port      = 443
+    instance_protocol  = "SSL"
+    lb_port            = 443
+    lb_protocol        = "SSL"
+    ssl_certificate_id = data.aws_acm_certificate.acm-0a1b2c3d-4e5f-6789-abcd-ef0123456789.arn
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+data "aws_acm_certificate" "acm-0a1b2c3d-4e5f-6789-abcd-ef0123456789" {
+  domain      = "api.example.com"
+  most_recent = true
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+func TestACMCertificateID(t *testing.T) {
+	grid := []struct {
+		arn      string
+		expected string
+	}{
+		{arn: "arn:aws:acm:eu-west-2:123456789012:certificate/0a1b2c3d", expected: "0a1b2c3d"},
+		{arn: "arn:aws-cn:acm:cn-north-1:123456789012:certificate/0a1b2c3d", expected: "0a1b2c3d"},
+		{arn: "arn:aws:iam::123456789012:server-certificate/api"},
+		{arn: "arn:aws:acm:eu-west-2:123456789012:certificate/"},
+		{arn: "not-an-arn"},
+	}
+	for _, g := range grid {
+		id, ok := acmCertificateID(g.arn)
+		if ok != (g.expected != "") || id != g.expected {
+			t.Errorf("unexpected result for %q: expected %q, got %q (ok=%v)", g.arn, g.expected, id, ok)
+		}
+	}
+
+	target := terraform.NewTerraformTarget(awsup.BuildMockAWSCloud("eu-west-2", "abc"), "test", t.TempDir(), nil)
+	target.ACMCertificateDomains = map[string]string{
+		"arn:aws:acm:eu-west-2:123456789012:certificate/0a1b2c3d": "api.example.com",
+		"arn:aws:iam::123456789012:server-certificate/api":        "api.example.com",
+	}
+	if _, err := renderTerraformListenerCertificate(target, "arn:aws:iam::123456789012:server-certificate/api"); err == nil {
+		t.Errorf("expected an error for an IAM server certificate mapped to a domain")
+	}
+
+	// Listeners sharing a certificate share its data source
+	for i := 0; i < 2; i++ {
+		if _, err := renderTerraformListenerCertificate(target, "arn:aws:acm:eu-west-2:123456789012:certificate/0a1b2c3d"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	dataSources, err := target.GetDataSourcesByType()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dataSources["aws_acm_certificate"]) != 1 {
+		t.Errorf("expected a single aws_acm_certificate data source, got %v", dataSources)
+	}
+}
+
+func TestClassicLoadBalancerTerraformTimeouts(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			ConfigureTerraform: func(target *terraform.TerraformTarget) {
+				target.LoadBalancerTimeouts = terraformWriter.Timeouts{Create: "30m", Delete: "20m"}
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+  timeouts {
+    create = "30m"
+    delete = "20m"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerTerraformScheme(t *testing.T) {
+	newELB := func(scheme *string) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Scheme:           scheme,
+			Listeners: map[string]*ClassicLoadBalancerListener{
+				"443": {InstancePort: 443},
+			},
+		}
+	}
+
+	internal := `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  internal = true
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`
+	internetFacing := `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{Resource: newELB(fi.PtrTo("internal")), Expected: internal},
+		{Resource: newELB(fi.PtrTo("Internal")), Expected: internal},
+		{Resource: newELB(nil), Expected: internetFacing},
+		{Resource: newELB(fi.PtrTo("internet-facing")), Expected: internetFacing},
+	})
+}
+
+func TestClassicLoadBalancerTerraformIdleTimeoutVariable(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			ConfigureTerraform: func(target *terraform.TerraformTarget) {
+				target.LoadBalancerIdleTimeoutVariables = true
+			},
+			Expected: `variable "api_example_com_idle_timeout" {
+  default = 300
+}
+
+provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  idle_timeout = var.api_example_com_idle_timeout
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerTerraformLocalExecProvisioner(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			ConfigureTerraform: func(target *terraform.TerraformTarget) {
+				target.LocalExecProvisioners = true
+				target.LoadBalancerLocalExecCommand = `dig +short "${self.dns_name}"`
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  provisioner "local-exec" {
+    command = "dig +short \"${self.dns_name}\""
+  }
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: elb,
+			ConfigureTerraform: func(target *terraform.TerraformTarget) {
+				target.Syntax = terraformWriter.SyntaxJSON
+				target.LocalExecProvisioners = true
+				target.LoadBalancerLocalExecCommand = `dig +short "${self.dns_name}"`
+			},
+			Expected: `{
+  "provider": {
+    "aws": {
+      "region": "eu-west-2"
+    }
+  },
+  "resource": {
+    "aws_elb": {
+      "api-example-com": {
+        "listener": [
+          {
+            "instance_port": 443,
+            "instance_protocol": "TCP",
+            "lb_port": 443,
+            "lb_protocol": "TCP"
+          }
+        ],
+        "name": "api-example-com",
+        "provisioner": [
+          {
+            "local-exec": {
+              "command": "dig +short \"${self.dns_name}\""
+            }
+          }
+        ],
+        "tags": {
+          "Name": "api.example.com"
+        }
+      }
+    }
+  },
+  "terraform": {
+    "required_providers": {
+      "aws": {
+        "source": "hashicorp/aws",
+        "version": ">= 5.0.0"
+      }
+    },
+    "required_version": ">= 0.15.0"
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerTerraformLocalExecProvisionerNotEnabled(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+
+	cloud := awsup.BuildMockAWSCloud("eu-west-2", "abc")
+	target := terraform.NewTerraformTarget(cloud, "test", "", nil)
+	target.LoadBalancerLocalExecCommand = "true"
+
+	err := elb.RenderTerraform(target, nil, elb, nil)
+	if err == nil || !strings.Contains(err.Error(), "local-exec provisioners are not enabled") {
+		t.Fatalf("expected error because local-exec provisioners are not enabled, got %v", err)
+	}
+}
+
+func TestClassicLoadBalancerTerraformRenderJSON(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Subnets: []*Subnet{
+			{Name: fi.PtrTo("us-test-1a.example.com")},
+		},
+		SecurityGroups: []*SecurityGroup{
+			{Name: fi.PtrTo("api-elb.example.com")},
+		},
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		HealthCheck: &ClassicLoadBalancerHealthCheck{
+			Target:             fi.PtrTo("SSL:443"),
+			HealthyThreshold:   fi.PtrTo(int32(2)),
+			UnhealthyThreshold: fi.PtrTo(int32(2)),
+			Interval:           fi.PtrTo(int32(10)),
+			Timeout:            fi.PtrTo(int32(5)),
+		},
+		ConnectionDraining: &ClassicLoadBalancerConnectionDraining{
+			Enabled: fi.PtrTo(true),
+			Timeout: fi.PtrTo(int32(300)),
+		},
+		CrossZoneLoadBalancing: &ClassicLoadBalancerCrossZoneLoadBalancing{
+			Enabled: fi.PtrTo(false),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			ConfigureTerraform: func(target *terraform.TerraformTarget) {
+				target.Syntax = terraformWriter.SyntaxJSON
+			},
+			Expected: `{
+  "provider": {
+    "aws": {
+      "region": "eu-west-2"
+    }
+  },
+  "resource": {
+    "aws_elb": {
+      "api-example-com": {
+        "connection_draining": true,
+        "connection_draining_timeout": 300,
+        "cross_zone_load_balancing": false,
+        "health_check": {
+          "healthy_threshold": 2,
+          "interval": 10,
+          "target": "SSL:443",
+          "timeout": 5,
+          "unhealthy_threshold": 2
+        },
+        "idle_timeout": 300,
+        "listener": [
+          {
+            "instance_port": 443,
+            "instance_protocol": "TCP",
+            "lb_port": 443,
+            "lb_protocol": "TCP"
+          }
+        ],
+        "name": "api-example-com",
+        "security_groups": "${[aws_security_group.api-elb-example-com.id]}",
+        "subnets": "${[aws_subnet.us-test-1a-example-com.id]}",
+        "tags": {
+          "Name": "api.example.com"
+        }
+      }
+    }
+  },
+  "terraform": {
+    "required_providers": {
+      "aws": {
+        "source": "hashicorp/aws",
+        "version": ">= 5.0.0"
+      }
+    },
+    "required_version": ">= 0.15.0"
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerConnectionDrainingDefaultTimeout(t *testing.T) {
+	e := &ClassicLoadBalancer{
+		Name:               fi.PtrTo("api.example.com"),
+		ConnectionDraining: &ClassicLoadBalancerConnectionDraining{Enabled: fi.PtrTo(true)},
+	}
+	if err := e.Normalize(nil); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+
+	// This is what Find reports for an ELB with draining enabled and no explicit timeout
+	a := &ClassicLoadBalancer{
+		Name: fi.PtrTo("api.example.com"),
+		ConnectionDraining: &ClassicLoadBalancerConnectionDraining{
+			Enabled: fi.PtrTo(true),
+			Timeout: fi.PtrTo(int32(300)),
+		},
+	}
+
+	changes := &ClassicLoadBalancer{}
+	if fi.BuildChanges(a, e, changes) {
+		t.Errorf("expected no changes, got %+v", changes.ConnectionDraining)
+	}
+}
+
+// countingMockELB counts the RemoveTags and RegisterInstancesWithLoadBalancer calls made against the mock.
+type countingMockELB struct {
+	*mockelb.MockELB
+	removeTagsCalls          int
+	registerInstancesCalls   int
+	modifyAttributesRequests []*elb.ModifyLoadBalancerAttributesInput
+}
+
+func (m *countingMockELB) ModifyLoadBalancerAttributes(ctx context.Context, request *elb.ModifyLoadBalancerAttributesInput, optFns ...func(*elb.Options)) (*elb.ModifyLoadBalancerAttributesOutput, error) {
+	m.modifyAttributesRequests = append(m.modifyAttributesRequests, request)
+	return m.MockELB.ModifyLoadBalancerAttributes(ctx, request, optFns...)
+}
+
+func (m *countingMockELB) RemoveTags(ctx context.Context, request *elb.RemoveTagsInput, optFns ...func(*elb.Options)) (*elb.RemoveTagsOutput, error) {
+	m.removeTagsCalls++
+	return m.MockELB.RemoveTags(ctx, request, optFns...)
+}
+
+func (m *countingMockELB) RegisterInstancesWithLoadBalancer(ctx context.Context, request *elb.RegisterInstancesWithLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.RegisterInstancesWithLoadBalancerOutput, error) {
+	m.registerInstancesCalls++
+	return &elb.RegisterInstancesWithLoadBalancerOutput{}, nil
+}
+
+// capturingMockELB records the CreateLoadBalancer requests made against the mock.
+type capturingMockELB struct {
+	*mockelb.MockELB
+	createRequests []*elb.CreateLoadBalancerInput
+}
+
+func (m *capturingMockELB) CreateLoadBalancer(ctx context.Context, request *elb.CreateLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerOutput, error) {
+	m.createRequests = append(m.createRequests, request)
+	return m.MockELB.CreateLoadBalancer(ctx, request, optFns...)
+}
+
+// subnetRecordingMockELB records the subnet attach and detach calls made against the mock, in order.
+type subnetRecordingMockELB struct {
+	*mockelb.MockELB
+	calls []string
+}
+
+func (m *subnetRecordingMockELB) AttachLoadBalancerToSubnets(ctx context.Context, request *elb.AttachLoadBalancerToSubnetsInput, optFns ...func(*elb.Options)) (*elb.AttachLoadBalancerToSubnetsOutput, error) {
+	m.calls = append(m.calls, "attach "+strings.Join(request.Subnets, ","))
+	return &elb.AttachLoadBalancerToSubnetsOutput{}, nil
+}
+
+func (m *subnetRecordingMockELB) DetachLoadBalancerFromSubnets(ctx context.Context, request *elb.DetachLoadBalancerFromSubnetsInput, optFns ...func(*elb.Options)) (*elb.DetachLoadBalancerFromSubnetsOutput, error) {
+	m.calls = append(m.calls, "detach "+strings.Join(request.Subnets, ","))
+	return &elb.DetachLoadBalancerFromSubnetsOutput{}, nil
+}
+
+// registeredInstancesMockELB reports instances as registered with every ELB it describes.
+type registeredInstancesMockELB struct {
+	*subnetRecordingMockELB
+	instanceIDs []string
+}
+
+func (m *registeredInstancesMockELB) DescribeLoadBalancers(ctx context.Context, request *elb.DescribeLoadBalancersInput, optFns ...func(*elb.Options)) (*elb.DescribeLoadBalancersOutput, error) {
+	lb := elbtypes.LoadBalancerDescription{LoadBalancerName: aws.String(request.LoadBalancerNames[0])}
+	for _, id := range m.instanceIDs {
+		lb.Instances = append(lb.Instances, elbtypes.Instance{InstanceId: aws.String(id)})
+	}
+	return &elb.DescribeLoadBalancersOutput{LoadBalancerDescriptions: []elbtypes.LoadBalancerDescription{lb}}, nil
+}
+
+// instanceZonesMockEC2 describes instances as placed in the given zones.
+type instanceZonesMockEC2 struct {
+	*mockec2.MockEC2
+	instanceZones map[string]string
+}
+
+func (m *instanceZonesMockEC2) DescribeInstances(ctx context.Context, request *ec2.DescribeInstancesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	reservation := ec2types.Reservation{}
+	for _, id := range request.InstanceIds {
+		reservation.Instances = append(reservation.Instances, ec2types.Instance{
+			InstanceId: aws.String(id),
+			Placement:  &ec2types.Placement{AvailabilityZone: aws.String(m.instanceZones[id])},
+		})
+	}
+	return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{reservation}}, nil
+}
+
+// callRecordingMockELB records the ELB creation and configuration calls made against the mock, in order.
+type callRecordingMockELB struct {
+	*mockelb.MockELB
+	calls []string
+}
+
+func (m *callRecordingMockELB) CreateLoadBalancer(ctx context.Context, request *elb.CreateLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerOutput, error) {
+	m.calls = append(m.calls, "CreateLoadBalancer")
+	return m.MockELB.CreateLoadBalancer(ctx, request, optFns...)
+}
+
+func (m *callRecordingMockELB) ModifyLoadBalancerAttributes(ctx context.Context, request *elb.ModifyLoadBalancerAttributesInput, optFns ...func(*elb.Options)) (*elb.ModifyLoadBalancerAttributesOutput, error) {
+	m.calls = append(m.calls, fmt.Sprintf("ModifyLoadBalancerAttributes idle_timeout=%d", aws.ToInt32(request.LoadBalancerAttributes.ConnectionSettings.IdleTimeout)))
+	return m.MockELB.ModifyLoadBalancerAttributes(ctx, request, optFns...)
+}
+
+func (m *callRecordingMockELB) AddTags(ctx context.Context, request *elb.AddTagsInput, optFns ...func(*elb.Options)) (*elb.AddTagsOutput, error) {
+	m.calls = append(m.calls, "AddTags")
+	return m.MockELB.AddTags(ctx, request, optFns...)
+}
+
+func (m *callRecordingMockELB) RemoveTags(ctx context.Context, request *elb.RemoveTagsInput, optFns ...func(*elb.Options)) (*elb.RemoveTagsOutput, error) {
+	var keys []string
+	for _, tag := range request.Tags {
+		keys = append(keys, aws.ToString(tag.Key))
+	}
+	sort.Strings(keys)
+	m.calls = append(m.calls, fmt.Sprintf("RemoveTags %s", strings.Join(keys, ",")))
+	return m.MockELB.RemoveTags(ctx, request, optFns...)
+}
+
+func (m *callRecordingMockELB) ConfigureHealthCheck(ctx context.Context, request *elb.ConfigureHealthCheckInput, optFns ...func(*elb.Options)) (*elb.ConfigureHealthCheckOutput, error) {
+	m.calls = append(m.calls, "ConfigureHealthCheck")
+	return m.MockELB.ConfigureHealthCheck(ctx, request, optFns...)
+}
+
+// throttlingMockELB throttles the first attributes and health check update, as AWS does during large rollouts.
+func TestClassicLoadBalancerCreateAppliesIdleTimeoutImmediately(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &callRecordingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		HealthCheck: &ClassicLoadBalancerHealthCheck{
+			Target: fi.PtrTo("SSL:443"),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := e.RenderAWS(target, nil, e, e); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	expected := []string{
+		"CreateLoadBalancer",
+		"ModifyLoadBalancerAttributes idle_timeout=300",
+		"AddTags",
+		"ConfigureHealthCheck",
+	}
+	if !reflect.DeepEqual(m.calls, expected) {
+		t.Errorf("expected calls %q, got %q", expected, m.calls)
+	}
+}
+
+func TestClassicLoadBalancerAttachesSubnetsBeforeDetaching(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+	subnets := map[string]string{
+		"subnet-a-old": "us-east-1a",
+		"subnet-b-old": "us-east-1b",
+		"subnet-b-new": "us-east-1b",
+		"subnet-c-new": "us-east-1c",
+	}
+	for id, zone := range subnets {
+		if _, err := c.CreateSubnetWithId(&ec2.CreateSubnetInput{
+			VpcId:            aws.String("vpc-1"),
+			AvailabilityZone: aws.String(zone),
+		}, id); err != nil {
+			t.Fatalf("error creating subnet: %v", err)
+		}
+	}
+
+	grid := []struct {
+		name     string
+		actual   []string
+		expected []string
+		calls    []string
+	}{
+		{
+			name:     "move to another zone",
+			actual:   []string{"subnet-a-old", "subnet-b-old"},
+			expected: []string{"subnet-a-old", "subnet-c-new"},
+			calls:    []string{"attach subnet-c-new", "detach subnet-b-old"},
+		},
+		{
+			name:     "replace within a zone",
+			actual:   []string{"subnet-a-old", "subnet-b-old"},
+			expected: []string{"subnet-a-old", "subnet-b-new", "subnet-c-new"},
+			calls:    []string{"attach subnet-c-new", "detach subnet-b-old", "attach subnet-b-new"},
+		},
+		{
+			name:     "add only",
+			actual:   []string{"subnet-a-old"},
+			expected: []string{"subnet-a-old", "subnet-c-new"},
+			calls:    []string{"attach subnet-c-new"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			mock := &subnetRecordingMockELB{MockELB: &mockelb.MockELB{}}
+			cloud.MockELB = mock
+
+			toSubnets := func(ids []string) []*Subnet {
+				var subnets []*Subnet
+				for _, id := range ids {
+					subnets = append(subnets, &Subnet{ID: aws.String(id)})
+				}
+				return subnets
+			}
+			e := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-example-com"),
+				Subnets:          toSubnets(g.expected),
+			}
+			a := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-example-com"),
+				Subnets:          toSubnets(g.actual),
+			}
+			changes := &ClassicLoadBalancer{Subnets: e.Subnets}
+
+			target := awsup.NewAWSAPITarget(cloud)
+			if err := e.RenderAWS(target, a, e, changes); err != nil {
+				t.Fatalf("unexpected error from RenderAWS: %v", err)
+			}
+			if !reflect.DeepEqual(mock.calls, g.calls) {
+				t.Errorf("unexpected subnet calls, expected %v got %v", g.calls, mock.calls)
+			}
+		})
+	}
+}
+
+func TestClassicLoadBalancerCheckChangesRequiresSubnet(t *testing.T) {
+	e := &ClassicLoadBalancer{
+		Name:    fi.PtrTo("api.example.com"),
+		Subnets: []*Subnet{},
+	}
+	a := &ClassicLoadBalancer{
+		Name:    fi.PtrTo("api.example.com"),
+		Subnets: []*Subnet{{ID: aws.String("subnet-a")}},
+	}
+	changes := &ClassicLoadBalancer{Subnets: e.Subnets}
+	if err := e.CheckChanges(a, e, changes); err == nil {
+		t.Errorf("expected an error when removing the last subnet")
+	}
+}
+
+func TestClassicLoadBalancerAccessLogPrefix(t *testing.T) {
+	grid := []struct {
+		name     string
+		prefix   string
+		expected string
+		err      string
+	}{
+		{
+			name:     "trailing slash",
+			prefix:   "api/elb/",
+			expected: "api/elb",
+		},
+		{
+			name:   "leading slash",
+			prefix: "/api/elb",
+			err:    `access log bucket prefix "/api/elb" of load balancer "api.example.com" must not start with a slash`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			e := &ClassicLoadBalancer{
+				Name: fi.PtrTo("api.example.com"),
+				AccessLog: &ClassicLoadBalancerAccessLog{
+					Enabled:        fi.PtrTo(true),
+					S3BucketName:   fi.PtrTo("access-logs"),
+					S3BucketPrefix: fi.PtrTo(g.prefix),
+				},
+			}
+			if err := e.Normalize(nil); err != nil {
+				t.Fatalf("unexpected error from Normalize: %v", err)
+			}
+			a := &ClassicLoadBalancer{Name: fi.PtrTo("api.example.com")}
+
+			err := e.CheckChanges(a, e, &ClassicLoadBalancer{AccessLog: e.AccessLog})
+			if g.err != "" {
+				if err == nil || err.Error() != g.err {
+					t.Errorf("expected error %q, got %v", g.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error from CheckChanges: %v", err)
+			}
+			if actual := fi.ValueOf(e.AccessLog.S3BucketPrefix); actual != g.expected {
+				t.Errorf("expected prefix %q, got %q", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClassicLoadBalancerCreateSortsSubnets(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &capturingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = c
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Subnets: []*Subnet{
+			{Name: fi.PtrTo("us-east-1c"), ID: fi.PtrTo("subnet-ccc")},
+			{Name: fi.PtrTo("us-east-1a"), ID: fi.PtrTo("subnet-aaa")},
+			{Name: fi.PtrTo("us-east-1b"), ID: fi.PtrTo("subnet-bbb")},
+		},
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+
+	if err := e.RenderAWS(awsup.NewAWSAPITarget(cloud), nil, e, e); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	if len(c.createRequests) != 1 {
+		t.Fatalf("expected exactly one CreateLoadBalancer request, got %d", len(c.createRequests))
+	}
+	expected := []string{"subnet-aaa", "subnet-bbb", "subnet-ccc"}
+	if actual := c.createRequests[0].Subnets; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected subnets in CreateLoadBalancer request, expected %v got %v", expected, actual)
+	}
+}
+
+func TestClassicLoadBalancerDoesNotRegisterInstances(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &countingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = c
+	cloud.MockAutoscaling = &mockautoscaling.MockAutoscaling{}
+
+	// The ASG attachment owns instance registration
+	if _, err := cloud.Autoscaling().CreateAutoScalingGroup(ctx, &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String("nodes.example.com"),
+		LoadBalancerNames:    []string{"api-example-com"},
+		MinSize:              aws.Int32(1),
+		MaxSize:              aws.Int32(1),
+	}); err != nil {
+		t.Fatalf("error creating ASG: %v", err)
+	}
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		HealthCheck: &ClassicLoadBalancerHealthCheck{
+			Target:             fi.PtrTo("SSL:443"),
+			Interval:           fi.PtrTo(int32(10)),
+			Timeout:            fi.PtrTo(int32(5)),
+			HealthyThreshold:   fi.PtrTo(int32(2)),
+			UnhealthyThreshold: fi.PtrTo(int32(2)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	target := awsup.NewAWSAPITarget(cloud)
+
+	if err := e.RenderAWS(target, nil, e, e); err != nil {
+		t.Fatalf("unexpected error from RenderAWS on create: %v", err)
+	}
+	a := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+	}
+	if err := e.RenderAWS(target, a, e, &ClassicLoadBalancer{HealthCheck: e.HealthCheck}); err != nil {
+		t.Fatalf("unexpected error from RenderAWS on update: %v", err)
+	}
+
+	if c.registerInstancesCalls != 0 {
+		t.Errorf("expected no RegisterInstancesWithLoadBalancer calls, got %d", c.registerInstancesCalls)
+	}
+}
+
+func TestClassicLoadBalancerRetainUnmanagedTags(t *testing.T) {
+	ctx := context.TODO()
+
+	for _, retain := range []bool{false, true} {
+		cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+		c := &countingMockELB{MockELB: &mockelb.MockELB{}}
+		cloud.MockELB = c
+
+		if _, err := c.CreateLoadBalancer(ctx, &elb.CreateLoadBalancerInput{LoadBalancerName: aws.String("api-example-com")}); err != nil {
+			t.Fatalf("error creating ELB: %v", err)
+		}
+		if _, err := c.AddTags(ctx, &elb.AddTagsInput{
+			LoadBalancerNames: []string{"api-example-com"},
+			Tags:              []elbtypes.Tag{{Key: aws.String("cost-center"), Value: aws.String("1234")}},
+		}); err != nil {
+			t.Fatalf("error tagging ELB: %v", err)
+		}
+
+		e := &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Tags:             map[string]string{"Name": "api.example.com"},
+		}
+		e.SetRetainUnmanagedTags(retain)
+		a := &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Tags:             map[string]string{"cost-center": "1234"},
+		}
+
+		if err := e.RenderAWS(awsup.NewAWSAPITarget(cloud), a, e, &ClassicLoadBalancer{Tags: e.Tags}); err != nil {
+			t.Fatalf("unexpected error from RenderAWS: %v", err)
+		}
+
+		tags, err := cloud.GetELBTags("api-example-com")
+		if err != nil {
+			t.Fatalf("error getting ELB tags: %v", err)
+		}
+		if tags["Name"] != "api.example.com" {
+			t.Errorf("retain=%v: expected kOps tag to be added, got %v", retain, tags)
+		}
+		if retain {
+			if c.removeTagsCalls != 0 {
+				t.Errorf("expected no RemoveTags calls, got %d", c.removeTagsCalls)
+			}
+			if tags["cost-center"] != "1234" {
+				t.Errorf("expected unmanaged tag to be retained, got %v", tags)
+			}
+		} else if _, found := tags["cost-center"]; found {
+			t.Errorf("expected unmanaged tag to be removed, got %v", tags)
+		}
+	}
+}
+
+func TestClassicLoadBalancerTerraformCreateVariable(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+	}
+	elb.SetTerraformCreateVariable("create_elb")
+
+	if actual, expected := elb.TerraformLink("dns_name").String, "one(aws_elb.api-example-com[*].dns_name)"; actual != expected {
+		t.Errorf("unexpected terraform link, expected %q got %q", expected, actual)
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			Expected: `variable "create_elb" {
+  default = true
+}
+
+provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  count = var.create_elb ? 1 : 0
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+// fakeResolver serves canned addresses for host names, failing for unknown hosts.
+type fakeResolver struct {
+	hosts map[string][]string
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addresses, found := r.hosts[host]
+	if !found {
+		return nil, fmt.Errorf("no such host %q", host)
+	}
+	return addresses, nil
+}
+
+func TestClassicLoadBalancerFindAddressesResolvesDNSName(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockELB = &mockelb.MockELB{}
+	target := awsup.NewAWSAPITarget(cloud)
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := e.RenderAWS(target, nil, e, e); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	grid := []struct {
+		name     string
+		resolve  bool
+		hosts    map[string][]string
+		expected []string
+	}{
+		{
+			name:     "resolution disabled",
+			hosts:    map[string][]string{"api-example-com.elb.cloudmock.com": {"192.0.2.10"}},
+			expected: []string{"api-example-com.elb.cloudmock.com"},
+		},
+		{
+			name:     "resolved",
+			resolve:  true,
+			hosts:    map[string][]string{"api-example-com.elb.cloudmock.com": {"192.0.2.11", "192.0.2.10"}},
+			expected: []string{"api-example-com.elb.cloudmock.com", "192.0.2.10", "192.0.2.11"},
+		},
+		{
+			name:     "not yet resolvable",
+			resolve:  true,
+			expected: []string{"api-example-com.elb.cloudmock.com"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			e.SetResolveAddresses(g.resolve)
+			e.resolver = &fakeResolver{hosts: g.hosts}
+
+			addresses, err := e.FindAddresses(c)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(addresses, g.expected) {
+				t.Errorf("expected addresses %v, got %v", g.expected, addresses)
+			}
+		})
+	}
+}
+
+// delayedResolver fails to resolve until it has been asked a number of times, like a newly created DNS record.
+type delayedResolver struct {
+	failures  int
+	addresses []string
+	lookups   int
+}
+
+func (r *delayedResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups++
+	if r.lookups <= r.failures {
+		return nil, fmt.Errorf("no such host %q", host)
+	}
+	return r.addresses, nil
+}
+
+// delayedDialer refuses connections until it has been dialed a number of times, like an ELB with no healthy instances.
+type delayedDialer struct {
+	failures int
+	dials    []string
+}
+
+func (d *delayedDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.dials = append(d.dials, address)
+	if len(d.dials) <= d.failures {
+		return nil, fmt.Errorf("dial %s %s: connection refused", network, address)
+	}
+	client, server := net.Pipe()
+	server.Close()
+	return client, nil
+}
+
+func TestClassicLoadBalancerReadinessGateSucceedsAfterDelay(t *testing.T) {
+	ctx := context.TODO()
+
+	resolver := &delayedResolver{failures: 2, addresses: []string{"192.0.2.10"}}
+	dialer := &delayedDialer{failures: 1}
+	e := &ClassicLoadBalancer{
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		DNSName:          fi.PtrTo("api-example-com.elb.cloudmock.com"),
+		resolver:         resolver,
+		dialer:           dialer,
+	}
+	e.SetReadinessGate(443)
+
+	attempts := 0
+	for {
+		attempts++
+		if attempts > 10 {
+			t.Fatalf("readiness gate did not succeed after %d attempts", attempts-1)
+		}
+		err := e.checkReady(ctx)
+		if err == nil {
+			break
+		}
+		if _, ok := err.(*fi.TryAgainLaterError); !ok {
+			t.Fatalf("expected a TryAgainLaterError so the task is retried, got %T: %v", err, err)
+		}
+	}
+
+	// Two attempts waiting for DNS, one waiting for the listener, then success
+	if attempts != 4 {
+		t.Errorf("expected the gate to succeed on attempt 4, succeeded on attempt %d", attempts)
+	}
+	expectedDials := []string{"192.0.2.10:443", "192.0.2.10:443"}
+	if !reflect.DeepEqual(dialer.dials, expectedDials) {
+		t.Errorf("expected dials %v, got %v", expectedDials, dialer.dials)
+	}
+}
+
+func TestClassicLoadBalancerBatchesAttributeChanges(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &countingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = c
+
+	if _, err := c.CreateLoadBalancer(ctx, &elb.CreateLoadBalancerInput{
+		LoadBalancerName: aws.String("api-example-com"),
+	}); err != nil {
+		t.Fatalf("error creating ELB: %v", err)
+	}
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		AccessLog: &ClassicLoadBalancerAccessLog{
+			Enabled:      fi.PtrTo(true),
+			EmitInterval: fi.PtrTo(int32(5)),
+			S3BucketName: fi.PtrTo("elb-logs"),
+		},
+		ConnectionDraining: &ClassicLoadBalancerConnectionDraining{
+			Enabled: fi.PtrTo(true),
+			Timeout: fi.PtrTo(int32(120)),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		CrossZoneLoadBalancing: &ClassicLoadBalancerCrossZoneLoadBalancing{
+			Enabled: fi.PtrTo(true),
+		},
+	}
+	a := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+	}
+	changes := &ClassicLoadBalancer{
+		AccessLog:              e.AccessLog,
+		ConnectionDraining:     e.ConnectionDraining,
+		ConnectionSettings:     e.ConnectionSettings,
+		CrossZoneLoadBalancing: e.CrossZoneLoadBalancing,
+	}
+
+	target := awsup.NewAWSAPITarget(cloud)
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	if len(c.modifyAttributesRequests) != 1 {
+		t.Fatalf("expected a single ModifyLoadBalancerAttributes call, got %d", len(c.modifyAttributesRequests))
+	}
+	expected := &elbtypes.LoadBalancerAttributes{
+		AccessLog: &elbtypes.AccessLog{
+			Enabled:      true,
+			EmitInterval: aws.Int32(5),
+			S3BucketName: aws.String("elb-logs"),
+		},
+		ConnectionDraining: &elbtypes.ConnectionDraining{
+			Enabled: true,
+			Timeout: aws.Int32(120),
+		},
+		ConnectionSettings: &elbtypes.ConnectionSettings{
+			IdleTimeout: aws.Int32(300),
+		},
+		CrossZoneLoadBalancing: &elbtypes.CrossZoneLoadBalancing{
+			Enabled: true,
+		},
+	}
+	if actual := c.modifyAttributesRequests[0].LoadBalancerAttributes; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected attributes, expected %+v got %+v", expected, actual)
+	}
+}
+
+func TestClassicLoadBalancerDesyncMitigationMode(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &countingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	newELB := func(desyncMitigationMode *string, idleTimeout int32) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners: map[string]*ClassicLoadBalancerListener{
+				"443": {InstancePort: 443},
+			},
+			ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+				IdleTimeout: fi.PtrTo(idleTimeout),
+			},
+			DesyncMitigationMode: desyncMitigationMode,
+			Tags:                 map[string]string{"Name": "api.example.com"},
+		}
+	}
+
+	created := newELB(fi.PtrTo("strictest"), 300)
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	// The mode is read back, so it is in steady state
+	e := newELB(fi.PtrTo("strictest"), 300)
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if actual := fi.ValueOf(a.DesyncMitigationMode); actual != "strictest" {
+		t.Errorf("expected Find to report the desync mitigation mode, got %q", actual)
+	}
+	changes := &ClassicLoadBalancer{}
+	if fi.BuildChanges(a, e, changes) {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+
+	// When unset, the mode is not sent, so other attribute changes leave it alone
+	m.modifyAttributesRequests = nil
+	e = newELB(nil, 600)
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.DesyncMitigationMode != nil {
+		t.Errorf("expected no desync mitigation mode change, got %q", *changes.DesyncMitigationMode)
+	}
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+	if len(m.modifyAttributesRequests) != 1 {
+		t.Fatalf("expected a single ModifyLoadBalancerAttributes call, got %d", len(m.modifyAttributesRequests))
+	}
+	if additionalAttributes := m.modifyAttributesRequests[0].LoadBalancerAttributes.AdditionalAttributes; len(additionalAttributes) != 0 {
+		t.Errorf("expected no additional attributes to be sent, got %v", additionalAttributes)
+	}
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if actual := fi.ValueOf(a.DesyncMitigationMode); actual != "strictest" {
+		t.Errorf("expected the desync mitigation mode to be kept, got %q", actual)
+	}
+}
+
+func TestValidateDesyncMitigationMode(t *testing.T) {
+	for _, mode := range []string{"monitor", "defensive", "strictest"} {
+		e := &ClassicLoadBalancer{Name: fi.PtrTo("api.example.com"), Shared: fi.PtrTo(true), DesyncMitigationMode: fi.PtrTo(mode)}
+		if err := e.CheckChanges(nil, e, e); err != nil {
+			t.Errorf("unexpected error for mode %q: %v", mode, err)
+		}
+	}
+
+	e := &ClassicLoadBalancer{Name: fi.PtrTo("api.example.com"), Shared: fi.PtrTo(true), DesyncMitigationMode: fi.PtrTo("Strictest")}
+	err := e.CheckChanges(nil, e, e)
+	if err == nil || !strings.Contains(err.Error(), `desync mitigation mode "Strictest" of load balancer "api.example.com" must be one of monitor, defensive, strictest`) {
+		t.Errorf("expected error for invalid mode, got %v", err)
+	}
+}
+
+func TestClassicLoadBalancerTerraformDesyncMitigationMode(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		DesyncMitigationMode: fi.PtrTo("strictest"),
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  desync_mitigation_mode = "strictest"
+  listener {
+    instance_port     = 443
+    instance_protocol = "TCP"
+    lb_port           = 443
+    lb_protocol       = "TCP"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+func TestClassicLoadBalancerDisablesConnectionDraining(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &countingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	created := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionDraining: &ClassicLoadBalancerConnectionDraining{
+			Enabled: fi.PtrTo(true),
+			Timeout: fi.PtrTo(int32(120)),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+	m.modifyAttributesRequests = nil
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		ConnectionDraining: &ClassicLoadBalancerConnectionDraining{
+			Enabled: fi.PtrTo(false),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := e.Normalize(c); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected to find the ELB")
+	}
+
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.ConnectionDraining == nil {
+		t.Fatalf("expected disabling connection draining to be detected as a change")
+	}
+
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+	if len(m.modifyAttributesRequests) != 1 {
+		t.Fatalf("expected a single ModifyLoadBalancerAttributes call, got %d", len(m.modifyAttributesRequests))
+	}
+	if m.modifyAttributesRequests[0].LoadBalancerAttributes.ConnectionDraining.Enabled {
+		t.Errorf("expected connection draining to be disabled")
+	}
+
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.ConnectionDraining != nil {
+		t.Errorf("expected no connection draining change once disabled, got %+v", changes.ConnectionDraining)
+	}
+}
+
+func TestClassicLoadBalancerDisablesAccessLog(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &countingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	created := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		AccessLog: &ClassicLoadBalancerAccessLog{
+			Enabled:        fi.PtrTo(true),
+			EmitInterval:   fi.PtrTo(int32(5)),
+			S3BucketName:   fi.PtrTo("access-logs"),
+			S3BucketPrefix: fi.PtrTo("api"),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+	m.modifyAttributesRequests = nil
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	// The destination is left over from when the access logs were enabled
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		AccessLog: &ClassicLoadBalancerAccessLog{
+			Enabled:      fi.PtrTo(false),
+			S3BucketName: fi.PtrTo("access-logs"),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := e.Normalize(c); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected to find the ELB")
+	}
+
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.AccessLog == nil {
+		t.Fatalf("expected disabling access logs to be detected as a change")
+	}
+	if err := e.CheckChanges(a, e, changes); err != nil {
+		t.Fatalf("unexpected error from CheckChanges: %v", err)
+	}
+
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+	if len(m.modifyAttributesRequests) != 1 {
+		t.Fatalf("expected a single ModifyLoadBalancerAttributes call, got %d", len(m.modifyAttributesRequests))
+	}
+	if m.modifyAttributesRequests[0].LoadBalancerAttributes.AccessLog.Enabled {
+		t.Errorf("expected access logs to be disabled")
+	}
+
+	// ELB still reports the bucket of the disabled access logs
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.AccessLog != nil {
+		t.Errorf("expected no access log change once disabled, got %+v", changes.AccessLog)
+	}
+
+	// Disabling doesn't require a bucket
+	disabled := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		AccessLog:        &ClassicLoadBalancerAccessLog{Enabled: fi.PtrTo(false)},
+	}
+	if err := disabled.CheckChanges(a, disabled, &ClassicLoadBalancer{AccessLog: disabled.AccessLog}); err != nil {
+		t.Errorf("unexpected error from CheckChanges when disabling access logs without a bucket: %v", err)
+	}
+}
+
+func TestClassicLoadBalancerNameChange(t *testing.T) {
+	grid := []struct {
+		name         string
+		allowReplace *bool
+		expectedName string
+	}{
+		{
+			name:         "existing ELB is reused by default",
+			expectedName: "api-old",
+		},
+		{
+			name:         "existing ELB is reused when replacing is disabled",
+			allowReplace: fi.PtrTo(false),
+			expectedName: "api-old",
+		},
+		{
+			name:         "existing ELB is replaced when allowed",
+			allowReplace: fi.PtrTo(true),
+			expectedName: "api-new",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+			m := &mockelb.MockELB{}
+			cloud.MockELB = m
+			target := awsup.NewAWSAPITarget(cloud)
+
+			created := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-old"),
+				Listeners: map[string]*ClassicLoadBalancerListener{
+					"443": {InstancePort: 443},
+				},
+				ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+					IdleTimeout: fi.PtrTo(int32(300)),
+				},
+				Tags: map[string]string{"Name": "api.example.com"},
+			}
+			if err := created.RenderAWS(target, nil, created, created); err != nil {
+				t.Fatalf("unexpected error from RenderAWS: %v", err)
+			}
+
+			c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("error building context: %v", err)
+			}
+
+			e := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-new"),
+				Listeners: map[string]*ClassicLoadBalancerListener{
+					"443": {InstancePort: 443},
+				},
+				ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+					IdleTimeout: fi.PtrTo(int32(300)),
+				},
+				Tags:                     map[string]string{"Name": "api.example.com"},
+				AllowReplaceOnNameChange: g.allowReplace,
+			}
+			if err := e.Normalize(c); err != nil {
+				t.Fatalf("unexpected error from Normalize: %v", err)
+			}
+			a, err := e.Find(c)
+			if err != nil {
+				t.Fatalf("unexpected error from Find: %v", err)
+			}
+			if a == nil {
+				t.Fatalf("expected to find the ELB")
+			}
+
+			changes := &ClassicLoadBalancer{}
+			fi.BuildChanges(a, e, changes)
+			if err := e.RenderAWS(target, a, e, changes); err != nil {
+				t.Fatalf("unexpected error from RenderAWS: %v", err)
+			}
+
+			var names []string
+			for name := range m.LoadBalancers {
+				names = append(names, name)
+			}
+			if !reflect.DeepEqual(names, []string{g.expectedName}) {
+				t.Errorf("expected only ELB %q to exist, got %v", g.expectedName, names)
+			}
+			if expected := g.expectedName + ".elb.cloudmock.com"; fi.ValueOf(e.DNSName) != expected {
+				t.Errorf("expected DNS name %q for the DNS records, got %q", expected, fi.ValueOf(e.DNSName))
+			}
+
+			a, err = e.Find(c)
+			if err != nil {
+				t.Fatalf("unexpected error from Find: %v", err)
+			}
+			if a == nil {
+				t.Fatalf("expected to find the ELB")
+			}
+			changes = &ClassicLoadBalancer{}
+			fi.BuildChanges(a, e, changes)
+			if changes.LoadBalancerName != nil {
+				t.Errorf("expected no name change once applied, got %q", fi.ValueOf(changes.LoadBalancerName))
+			}
+		})
+	}
+}
+
+// failingCreateMockELB rejects every CreateLoadBalancer call.
+type failingCreateMockELB struct {
+	*mockelb.MockELB
+}
+
+func (m *failingCreateMockELB) CreateLoadBalancer(ctx context.Context, request *elb.CreateLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerOutput, error) {
+	return nil, &smithy.GenericAPIError{Code: "TooManyLoadBalancers", Message: "Exceeded quota of account"}
+}
+
+func TestClassicLoadBalancerNameChangeFailedCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &mockelb.MockELB{}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	created := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-old"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	cloud.MockELB = &failingCreateMockELB{MockELB: m}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-new"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags:                     map[string]string{"Name": "api.example.com"},
+		AllowReplaceOnNameChange: fi.PtrTo(true),
+	}
+	if err := e.Normalize(c); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected to find the ELB")
+	}
+
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if err := e.RenderAWS(target, a, e, changes); err == nil {
+		t.Fatalf("expected an error from RenderAWS")
+	}
+
+	var names []string
+	for name := range m.LoadBalancers {
+		names = append(names, name)
+	}
+	if !reflect.DeepEqual(names, []string{"api-old"}) {
+		t.Errorf("expected the old ELB to be kept when creating its replacement fails, got %v", names)
+	}
+	if expected := "api-old.elb.cloudmock.com"; fi.ValueOf(e.DNSName) != expected {
+		t.Errorf("expected DNS records to keep pointing at %q, got %q", expected, fi.ValueOf(e.DNSName))
+	}
+}
+
+// failingDeleteMockELB rejects DeleteLoadBalancer calls for the given ELBs.
+type failingDeleteMockELB struct {
+	*mockelb.MockELB
+	names []string
+}
+
+func (m *failingDeleteMockELB) DeleteLoadBalancer(ctx context.Context, request *elb.DeleteLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.DeleteLoadBalancerOutput, error) {
+	if slices.Contains(m.names, aws.ToString(request.LoadBalancerName)) {
+		return nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "Not authorized to perform elasticloadbalancing:DeleteLoadBalancer"}
+	}
+	return m.MockELB.DeleteLoadBalancer(ctx, request, optFns...)
+}
+
+func TestClassicLoadBalancerNameChangeFailedDelete(t *testing.T) {
+	grid := []struct {
+		name          string
+		failDelete    []string
+		expectedNames []string
+		manualCleanup bool
+	}{
+		{
+			name:          "replacement rolled back",
+			failDelete:    []string{"api-old"},
+			expectedNames: []string{"api-old"},
+		},
+		{
+			name:          "rollback fails",
+			failDelete:    []string{"api-old", "api-new"},
+			expectedNames: []string{"api-new", "api-old"},
+			manualCleanup: true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+			m := &mockelb.MockELB{}
+			cloud.MockELB = m
+			target := awsup.NewAWSAPITarget(cloud)
+
+			created := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-old"),
+				Listeners: map[string]*ClassicLoadBalancerListener{
+					"443": {InstancePort: 443},
+				},
+				ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+					IdleTimeout: fi.PtrTo(int32(300)),
+				},
+				Tags: map[string]string{"Name": "api.example.com"},
+			}
+			if err := created.RenderAWS(target, nil, created, created); err != nil {
+				t.Fatalf("unexpected error from RenderAWS: %v", err)
+			}
+
+			cloud.MockELB = &failingDeleteMockELB{MockELB: m, names: g.failDelete}
+
+			c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("error building context: %v", err)
+			}
+
+			e := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-new"),
+				Listeners: map[string]*ClassicLoadBalancerListener{
+					"443": {InstancePort: 443},
+				},
+				ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+					IdleTimeout: fi.PtrTo(int32(300)),
+				},
+				Tags:                     map[string]string{"Name": "api.example.com"},
+				AllowReplaceOnNameChange: fi.PtrTo(true),
+			}
+			if err := e.Normalize(c); err != nil {
+				t.Fatalf("unexpected error from Normalize: %v", err)
+			}
+			a, err := e.Find(c)
+			if err != nil {
+				t.Fatalf("unexpected error from Find: %v", err)
+			}
+			if a == nil {
+				t.Fatalf("expected to find the ELB")
+			}
+
+			changes := &ClassicLoadBalancer{}
+			fi.BuildChanges(a, e, changes)
+			err = e.RenderAWS(target, a, e, changes)
+			if err == nil {
+				t.Fatalf("expected an error from RenderAWS")
+			}
+			if manualCleanup := strings.Contains(err.Error(), "must be deleted by hand"); manualCleanup != g.manualCleanup {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			var names []string
+			for name := range m.LoadBalancers {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, g.expectedNames) {
+				t.Errorf("expected ELBs %v, got %v", g.expectedNames, names)
+			}
+			if !g.manualCleanup {
+				if expected := "api-old.elb.cloudmock.com"; fi.ValueOf(e.DNSName) != expected {
+					t.Errorf("expected DNS records to keep pointing at %q, got %q", expected, fi.ValueOf(e.DNSName))
+				}
+			}
+		})
+	}
+}
+
+func TestChangedLoadBalancerAttributesCrossZoneToggle(t *testing.T) {
+	actual := &ClassicLoadBalancer{
+		AccessLog: &ClassicLoadBalancerAccessLog{
+			Enabled: fi.PtrTo(false),
+		},
+		ConnectionDraining: &ClassicLoadBalancerConnectionDraining{
+			Enabled: fi.PtrTo(true),
+			Timeout: fi.PtrTo(int32(300)),
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		CrossZoneLoadBalancing: &ClassicLoadBalancerCrossZoneLoadBalancing{
+			Enabled: fi.PtrTo(false),
+		},
+	}
+	attributes := &elbtypes.LoadBalancerAttributes{
+		AccessLog: &elbtypes.AccessLog{
+			Enabled: false,
+		},
+		ConnectionDraining: &elbtypes.ConnectionDraining{
+			Enabled: true,
+			Timeout: aws.Int32(300),
+		},
+		ConnectionSettings: &elbtypes.ConnectionSettings{
+			IdleTimeout: aws.Int32(300),
+		},
+		CrossZoneLoadBalancing: &elbtypes.CrossZoneLoadBalancing{
+			Enabled: true,
+		},
+	}
+
+	expected := []string{"CrossZoneLoadBalancing.Enabled: false -> true"}
+	if changed := changedLoadBalancerAttributes(actual, attributes); !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected changes %q, got %q", expected, changed)
+	}
+
+	// When the ELB is being created, every attribute is logged as changed from unset
+	expected = []string{
+		"AccessLog.Enabled: <unset> -> false",
+		"ConnectionDraining.Enabled: <unset> -> true",
+		"ConnectionDraining.Timeout: <unset> -> 300",
+		"ConnectionSettings.IdleTimeout: <unset> -> 300",
+		"CrossZoneLoadBalancing.Enabled: <unset> -> true",
+	}
+	if changed := changedLoadBalancerAttributes(nil, attributes); !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected changes %q, got %q", expected, changed)
+	}
+}
+
+func TestClassicLoadBalancerInternetFacingSchemeSteadyState(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockELB = &mockelb.MockELB{}
+	target := awsup.NewAWSAPITarget(cloud)
+
+	// AWS reports the scheme of a public ELB as internet-facing
+	created := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Scheme:           fi.PtrTo("internet-facing"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	for _, scheme := range []*string{nil, fi.PtrTo(""), fi.PtrTo("internet-facing")} {
+		e := &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Scheme:           scheme,
+		}
+		if err := e.Normalize(c); err != nil {
+			t.Fatalf("unexpected error from Normalize: %v", err)
+		}
+
+		a, err := e.Find(c)
+		if err != nil {
+			t.Fatalf("unexpected error from Find: %v", err)
+		}
+		if a == nil {
+			t.Fatalf("expected to find the ELB")
+		}
+
+		changes := &ClassicLoadBalancer{}
+		fi.BuildChanges(a, e, changes)
+		if changes.Scheme != nil {
+			t.Errorf("scheme %q: expected no scheme change, got %q", fi.ValueOf(scheme), fi.ValueOf(changes.Scheme))
+		}
+	}
+}
+
+func TestClassicLoadBalancerInternalSchemeSteadyState(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockELB = &mockelb.MockELB{}
+	target := awsup.NewAWSAPITarget(cloud)
+
+	created := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Scheme:           fi.PtrTo("internal"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	for _, scheme := range []string{"internal", "Internal"} {
+		e := &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Scheme:           fi.PtrTo(scheme),
+		}
+		if err := e.Normalize(c); err != nil {
+			t.Fatalf("unexpected error from Normalize: %v", err)
+		}
+
+		a, err := e.Find(c)
+		if err != nil {
+			t.Fatalf("unexpected error from Find: %v", err)
+		}
+		if a == nil {
+			t.Fatalf("expected to find the ELB")
+		}
+		if fi.ValueOf(a.Scheme) != "internal" {
+			t.Errorf("expected Find to report the internal scheme, got %q", fi.ValueOf(a.Scheme))
+		}
+
+		changes := &ClassicLoadBalancer{}
+		fi.BuildChanges(a, e, changes)
+		if changes.Scheme != nil {
+			t.Errorf("scheme %q: expected no scheme change, got %q", scheme, fi.ValueOf(changes.Scheme))
+		}
+	}
+}
+
+// describeCountingMockELB counts the attribute and tag describes made against the mock.
+type describeCountingMockELB struct {
+	*mockelb.MockELB
+	describeAttributesCalls int
+	describeTagsCalls       int
+}
+
+func (m *describeCountingMockELB) DescribeLoadBalancerAttributes(ctx context.Context, request *elb.DescribeLoadBalancerAttributesInput, optFns ...func(*elb.Options)) (*elb.DescribeLoadBalancerAttributesOutput, error) {
+	m.describeAttributesCalls++
+	return m.MockELB.DescribeLoadBalancerAttributes(ctx, request, optFns...)
+}
+
+func (m *describeCountingMockELB) DescribeTags(ctx context.Context, request *elb.DescribeTagsInput, optFns ...func(*elb.Options)) (*elb.DescribeTagsOutput, error) {
+	m.describeTagsCalls++
+	return m.MockELB.DescribeTags(ctx, request, optFns...)
+}
+
+func TestClassicLoadBalancerFindDescribesOnce(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &describeCountingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com", "owner": "team"},
+	}
+	if err := e.RenderAWS(target, nil, e, e); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	m.describeAttributesCalls = 0
+	m.describeTagsCalls = 0
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected to find the ELB")
+	}
+	if m.describeAttributesCalls != 1 {
+		t.Errorf("expected attributes to be described once, got %d", m.describeAttributesCalls)
+	}
+	if m.describeTagsCalls != 1 {
+		t.Errorf("expected tags to be described once, got %d", m.describeTagsCalls)
+	}
+	if !reflect.DeepEqual(a.Tags, e.Tags) {
+		t.Errorf("expected tags %v, got %v", e.Tags, a.Tags)
+	}
+}
+
+func TestClassicLoadBalancerSSLListenerWithTCPBackend(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockELB = &mockelb.MockELB{}
+	target := awsup.NewAWSAPITarget(cloud)
+
+	newELB := func(instanceProtocol *string) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners: map[string]*ClassicLoadBalancerListener{
+				"443": {InstancePort: 443, SSLCertificateID: "arn:aws:acm:us-east-1:123456789012:certificate/abc", InstanceProtocol: instanceProtocol},
+			},
+			ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+				IdleTimeout: fi.PtrTo(int32(300)),
+			},
+			Tags: map[string]string{"Name": "api.example.com"},
+		}
+	}
+
+	created := newELB(fi.PtrTo("TCP"))
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	lb, err := findLoadBalancerByLoadBalancerName(ctx, cloud, "api-example-com")
+	if err != nil {
+		t.Fatalf("unexpected error finding ELB: %v", err)
+	}
+	listener := lb.ListenerDescriptions[0].Listener
+	if aws.ToString(listener.Protocol) != "SSL" || aws.ToString(listener.InstanceProtocol) != "TCP" {
+		t.Errorf("expected SSL listener with TCP instance protocol, got %q/%q", aws.ToString(listener.Protocol), aws.ToString(listener.InstanceProtocol))
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	e := newELB(fi.PtrTo("tcp"))
+	if err := e.Normalize(c); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected to find the ELB")
+	}
+	if actual := fi.ValueOf(a.Listeners["443"].InstanceProtocol); actual != "TCP" {
+		t.Errorf("expected Find to read back instance protocol TCP, got %q", actual)
+	}
+
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners != nil {
+		t.Errorf("expected no listener changes, got %v", changes.Listeners)
+	}
+
+	// An SSL instance protocol is the default for an SSL listener, so it differs from the TCP one read back
+	e = newELB(fi.PtrTo("SSL"))
+	if err := e.Normalize(c); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners == nil {
+		t.Errorf("expected listener changes")
+	}
+}
+
+func TestClassicLoadBalancerHTTPListeners(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockELB = &mockelb.MockELB{}
+	target := awsup.NewAWSAPITarget(cloud)
+
+	newELB := func(protocol *string) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners: map[string]*ClassicLoadBalancerListener{
+				"443":  {InstancePort: 8080, Protocol: protocol, SSLCertificateID: "arn:aws:acm:us-east-1:123456789012:certificate/abc", InstanceProtocol: fi.PtrTo("HTTP")},
+				"8443": {InstancePort: 8443},
+			},
+			ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+				IdleTimeout: fi.PtrTo(int32(300)),
+			},
+			Tags: map[string]string{"Name": "api.example.com"},
+		}
+	}
+
+	created := newELB(fi.PtrTo("HTTPS"))
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	lb, err := findLoadBalancerByLoadBalancerName(ctx, cloud, "api-example-com")
+	if err != nil {
+		t.Fatalf("unexpected error finding ELB: %v", err)
+	}
+	protocols := make(map[int32]string)
+	for _, ld := range lb.ListenerDescriptions {
+		protocols[ld.Listener.LoadBalancerPort] = aws.ToString(ld.Listener.Protocol) + "/" + aws.ToString(ld.Listener.InstanceProtocol)
+	}
+	if expected := map[int32]string{443: "HTTPS/HTTP", 8443: "TCP/TCP"}; !reflect.DeepEqual(protocols, expected) {
+		t.Errorf("expected listener protocols %v, got %v", expected, protocols)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	e := newELB(fi.PtrTo("https"))
+	if err := e.Normalize(c); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if actual := fi.ValueOf(a.Listeners["443"].Protocol); actual != "HTTPS" {
+		t.Errorf("expected Find to read back protocol HTTPS, got %q", actual)
+	}
+	// Listeners using the default protocol read back as unset, as on clusters created before protocols were configurable
+	if a.Listeners["8443"].Protocol != nil {
+		t.Errorf("expected Find to read back the default protocol as unset, got %q", fi.ValueOf(a.Listeners["8443"].Protocol))
+	}
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners != nil {
+		t.Errorf("expected no listener changes, got %v", changes.Listeners)
+	}
+
+	// Switching back to the default SSL protocol is a listener change
+	e = newELB(nil)
+	e.Listeners["443"].InstanceProtocol = nil
+	if err := e.Normalize(c); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners == nil {
+		t.Errorf("expected listener changes")
+	}
+}
+
+func TestClassicLoadBalancerTerraformHTTPSListener(t *testing.T) {
+	elb := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 8080, Protocol: fi.PtrTo("HTTPS"), SSLCertificateID: "arn:cert", InstanceProtocol: fi.PtrTo("HTTP")},
+		},
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: elb,
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port      = 8080
+    instance_protocol  = "HTTP"
+    lb_port            = 443
+    lb_protocol        = "HTTPS"
+    ssl_certificate_id = "arn:cert"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
+
+// listenerRecordingMockELB records the listener delete and create calls made against the mock, in order.
+type listenerRecordingMockELB struct {
+	*mockelb.MockELB
+	calls []string
+}
+
+func (m *listenerRecordingMockELB) DeleteLoadBalancerListeners(ctx context.Context, request *elb.DeleteLoadBalancerListenersInput, optFns ...func(*elb.Options)) (*elb.DeleteLoadBalancerListenersOutput, error) {
+	m.calls = append(m.calls, fmt.Sprintf("DeleteLoadBalancerListeners %v", request.LoadBalancerPorts))
+	return m.MockELB.DeleteLoadBalancerListeners(ctx, request, optFns...)
+}
+
+func (m *listenerRecordingMockELB) CreateLoadBalancerListeners(ctx context.Context, request *elb.CreateLoadBalancerListenersInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerListenersOutput, error) {
+	for _, l := range request.Listeners {
+		m.calls = append(m.calls, fmt.Sprintf("CreateLoadBalancerListeners %d %s", l.LoadBalancerPort, aws.ToString(l.SSLCertificateId)))
+	}
+	return m.MockELB.CreateLoadBalancerListeners(ctx, request, optFns...)
+}
+
+func TestClassicLoadBalancerRecreatesOnlyChangedListeners(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &listenerRecordingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	newELB := func(certificates map[string]string) *ClassicLoadBalancer {
+		e := &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners:        map[string]*ClassicLoadBalancerListener{},
+			ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+				IdleTimeout: fi.PtrTo(int32(300)),
+			},
+			Tags: map[string]string{"Name": "api.example.com"},
+		}
+		for port, certificate := range certificates {
+			e.Listeners[port] = &ClassicLoadBalancerListener{InstancePort: 443, SSLCertificateID: certificate}
+		}
+		return e
+	}
+
+	created := newELB(map[string]string{
+		"443":  "arn:aws:acm:us-east-1:123456789012:certificate/api",
+		"8443": "arn:aws:acm:us-east-1:123456789012:certificate/admin",
+	})
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	e := newELB(map[string]string{
+		"443":  "arn:aws:acm:us-east-1:123456789012:certificate/api",
+		"8443": "arn:aws:acm:us-east-1:123456789012:certificate/admin-renewed",
+	})
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected to find the ELB")
+	}
+
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners == nil {
+		t.Fatalf("expected the certificate change to be detected")
+	}
+
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	expected := []string{
+		"DeleteLoadBalancerListeners [8443]",
+		"CreateLoadBalancerListeners 8443 arn:aws:acm:us-east-1:123456789012:certificate/admin-renewed",
+	}
+	if !reflect.DeepEqual(m.calls, expected) {
+		t.Errorf("unexpected listener calls, expected %q got %q", expected, m.calls)
+	}
+
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners != nil {
+		t.Errorf("expected no listener changes once reconciled, got %+v", changes.Listeners)
+	}
+}
+
+func TestClassicLoadBalancerDeletesRemovedListeners(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &listenerRecordingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	newELB := func(ports ...string) *ClassicLoadBalancer {
+		e := &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners:        map[string]*ClassicLoadBalancerListener{},
+			ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+				IdleTimeout: fi.PtrTo(int32(300)),
+			},
+			Tags: map[string]string{"Name": "api.example.com"},
+		}
+		for _, port := range ports {
+			e.Listeners[port] = &ClassicLoadBalancerListener{InstancePort: 443}
+		}
+		return e
+	}
+
+	created := newELB("443", "8443", "9443")
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	e := newELB("443", "9443")
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a == nil {
+		t.Fatalf("expected to find the ELB")
+	}
+
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners == nil {
+		t.Fatalf("expected the removed listener to be detected")
+	}
+
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	expected := []string{"DeleteLoadBalancerListeners [8443]"}
+	if !reflect.DeepEqual(m.calls, expected) {
+		t.Errorf("unexpected listener calls, expected %q got %q", expected, m.calls)
+	}
+
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	var ports []string
+	for port := range a.Listeners {
+		ports = append(ports, port)
+	}
+	sort.Strings(ports)
+	if expected := []string{"443", "9443"}; !reflect.DeepEqual(ports, expected) {
+		t.Errorf("expected listeners on ports %v, got %v", expected, ports)
+	}
+}
+
+func TestClassicLoadBalancerRefusesDetachingZoneWithInstances(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &instanceZonesMockEC2{
+		MockEC2: &mockec2.MockEC2{},
+		instanceZones: map[string]string{
+			"i-a": "us-east-1a",
+			"i-b": "us-east-1b",
+		},
+	}
+	cloud.MockEC2 = c
+	for id, zone := range map[string]string{"subnet-a": "us-east-1a", "subnet-b": "us-east-1b", "subnet-c": "us-east-1c"} {
+		if _, err := c.CreateSubnetWithId(&ec2.CreateSubnetInput{
+			VpcId:            aws.String("vpc-1"),
+			AvailabilityZone: aws.String(zone),
+		}, id); err != nil {
+			t.Fatalf("error creating subnet: %v", err)
+		}
+	}
+
+	grid := []struct {
+		name         string
+		instanceIDs  []string
+		featureFlags string
+		expectedErr  string
+		calls        []string
+	}{
+		{
+			name:        "instances in the detached zone",
+			instanceIDs: []string{"i-a", "i-b"},
+			expectedErr: `refusing to detach ELB "api-example-com" from the last subnet of a zone with registered instances [i-b (us-east-1b)]`,
+		},
+		{
+			name:        "no instances in the detached zone",
+			instanceIDs: []string{"i-a"},
+			calls:       []string{"attach subnet-c", "detach subnet-b"},
+		},
+		{
+			name:         "overridden by feature flag",
+			instanceIDs:  []string{"i-a", "i-b"},
+			featureFlags: "+ELBAllowDetachingZonesWithInstances",
+			calls:        []string{"attach subnet-c", "detach subnet-b"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			featureflag.ParseFlags(g.featureFlags)
+			defer featureflag.ParseFlags("-ELBAllowDetachingZonesWithInstances")
+
+			mock := &registeredInstancesMockELB{
+				subnetRecordingMockELB: &subnetRecordingMockELB{MockELB: &mockelb.MockELB{}},
+				instanceIDs:            g.instanceIDs,
+			}
+			cloud.MockELB = mock
+
+			e := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-example-com"),
+				Subnets:          []*Subnet{{ID: aws.String("subnet-a")}, {ID: aws.String("subnet-c")}},
+			}
+			a := &ClassicLoadBalancer{
+				Name:             fi.PtrTo("api.example.com"),
+				LoadBalancerName: fi.PtrTo("api-example-com"),
+				Subnets:          []*Subnet{{ID: aws.String("subnet-a")}, {ID: aws.String("subnet-b")}},
+			}
+			changes := &ClassicLoadBalancer{Subnets: e.Subnets}
+
+			err := e.RenderAWS(awsup.NewAWSAPITarget(cloud), a, e, changes)
+			if g.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), g.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", g.expectedErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error from RenderAWS: %v", err)
+			}
+			if !reflect.DeepEqual(mock.calls, g.calls) {
+				t.Errorf("unexpected subnet calls, expected %v got %v", g.calls, mock.calls)
+			}
+		})
+	}
+}
+
+func TestClassicLoadBalancerAddsTagsBeforeRemoving(t *testing.T) {
+	ctx := context.TODO()
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &callRecordingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+
+	if _, err := m.MockELB.CreateLoadBalancer(ctx, &elb.CreateLoadBalancerInput{LoadBalancerName: aws.String("api-example-com")}); err != nil {
+		t.Fatalf("error creating ELB: %v", err)
+	}
+	if _, err := m.MockELB.AddTags(ctx, &elb.AddTagsInput{
+		LoadBalancerNames: []string{"api-example-com"},
+		Tags: []elbtypes.Tag{
+			{Key: aws.String("Name"), Value: aws.String("api.example.com")},
+			{Key: aws.String("owner"), Value: aws.String("old-team")},
+			{Key: aws.String("stale"), Value: aws.String("true")},
+		},
+	}); err != nil {
+		t.Fatalf("error tagging ELB: %v", err)
+	}
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Tags: map[string]string{
+			"Name":  "api.example.com",
+			"owner": "new-team",
+		},
+	}
+	a := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Tags: map[string]string{
+			"Name":  "api.example.com",
+			"owner": "old-team",
+			"stale": "true",
+		},
+	}
+	if err := e.RenderAWS(awsup.NewAWSAPITarget(cloud), a, e, &ClassicLoadBalancer{Tags: e.Tags}); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	expectedCalls := []string{"AddTags", "RemoveTags stale"}
+	if !reflect.DeepEqual(m.calls, expectedCalls) {
+		t.Errorf("unexpected calls, expected %v got %v", expectedCalls, m.calls)
+	}
+
+	tags, err := cloud.GetELBTags("api-example-com")
+	if err != nil {
+		t.Fatalf("error getting ELB tags: %v", err)
+	}
+	if !reflect.DeepEqual(tags, e.Tags) {
+		t.Errorf("unexpected tags, expected %v got %v", e.Tags, tags)
+	}
+}
+
+// tagReadingMockELB also records the DescribeTags calls made against the mock.
+type tagReadingMockELB struct {
+	*callRecordingMockELB
+}
+
+func (m *tagReadingMockELB) DescribeTags(ctx context.Context, request *elb.DescribeTagsInput, optFns ...func(*elb.Options)) (*elb.DescribeTagsOutput, error) {
+	m.calls = append(m.calls, "DescribeTags")
+	return m.MockELB.DescribeTags(ctx, request, optFns...)
+}
+
+func TestClassicLoadBalancerSkipsUnchangedTags(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &tagReadingMockELB{callRecordingMockELB: &callRecordingMockELB{MockELB: &mockelb.MockELB{}}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Listeners: map[string]*ClassicLoadBalancerListener{
+			"443": {InstancePort: 443},
+		},
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+		Tags: map[string]string{"Name": "api.example.com"},
+	}
+	if err := e.RenderAWS(target, nil, e, e); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+
+	// Change an attribute, so that the ELB is still updated
+	e.ConnectionSettings = &ClassicLoadBalancerConnectionSettings{IdleTimeout: fi.PtrTo(int32(600))}
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Tags != nil {
+		t.Fatalf("expected no tag changes, got %v", changes.Tags)
+	}
+
+	m.calls = nil
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	expected := []string{"ModifyLoadBalancerAttributes idle_timeout=600"}
+	if !reflect.DeepEqual(m.calls, expected) {
+		t.Errorf("expected calls %q, got %q", expected, m.calls)
+	}
+}
+
+func TestClassicLoadBalancerDryRunClassifiesChanges(t *testing.T) {
+	a := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(60)),
+		},
+	}
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		Scheme:           fi.PtrTo("internal"),
+		ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+			IdleTimeout: fi.PtrTo(int32(300)),
+		},
+	}
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+
+	target := fi.NewCloudupDryRunTarget(assets.NewAssetBuilder(vfs.Context, nil, "1.30.0", false), io.Discard)
+	if err := target.Render(a, e, changes); err != nil {
+		t.Fatalf("unexpected error from Render: %v", err)
+	}
+	var out bytes.Buffer
+	if err := target.PrintReport(map[string]fi.CloudupTask{"api.example.com": e}, &out); err != nil {
+		t.Fatalf("unexpected error from PrintReport: %v", err)
+	}
+
+	expected := map[string]string{
+		"ConnectionSettings": "(non-disruptive)",
+		"Scheme":             "(disruptive)",
+	}
+	for fieldName, classification := range expected {
+		found := false
+		for _, line := range strings.Split(out.String(), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) > 0 && fields[0] == fieldName {
+				found = true
+				if !strings.HasSuffix(line, " "+classification) {
+					t.Errorf("expected %s change to be %s, got %q", fieldName, classification, line)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s change not found in report:\n%s", fieldName, out.String())
+		}
+	}
+}
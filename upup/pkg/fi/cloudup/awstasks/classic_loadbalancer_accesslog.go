@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// elbLogDeliveryAccountIDs are the accounts that deliver ELB access logs in the regions launched before August 2022.
+// Newer regions deliver them with the elbLogDeliveryServicePrincipal instead.
+// See https://docs.aws.amazon.com/elasticloadbalancing/latest/classic/enable-access-logs.html
+var elbLogDeliveryAccountIDs = map[string]string{
+	"us-east-1":      "127311923021",
+	"us-east-2":      "033677994240",
+	"us-west-1":      "027434742980",
+	"us-west-2":      "797873946194",
+	"af-south-1":     "098369216593",
+	"ap-east-1":      "754344448648",
+	"ap-southeast-3": "589379963580",
+	"ap-south-1":     "718504428378",
+	"ap-northeast-3": "383597477331",
+	"ap-northeast-2": "600734575887",
+	"ap-southeast-1": "114774131450",
+	"ap-southeast-2": "783225319266",
+	"ap-northeast-1": "582318560864",
+	"ca-central-1":   "985666609251",
+	"eu-central-1":   "054676820928",
+	"eu-west-1":      "156460612806",
+	"eu-west-2":      "652711504416",
+	"eu-south-1":     "635631232127",
+	"eu-west-3":      "009996457667",
+	"eu-north-1":     "897822967062",
+	"me-south-1":     "076674570225",
+	"sa-east-1":      "507241528517",
+	"us-gov-west-1":  "048591011584",
+	"us-gov-east-1":  "190560391635",
+	"cn-north-1":     "638102146993",
+	"cn-northwest-1": "037604701340",
+}
+
+const elbLogDeliveryServicePrincipal = "logdelivery.elasticloadbalancing.amazonaws.com"
+
+type accessLogBucketPolicyPrincipal struct {
+	AWS     string `json:",omitempty"`
+	Service string `json:",omitempty"`
+}
+
+type accessLogBucketPolicyStatement struct {
+	Effect    string
+	Principal accessLogBucketPolicyPrincipal
+	Action    string
+	Resource  string
+}
+
+// buildAccessLogBucketPolicyStatement returns the S3 bucket policy statement allowing ELB to deliver the access logs
+// of the load balancers of an account and region to the bucket and prefix.
+// kOps doesn't manage the access log bucket, so the statement is suggested to the user when delivery is denied.
+func buildAccessLogBucketPolicyStatement(partition, region, accountID, bucket, prefix string) (string, error) {
+	statement := accessLogBucketPolicyStatement{
+		Effect: "Allow",
+		Action: "s3:PutObject",
+	}
+	if elbAccountID, found := elbLogDeliveryAccountIDs[region]; found {
+		statement.Principal.AWS = fmt.Sprintf("arn:%s:iam::%s:root", partition, elbAccountID)
+	} else {
+		statement.Principal.Service = elbLogDeliveryServicePrincipal
+	}
+
+	resource := bucket
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		resource += "/" + prefix
+	}
+	statement.Resource = fmt.Sprintf("arn:%s:s3:::%s/AWSLogs/%s/*", partition, resource, accountID)
+
+	b, err := json.Marshal(statement)
+	if err != nil {
+		return "", fmt.Errorf("error building access log bucket policy statement: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/smithy-go"
+	"k8s.io/kops/cloudmock/aws/mockelb"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestBuildAccessLogBucketPolicyStatement(t *testing.T) {
+	grid := []struct {
+		name      string
+		partition string
+		region    string
+		prefix    string
+		expected  string
+	}{
+		{
+			name:      "region with log delivery account",
+			partition: "aws",
+			region:    "us-east-1",
+			prefix:    "api",
+			expected:  `{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::127311923021:root"},"Action":"s3:PutObject","Resource":"arn:aws:s3:::elb-logs/api/AWSLogs/123456789012/*"}`,
+		},
+		{
+			name:      "region with log delivery service",
+			partition: "aws",
+			region:    "ap-southeast-4",
+			expected:  `{"Effect":"Allow","Principal":{"Service":"logdelivery.elasticloadbalancing.amazonaws.com"},"Action":"s3:PutObject","Resource":"arn:aws:s3:::elb-logs/AWSLogs/123456789012/*"}`,
+		},
+		{
+			name:      "other partition and slashed prefix",
+			partition: "aws-cn",
+			region:    "cn-north-1",
+			prefix:    "clusters/api/",
+			expected:  `{"Effect":"Allow","Principal":{"AWS":"arn:aws-cn:iam::638102146993:root"},"Action":"s3:PutObject","Resource":"arn:aws-cn:s3:::elb-logs/clusters/api/AWSLogs/123456789012/*"}`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			actual, err := buildAccessLogBucketPolicyStatement(g.partition, g.region, "123456789012", "elb-logs", g.prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != g.expected {
+				t.Errorf("unexpected statement, expected %s got %s", g.expected, actual)
+			}
+		})
+	}
+}
+
+// accessDeniedMockELB rejects access logging, as ELB does when it can't write to the bucket.
+type accessDeniedMockELB struct {
+	*mockelb.MockELB
+}
+
+func (m *accessDeniedMockELB) ModifyLoadBalancerAttributes(ctx context.Context, request *elb.ModifyLoadBalancerAttributesInput, optFns ...func(*elb.Options)) (*elb.ModifyLoadBalancerAttributesOutput, error) {
+	return nil, &smithy.GenericAPIError{Code: "InvalidConfigurationRequest", Message: "Access Denied for bucket: elb-logs"}
+}
+
+func TestClassicLoadBalancerAccessLogDeniedSuggestsBucketPolicy(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-west-2", "abc")
+	m := &accessDeniedMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+
+	if _, err := m.CreateLoadBalancer(ctx, &elb.CreateLoadBalancerInput{LoadBalancerName: aws.String("api-example-com")}); err != nil {
+		t.Fatalf("error creating ELB: %v", err)
+	}
+
+	e := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+		AccessLog: &ClassicLoadBalancerAccessLog{
+			Enabled:        fi.PtrTo(true),
+			S3BucketName:   fi.PtrTo("elb-logs"),
+			S3BucketPrefix: fi.PtrTo("api"),
+		},
+	}
+	a := &ClassicLoadBalancer{
+		Name:             fi.PtrTo("api.example.com"),
+		LoadBalancerName: fi.PtrTo("api-example-com"),
+	}
+
+	err := e.RenderAWS(awsup.NewAWSAPITarget(cloud), a, e, &ClassicLoadBalancer{AccessLog: e.AccessLog})
+	if err == nil {
+		t.Fatalf("expected error enabling access logs")
+	}
+	// The bucket may be owned by a central logging account, so the statement is built without reading
+	// the bucket, from the account that owns the load balancer.
+	expected := `{"Effect":"Allow","Principal":{"AWS":"arn:aws-test:iam::797873946194:root"},"Action":"s3:PutObject","Resource":"arn:aws-test:s3:::elb-logs/api/AWSLogs/123456789012/*"}`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected error to suggest the bucket policy statement %s, got %v", expected, err)
+	}
+}
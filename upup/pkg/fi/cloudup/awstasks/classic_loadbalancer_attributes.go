@@ -19,6 +19,7 @@ package awstasks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
@@ -40,8 +41,8 @@ func (_ *ClassicLoadBalancerAccessLog) GetDependencies(tasks map[string]fi.Cloud
 }
 
 type terraformLoadBalancerAccessLog struct {
-	EmitInterval   *int32  `cty:"interval"`
-	Enabled        *bool   `cty:"enabled"`
+	EmitInterval   *int32  `cty:"interval" providerdefault:"60"`
+	Enabled        *bool   `cty:"enabled" providerdefault:"true"`
 	S3BucketName   *string `cty:"bucket"`
 	S3BucketPrefix *string `cty:"bucket_prefix"`
 }
@@ -55,6 +56,9 @@ type terraformLoadBalancerAccessLog struct {
 //	return nil
 //}
 
+// defaultConnectionDrainingTimeout is the timeout AWS applies when connection draining is enabled without one.
+const defaultConnectionDrainingTimeout = 300
+
 type ClassicLoadBalancerConnectionDraining struct {
 	Enabled *bool
 	Timeout *int32
@@ -80,6 +84,22 @@ func (_ *ClassicLoadBalancerConnectionSettings) GetDependencies(tasks map[string
 	return nil
 }
 
+// desyncMitigationModeAttribute is the additional ELB attribute holding the HTTP desync mitigation mode.
+const desyncMitigationModeAttribute = "elb.http.desyncmitigationmode"
+
+// desyncMitigationModes are the supported HTTP desync mitigation modes.
+var desyncMitigationModes = []string{"monitor", "defensive", "strictest"}
+
+// findDesyncMitigationMode returns the HTTP desync mitigation mode from the additional attributes of the ELB.
+func findDesyncMitigationMode(attributes *elbtypes.LoadBalancerAttributes) *string {
+	for _, attribute := range attributes.AdditionalAttributes {
+		if aws.ToString(attribute.Key) == desyncMitigationModeAttribute {
+			return attribute.Value
+		}
+	}
+	return nil
+}
+
 func findELBAttributes(ctx context.Context, cloud awsup.AWSCloud, name string) (*elbtypes.LoadBalancerAttributes, error) {
 	request := &elb.DescribeLoadBalancerAttributesInput{
 		LoadBalancerName: aws.String(name),
@@ -96,11 +116,15 @@ func findELBAttributes(ctx context.Context, cloud awsup.AWSCloud, name string) (
 	return response.LoadBalancerAttributes, nil
 }
 
+// modifyLoadBalancerAttributes applies the access log, connection draining, connection settings and cross-zone
+// attributes in a single ModifyLoadBalancerAttributes request, so that they are applied (or rejected) together.
+// The full desired state is sent even if only one group changed; unset mandatory attributes get their defaults.
 func (_ *ClassicLoadBalancer) modifyLoadBalancerAttributes(t *awsup.AWSAPITarget, a, e, changes *ClassicLoadBalancer) error {
 	if changes.AccessLog == nil &&
 		changes.ConnectionDraining == nil &&
 		changes.ConnectionSettings == nil &&
-		changes.CrossZoneLoadBalancing == nil {
+		changes.CrossZoneLoadBalancing == nil &&
+		changes.DesyncMitigationMode == nil {
 		klog.V(4).Infof("No LoadBalancerAttribute changes; skipping update")
 		return nil
 	}
@@ -122,7 +146,7 @@ func (_ *ClassicLoadBalancer) modifyLoadBalancerAttributes(t *awsup.AWSAPITarget
 		request.LoadBalancerAttributes.ConnectionDraining.Enabled = false
 	}
 	if e.ConnectionDraining == nil || e.ConnectionDraining.Timeout == nil {
-		request.LoadBalancerAttributes.ConnectionDraining.Timeout = aws.Int32(300)
+		request.LoadBalancerAttributes.ConnectionDraining.Timeout = aws.Int32(defaultConnectionDrainingTimeout)
 	}
 	request.LoadBalancerAttributes.ConnectionSettings = &elbtypes.ConnectionSettings{}
 	if e.ConnectionSettings == nil || e.ConnectionSettings.IdleTimeout == nil {
@@ -137,17 +161,15 @@ func (_ *ClassicLoadBalancer) modifyLoadBalancerAttributes(t *awsup.AWSAPITarget
 
 	// Setting non mandatory values only if not empty
 
-	// We don't map AdditionalAttributes (yet)
-	//if len(e.AdditionalAttributes) != 0 {
-	//	var additionalAttributes []*elb.AdditionalAttribute
-	//	for index, additionalAttribute := range e.AdditionalAttributes {
-	//		additionalAttributes[index] = &elb.AdditionalAttribute{
-	//			Key:   additionalAttribute.Key,
-	//			Value: additionalAttribute.Value,
-	//		}
-	//	}
-	//	request.LoadBalancerAttributes.AdditionalAttributes = additionalAttributes
-	//}
+	// Additional attributes that are not sent are left unchanged
+	if e.DesyncMitigationMode != nil {
+		request.LoadBalancerAttributes.AdditionalAttributes = []elbtypes.AdditionalAttribute{
+			{
+				Key:   aws.String(desyncMitigationModeAttribute),
+				Value: e.DesyncMitigationMode,
+			},
+		}
+	}
 
 	if e.AccessLog != nil && e.AccessLog.Enabled != nil {
 		request.LoadBalancerAttributes.AccessLog.Enabled = aws.ToBool(e.AccessLog.Enabled)
@@ -172,13 +194,102 @@ func (_ *ClassicLoadBalancer) modifyLoadBalancerAttributes(t *awsup.AWSAPITarget
 	}
 
 	klog.V(2).Infof("Configuring ELB attributes for ELB %q", loadBalancerName)
+	if klog.V(2).Enabled() {
+		for _, change := range changedLoadBalancerAttributes(a, request.LoadBalancerAttributes) {
+			klog.Infof("Changing attribute of ELB %q: %s", loadBalancerName, change)
+		}
+	}
 
 	response, err := t.Cloud.ELB().ModifyLoadBalancerAttributes(ctx, request)
 	if err != nil {
-		return fmt.Errorf("error configuring ELB attributes for ELB %q: %v", loadBalancerName, err)
+		if request.LoadBalancerAttributes.AccessLog.Enabled && isAccessLogBucketAccessDenied(err) {
+			// The bucket may be owned by another account, in which case we can't inspect its policy ourselves
+			bucket := aws.ToString(request.LoadBalancerAttributes.AccessLog.S3BucketName)
+			operation := fmt.Sprintf("configuring ELB attributes (the policy of S3 bucket %q must allow the ELB log delivery service to write to it)", bucket)
+			if statement := accessLogBucketPolicyHint(ctx, t.Cloud, bucket, aws.ToString(request.LoadBalancerAttributes.AccessLog.S3BucketPrefix)); statement != "" {
+				operation = fmt.Sprintf("configuring ELB attributes (the policy of S3 bucket %q must allow the ELB log delivery service to write to it, e.g. with the statement %s)", bucket, statement)
+			}
+			return newELBTaskError(operation, loadBalancerName, err)
+		}
+		return newELBTaskError("configuring ELB attributes", loadBalancerName, err)
 	}
 
 	klog.V(4).Infof("modified ELB attributes for ELB %q, response %+v", loadBalancerName, response)
 
 	return nil
 }
+
+// changedLoadBalancerAttributes lists the attributes being set on the ELB that differ from the actual ELB,
+// formatted as "<attribute>: <old> -> <new>". The actual ELB is nil when it is being created.
+func changedLoadBalancerAttributes(a *ClassicLoadBalancer, attributes *elbtypes.LoadBalancerAttributes) []string {
+	var accessLog ClassicLoadBalancerAccessLog
+	var connectionDraining ClassicLoadBalancerConnectionDraining
+	var connectionSettings ClassicLoadBalancerConnectionSettings
+	var crossZoneLoadBalancing ClassicLoadBalancerCrossZoneLoadBalancing
+	if a != nil {
+		if a.AccessLog != nil {
+			accessLog = *a.AccessLog
+		}
+		if a.ConnectionDraining != nil {
+			connectionDraining = *a.ConnectionDraining
+		}
+		if a.ConnectionSettings != nil {
+			connectionSettings = *a.ConnectionSettings
+		}
+		if a.CrossZoneLoadBalancing != nil {
+			crossZoneLoadBalancing = *a.CrossZoneLoadBalancing
+		}
+	}
+
+	var changed []string
+	add := func(name string, from, to interface{}) {
+		if from != to {
+			changed = append(changed, fmt.Sprintf("%s: %v -> %v", name, from, to))
+		}
+	}
+	add("AccessLog.Enabled", attributeValue(accessLog.Enabled), attributes.AccessLog.Enabled)
+	add("AccessLog.EmitInterval", attributeValue(accessLog.EmitInterval), attributeValue(attributes.AccessLog.EmitInterval))
+	add("AccessLog.S3BucketName", attributeValue(accessLog.S3BucketName), attributeValue(attributes.AccessLog.S3BucketName))
+	add("AccessLog.S3BucketPrefix", attributeValue(accessLog.S3BucketPrefix), attributeValue(attributes.AccessLog.S3BucketPrefix))
+	add("ConnectionDraining.Enabled", attributeValue(connectionDraining.Enabled), attributes.ConnectionDraining.Enabled)
+	add("ConnectionDraining.Timeout", attributeValue(connectionDraining.Timeout), attributeValue(attributes.ConnectionDraining.Timeout))
+	add("ConnectionSettings.IdleTimeout", attributeValue(connectionSettings.IdleTimeout), attributeValue(attributes.ConnectionSettings.IdleTimeout))
+	add("CrossZoneLoadBalancing.Enabled", attributeValue(crossZoneLoadBalancing.Enabled), attributes.CrossZoneLoadBalancing.Enabled)
+	if desyncMitigationMode := findDesyncMitigationMode(attributes); desyncMitigationMode != nil {
+		var actualDesyncMitigationMode *string
+		if a != nil {
+			actualDesyncMitigationMode = a.DesyncMitigationMode
+		}
+		add("DesyncMitigationMode", attributeValue(actualDesyncMitigationMode), *desyncMitigationMode)
+	}
+	return changed
+}
+
+// attributeValue dereferences an optional attribute value for comparison and logging.
+func attributeValue[T comparable](v *T) interface{} {
+	if v == nil {
+		return "<unset>"
+	}
+	return *v
+}
+
+// accessLogBucketPolicyHint returns the bucket policy statement allowing ELB to deliver access logs to the bucket,
+// or an empty string if it can't be built.
+func accessLogBucketPolicyHint(ctx context.Context, cloud awsup.AWSCloud, bucket, prefix string) string {
+	accountID, partition, err := cloud.AccountInfo(ctx)
+	if err != nil {
+		klog.Warningf("unable to determine AWS account for access log bucket policy: %v", err)
+		return ""
+	}
+	statement, err := buildAccessLogBucketPolicyStatement(partition, cloud.Region(), accountID, bucket, prefix)
+	if err != nil {
+		klog.Warningf("%v", err)
+		return ""
+	}
+	return statement
+}
+
+// isAccessLogBucketAccessDenied returns true if the error indicates that ELB could not write to the access log bucket.
+func isAccessLogBucketAccessDenied(err error) bool {
+	return awsup.AWSErrorCode(err) == "InvalidConfigurationRequest" && strings.Contains(awsup.AWSErrorMessage(err), "Access Denied")
+}
@@ -17,6 +17,11 @@ limitations under the License.
 package awstasks
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
 	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	"k8s.io/kops/upup/pkg/fi"
 )
@@ -31,6 +36,122 @@ type ClassicLoadBalancerHealthCheck struct {
 	Timeout  *int32
 }
 
+// ClassicLoadBalancerHealthCheckTarget is the structured form of a health check target.
+// ELB, and the terraform aws_elb resource, only accept the packed form
+// <protocol>:<port>[<path>], for example "SSL:443" or "HTTP:3990/readyz".
+type ClassicLoadBalancerHealthCheckTarget struct {
+	// Protocol is one of TCP, SSL, HTTP or HTTPS.
+	Protocol string
+	// Port is the instance port that is checked.
+	Port int32
+	// Path is the path that is requested; it is required for HTTP and HTTPS, and not allowed otherwise.
+	Path string
+}
+
+// String returns the packed form of the target.
+func (t *ClassicLoadBalancerHealthCheckTarget) String() string {
+	return fmt.Sprintf("%s:%d%s", t.Protocol, t.Port, t.Path)
+}
+
+// Validate checks that the target is accepted by ELB.
+func (t *ClassicLoadBalancerHealthCheckTarget) Validate() error {
+	if t.Port < 1 || t.Port > 65535 {
+		return fmt.Errorf("invalid health check port %d", t.Port)
+	}
+	switch t.Protocol {
+	case "TCP", "SSL":
+		if t.Path != "" {
+			return fmt.Errorf("health check path is not allowed for protocol %s", t.Protocol)
+		}
+	case "HTTP", "HTTPS":
+		if !strings.HasPrefix(t.Path, "/") {
+			return fmt.Errorf("health check path must start with / for protocol %s", t.Protocol)
+		}
+		if err := validateHealthCheckTargetCharacters(t.Path); err != nil {
+			return fmt.Errorf("invalid health check path: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported health check protocol %q", t.Protocol)
+	}
+	return nil
+}
+
+// validateHealthCheckTargetCharacters rejects whitespace and non-ASCII characters,
+// which ELB doesn't reject but silently mangles, leaving a health check that never passes.
+func validateHealthCheckTargetCharacters(s string) error {
+	for i, r := range s {
+		if r > unicode.MaxASCII {
+			return fmt.Errorf("non-ASCII character %q at offset %d is not allowed", r, i)
+		}
+		if unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return fmt.Errorf("whitespace or control character %q at offset %d is not allowed", r, i)
+		}
+	}
+	return nil
+}
+
+// ParseClassicLoadBalancerHealthCheckTarget parses and validates a packed health check target.
+func ParseClassicLoadBalancerHealthCheckTarget(s string) (*ClassicLoadBalancerHealthCheckTarget, error) {
+	if err := validateHealthCheckTargetCharacters(s); err != nil {
+		return nil, fmt.Errorf("invalid health check target %q: %w", s, err)
+	}
+	protocol, rest, found := strings.Cut(s, ":")
+	if !found {
+		return nil, fmt.Errorf("health check target %q is not of the form <protocol>:<port>[<path>]", s)
+	}
+	portString, path := rest, ""
+	if i := strings.Index(rest, "/"); i >= 0 {
+		portString, path = rest[:i], rest[i:]
+	}
+	port, err := strconv.ParseInt(portString, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("health check target %q has an invalid port", s)
+	}
+
+	t := &ClassicLoadBalancerHealthCheckTarget{
+		Protocol: protocol,
+		Port:     int32(port),
+		Path:     path,
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid health check target %q: %w", s, err)
+	}
+	return t, nil
+}
+
+// normalizeHealthCheckTarget returns the target in a canonical form, so that targets that ELB treats
+// as equivalent compare as equal: the protocol is upper-cased and any trailing slash is removed from the path.
+// The path is otherwise left alone, as HTTP paths are case sensitive. Targets that don't parse are returned unchanged.
+func normalizeHealthCheckTarget(s string) string {
+	protocol, rest, found := strings.Cut(s, ":")
+	if !found {
+		return s
+	}
+	if i := strings.Index(rest, "/"); i >= 0 {
+		portString, path := rest[:i], rest[i:]
+		if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+			path = trimmed
+		} else {
+			path = "/"
+		}
+		rest = portString + path
+	}
+	return strings.ToUpper(protocol) + ":" + rest
+}
+
+// fallbackGRPCHealthCheckTarget replaces a gRPC health check target, which ELB does not support, with a TCP check
+// of the same port; a TCP check only verifies that the port accepts connections, not that the gRPC service is serving.
+// It returns a warning when the target was replaced.
+func fallbackGRPCHealthCheckTarget(s string) (string, string) {
+	protocol, rest, found := strings.Cut(s, ":")
+	if !found || !strings.EqualFold(protocol, "GRPC") {
+		return s, ""
+	}
+	port, _, _ := strings.Cut(rest, "/")
+	target := "TCP:" + port
+	return target, fmt.Sprintf("classic load balancers do not support gRPC health checks; using %q instead of %q", target, s)
+}
+
 var _ fi.CloudupHasDependencies = &ClassicLoadBalancerListener{}
 
 func (e *ClassicLoadBalancerHealthCheck) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
@@ -44,7 +165,9 @@ func findHealthCheck(lb *elbtypes.LoadBalancerDescription) (*ClassicLoadBalancer
 
 	actual := &ClassicLoadBalancerHealthCheck{}
 	if lb.HealthCheck != nil {
-		actual.Target = lb.HealthCheck.Target
+		if lb.HealthCheck.Target != nil {
+			actual.Target = fi.PtrTo(normalizeHealthCheckTarget(*lb.HealthCheck.Target))
+		}
 		actual.HealthyThreshold = lb.HealthCheck.HealthyThreshold
 		actual.UnhealthyThreshold = lb.HealthCheck.UnhealthyThreshold
 		actual.Interval = lb.HealthCheck.Interval
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestClassicLoadBalancerHealthCheckTarget(t *testing.T) {
+	grid := []struct {
+		target   ClassicLoadBalancerHealthCheckTarget
+		expected string
+	}{
+		{
+			target:   ClassicLoadBalancerHealthCheckTarget{Protocol: "SSL", Port: 443},
+			expected: "SSL:443",
+		},
+		{
+			target:   ClassicLoadBalancerHealthCheckTarget{Protocol: "TCP", Port: 8443},
+			expected: "TCP:8443",
+		},
+		{
+			target:   ClassicLoadBalancerHealthCheckTarget{Protocol: "HTTP", Port: 3990, Path: "/readyz"},
+			expected: "HTTP:3990/readyz",
+		},
+		{
+			target:   ClassicLoadBalancerHealthCheckTarget{Protocol: "HTTPS", Port: 443, Path: "/"},
+			expected: "HTTPS:443/",
+		},
+	}
+	for _, g := range grid {
+		if err := g.target.Validate(); err != nil {
+			t.Errorf("unexpected error validating %+v: %v", g.target, err)
+		}
+		if actual := g.target.String(); actual != g.expected {
+			t.Errorf("expected %q, got %q", g.expected, actual)
+		}
+
+		parsed, err := ParseClassicLoadBalancerHealthCheckTarget(g.expected)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", g.expected, err)
+		} else if !reflect.DeepEqual(*parsed, g.target) {
+			t.Errorf("parsing %q: expected %+v, got %+v", g.expected, g.target, *parsed)
+		}
+	}
+}
+
+func TestParseClassicLoadBalancerHealthCheckTargetInvalid(t *testing.T) {
+	for _, target := range []string{
+		"",
+		"SSL",
+		"SSL:",
+		"SSL:abc",
+		"SSL:0",
+		"SSL:70000",
+		"SSL:443/healthz",
+		"HTTP:80",
+		"UDP:53",
+		"ssl:443",
+	} {
+		if _, err := ParseClassicLoadBalancerHealthCheckTarget(target); err == nil {
+			t.Errorf("expected an error parsing %q", target)
+		}
+	}
+}
+
+func TestParseClassicLoadBalancerHealthCheckTargetCharacters(t *testing.T) {
+	grid := map[string]string{
+		"HTTP:3990/ready z":          "whitespace or control character ' ' at offset 15 is not allowed",
+		"HTTP:3990/readyz\n":         "whitespace or control character '\\n' at offset 16 is not allowed",
+		" SSL:443":                   "whitespace or control character ' ' at offset 0 is not allowed",
+		"SSL: 443":                   "whitespace or control character ' ' at offset 4 is not allowed",
+		"HTTP:3990/gesundheit\u00e9": "non-ASCII character 'é' at offset 20 is not allowed",
+		"HTTP:3990/\u0440\u0435\u0430\u0434\u0438": "non-ASCII character 'р' at offset 10 is not allowed",
+	}
+	for target, expected := range grid {
+		_, err := ParseClassicLoadBalancerHealthCheckTarget(target)
+		if err == nil {
+			t.Errorf("expected an error parsing %q", target)
+			continue
+		}
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("parsing %q: expected error containing %q, got %q", target, expected, err)
+		}
+	}
+
+	// The structured form is checked as well
+	target := &ClassicLoadBalancerHealthCheckTarget{Protocol: "HTTP", Port: 3990, Path: "/ready z"}
+	if err := target.Validate(); err == nil {
+		t.Errorf("expected an error validating %+v", target)
+	}
+}
+
+func TestNormalizeHealthCheckTarget(t *testing.T) {
+	grid := map[string]string{
+		"SSL:443":            "SSL:443",
+		"ssl:443":            "SSL:443",
+		"HTTP:3990/readyz":   "HTTP:3990/readyz",
+		"HTTP:3990/readyz/":  "HTTP:3990/readyz",
+		"http:3990/Readyz//": "HTTP:3990/Readyz",
+		"HTTP:80/":           "HTTP:80/",
+		"HTTP:80//":          "HTTP:80/",
+		"not-a-target":       "not-a-target",
+	}
+	for target, expected := range grid {
+		if actual := normalizeHealthCheckTarget(target); actual != expected {
+			t.Errorf("normalizing %q: expected %q, got %q", target, expected, actual)
+		}
+	}
+}
+
+func TestClassicLoadBalancerHealthCheckTargetTrailingSlashSteadyState(t *testing.T) {
+	e := &ClassicLoadBalancer{
+		Name: fi.PtrTo("api.example.com"),
+		HealthCheck: &ClassicLoadBalancerHealthCheck{
+			Target:   fi.PtrTo("HTTP:3990/readyz/"),
+			Interval: fi.PtrTo(int32(10)),
+		},
+	}
+	if err := e.Normalize(nil); err != nil {
+		t.Fatalf("unexpected error from Normalize: %v", err)
+	}
+
+	// AWS reports the target without the trailing slash
+	healthCheck, err := findHealthCheck(&elbtypes.LoadBalancerDescription{
+		HealthCheck: &elbtypes.HealthCheck{
+			Target:   aws.String("HTTP:3990/readyz"),
+			Interval: aws.Int32(10),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error from findHealthCheck: %v", err)
+	}
+	a := &ClassicLoadBalancer{
+		Name:        fi.PtrTo("api.example.com"),
+		HealthCheck: healthCheck,
+	}
+
+	changes := &ClassicLoadBalancer{}
+	if fi.BuildChanges(a, e, changes) {
+		t.Errorf("expected no changes, got health check %+v", changes.HealthCheck)
+	}
+}
+
+func TestFallbackGRPCHealthCheckTarget(t *testing.T) {
+	grid := []struct {
+		target   string
+		expected string
+		warning  string
+	}{
+		{
+			target:   "GRPC:50051",
+			expected: "TCP:50051",
+			warning:  `classic load balancers do not support gRPC health checks; using "TCP:50051" instead of "GRPC:50051"`,
+		},
+		{
+			target:   "grpc:8443/grpc.health.v1.Health/Check",
+			expected: "TCP:8443",
+			warning:  `classic load balancers do not support gRPC health checks; using "TCP:8443" instead of "grpc:8443/grpc.health.v1.Health/Check"`,
+		},
+		{
+			target:   "SSL:443",
+			expected: "SSL:443",
+		},
+		{
+			target:   "HTTP:3990/readyz",
+			expected: "HTTP:3990/readyz",
+		},
+	}
+	for _, g := range grid {
+		actual, warning := fallbackGRPCHealthCheckTarget(g.target)
+		if actual != g.expected {
+			t.Errorf("%q: expected target %q, got %q", g.target, g.expected, actual)
+		}
+		if warning != g.warning {
+			t.Errorf("%q: expected warning %q, got %q", g.target, g.warning, warning)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+const (
+	// sslNegotiationPolicyType is the type of the ELB policies that configure how SSL listeners negotiate connections.
+	sslNegotiationPolicyType = "SSLNegotiationPolicyType"
+	// referenceSecurityPolicyAttribute is the SSL negotiation policy attribute naming the security policy it is based on.
+	referenceSecurityPolicyAttribute = "Reference-Security-Policy"
+)
+
+// sslNegotiationPolicyName returns the name of the SSL negotiation policy created for a security policy.
+// ELB policies can't be modified, so each security policy gets its own.
+func sslNegotiationPolicyName(sslPolicy string) string {
+	return "kops-" + sslPolicy
+}
+
+// hasListenerSSLPolicies returns true if any of the listeners sets a security policy.
+func hasListenerSSLPolicies(listeners map[string]*ClassicLoadBalancerListener) bool {
+	for _, listener := range listeners {
+		if listener.SSLPolicy != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// findListenerSSLPolicies returns the security policy of the SSL negotiation policy of each listener, by load balancer port.
+func findListenerSSLPolicies(ctx context.Context, cloud awsup.AWSCloud, lb *elbtypes.LoadBalancerDescription) (map[int32]string, error) {
+	var policyNames []string
+	for _, ld := range lb.ListenerDescriptions {
+		policyNames = append(policyNames, ld.PolicyNames...)
+	}
+	if len(policyNames) == 0 {
+		return nil, nil
+	}
+
+	request := &elb.DescribeLoadBalancerPoliciesInput{
+		LoadBalancerName: lb.LoadBalancerName,
+		PolicyNames:      policyNames,
+	}
+	response, err := cloud.ELB().DescribeLoadBalancerPolicies(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing policies of ELB %q: %w", aws.ToString(lb.LoadBalancerName), err)
+	}
+
+	securityPolicies := make(map[string]string)
+	for _, policy := range response.PolicyDescriptions {
+		if aws.ToString(policy.PolicyTypeName) != sslNegotiationPolicyType {
+			continue
+		}
+		for _, attribute := range policy.PolicyAttributeDescriptions {
+			if aws.ToString(attribute.AttributeName) == referenceSecurityPolicyAttribute {
+				securityPolicies[aws.ToString(policy.PolicyName)] = aws.ToString(attribute.AttributeValue)
+			}
+		}
+	}
+
+	sslPolicies := make(map[int32]string)
+	for _, ld := range lb.ListenerDescriptions {
+		if ld.Listener == nil {
+			continue
+		}
+		for _, policyName := range ld.PolicyNames {
+			if securityPolicy, found := securityPolicies[policyName]; found {
+				sslPolicies[ld.Listener.LoadBalancerPort] = securityPolicy
+			}
+		}
+	}
+	return sslPolicies, nil
+}
+
+// setListenerSSLPolicies applies the security policy of each listener that sets one.
+// Listeners without a security policy are left alone, and keep the policy ELB assigned to them.
+func setListenerSSLPolicies(ctx context.Context, t *awsup.AWSAPITarget, loadBalancerName string, listeners map[string]*ClassicLoadBalancerListener) error {
+	ports := make([]string, 0, len(listeners))
+	for port, listener := range listeners {
+		if listener.SSLPolicy != nil {
+			ports = append(ports, port)
+		}
+	}
+	sort.Strings(ports)
+
+	for _, port := range ports {
+		loadBalancerPort, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return fmt.Errorf("error parsing load balancer listener port: %q", port)
+		}
+		sslPolicy := *listeners[port].SSLPolicy
+		policyName := sslNegotiationPolicyName(sslPolicy)
+
+		klog.V(2).Infof("Setting security policy of listener %s of ELB %q to %q", port, loadBalancerName, sslPolicy)
+
+		_, err = t.Cloud.ELB().CreateLoadBalancerPolicy(ctx, &elb.CreateLoadBalancerPolicyInput{
+			LoadBalancerName: aws.String(loadBalancerName),
+			PolicyName:       aws.String(policyName),
+			PolicyTypeName:   aws.String(sslNegotiationPolicyType),
+			PolicyAttributes: []elbtypes.PolicyAttribute{
+				{
+					AttributeName:  aws.String(referenceSecurityPolicyAttribute),
+					AttributeValue: aws.String(sslPolicy),
+				},
+			},
+		})
+		// The policy is shared by all the listeners using the same security policy
+		if err != nil && awsup.AWSErrorCode(err) != "DuplicatePolicyName" {
+			return newELBTaskError("creating SSL negotiation policy", loadBalancerName, err)
+		}
+
+		_, err = t.Cloud.ELB().SetLoadBalancerPoliciesOfListener(ctx, &elb.SetLoadBalancerPoliciesOfListenerInput{
+			LoadBalancerName: aws.String(loadBalancerName),
+			LoadBalancerPort: int32(loadBalancerPort),
+			PolicyNames:      []string{policyName},
+		})
+		if err != nil {
+			return newELBTaskError("setting listener policies", loadBalancerName, err)
+		}
+	}
+	return nil
+}
+
+type terraformLoadBalancerSSLNegotiationPolicy struct {
+	Count        *terraformWriter.Literal                              `cty:"count"`
+	Name         string                                                `cty:"name"`
+	LoadBalancer *terraformWriter.Literal                              `cty:"load_balancer"`
+	LBPort       int32                                                 `cty:"lb_port"`
+	Attribute    []*terraformLoadBalancerSSLNegotiationPolicyAttribute `cty:"attribute"`
+}
+
+type terraformLoadBalancerSSLNegotiationPolicyAttribute struct {
+	Name  string `cty:"name"`
+	Value string `cty:"value"`
+}
+
+// renderTerraformSSLPolicies renders an aws_lb_ssl_negotiation_policy for each listener that sets a security policy.
+func (e *ClassicLoadBalancer) renderTerraformSSLPolicies(t *terraform.TerraformTarget, count *terraformWriter.Literal) error {
+	ports := make([]string, 0, len(e.Listeners))
+	for port, listener := range e.Listeners {
+		if listener.SSLPolicy != nil {
+			ports = append(ports, port)
+		}
+	}
+	sort.Strings(ports)
+
+	for _, port := range ports {
+		loadBalancerPort, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return fmt.Errorf("error parsing load balancer listener port: %q", port)
+		}
+		sslPolicy := *e.Listeners[port].SSLPolicy
+
+		tf := &terraformLoadBalancerSSLNegotiationPolicy{
+			Count:        count,
+			Name:         sslNegotiationPolicyName(sslPolicy),
+			LoadBalancer: e.TerraformLink(),
+			LBPort:       int32(loadBalancerPort),
+			Attribute: []*terraformLoadBalancerSSLNegotiationPolicyAttribute{
+				{
+					Name:  referenceSecurityPolicyAttribute,
+					Value: sslPolicy,
+				},
+			},
+		}
+		if err := t.RenderResource("aws_lb_ssl_negotiation_policy", e.terraformResourceName()+"-"+port, tf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"k8s.io/kops/cloudmock/aws/mockelb"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestClassicLoadBalancerSSLPolicy(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &mockelb.MockELB{}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	newELB := func(sslPolicy *string) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners: map[string]*ClassicLoadBalancerListener{
+				"443":  {InstancePort: 443, SSLCertificateID: "arn:cert", SSLPolicy: sslPolicy},
+				"8443": {InstancePort: 8443},
+			},
+			ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+				IdleTimeout: fi.PtrTo(int32(300)),
+			},
+			Tags: map[string]string{"Name": "api.example.com"},
+		}
+	}
+
+	created := newELB(fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01"))
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+
+	lb, err := findLoadBalancerByLoadBalancerName(ctx, cloud, "api-example-com")
+	if err != nil {
+		t.Fatalf("error finding ELB: %v", err)
+	}
+	policies := make(map[int32][]string)
+	for _, ld := range lb.ListenerDescriptions {
+		policies[ld.Listener.LoadBalancerPort] = ld.PolicyNames
+	}
+	if expected := map[int32][]string{443: {"kops-ELBSecurityPolicy-TLS-1-2-2017-01"}, 8443: nil}; !reflect.DeepEqual(policies, expected) {
+		t.Errorf("expected listener policies %v, got %v", expected, policies)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	// The security policy is read back, so an unchanged policy is not applied again
+	e := newELB(fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01"))
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if actual := fi.ValueOf(a.Listeners["443"].SSLPolicy); actual != "ELBSecurityPolicy-TLS-1-2-2017-01" {
+		t.Errorf("expected Find to report the security policy, got %q", actual)
+	}
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners != nil {
+		t.Errorf("expected no listener changes, got %v", changes.Listeners)
+	}
+
+	// A changed security policy is reported as a listener change
+	e = newELB(fi.PtrTo("ELBSecurityPolicy-TLS13-1-2-2021-06"))
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners == nil {
+		t.Errorf("expected a listener change")
+	}
+
+	// Listeners without a security policy ignore the policy ELB assigned to them
+	if _, err := m.CreateLoadBalancerPolicy(ctx, &elb.CreateLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String("api-example-com"),
+		PolicyName:       aws.String("ELBSecurityPolicy-2016-08"),
+		PolicyTypeName:   aws.String(sslNegotiationPolicyType),
+		PolicyAttributes: []elbtypes.PolicyAttribute{
+			{AttributeName: aws.String(referenceSecurityPolicyAttribute), AttributeValue: aws.String("ELBSecurityPolicy-2016-08")},
+		},
+	}); err != nil {
+		t.Fatalf("error creating policy: %v", err)
+	}
+	if _, err := m.SetLoadBalancerPoliciesOfListener(ctx, &elb.SetLoadBalancerPoliciesOfListenerInput{
+		LoadBalancerName: aws.String("api-example-com"),
+		LoadBalancerPort: 443,
+		PolicyNames:      []string{"ELBSecurityPolicy-2016-08"},
+	}); err != nil {
+		t.Fatalf("error setting listener policies: %v", err)
+	}
+	e = newELB(nil)
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	if a.Listeners["443"].SSLPolicy != nil {
+		t.Errorf("expected no security policy, got %q", fi.ValueOf(a.Listeners["443"].SSLPolicy))
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners != nil {
+		t.Errorf("expected no listener changes, got %v", changes.Listeners)
+	}
+}
+
+func TestClassicLoadBalancerSSLPolicyPreexistingSteadyState(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	m := &listenerRecordingMockELB{MockELB: &mockelb.MockELB{}}
+	cloud.MockELB = m
+	target := awsup.NewAWSAPITarget(cloud)
+
+	newELB := func(sslPolicy *string) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners: map[string]*ClassicLoadBalancerListener{
+				"443": {InstancePort: 443, SSLCertificateID: "arn:cert", SSLPolicy: sslPolicy},
+			},
+			ConnectionSettings: &ClassicLoadBalancerConnectionSettings{
+				IdleTimeout: fi.PtrTo(int32(300)),
+			},
+			Tags: map[string]string{"Name": "api.example.com"},
+		}
+	}
+
+	// The ELB was created without a security policy, and the policy was then set outside of kOps
+	created := newELB(nil)
+	if err := created.RenderAWS(target, nil, created, created); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+	if _, err := m.CreateLoadBalancerPolicy(ctx, &elb.CreateLoadBalancerPolicyInput{
+		LoadBalancerName: aws.String("api-example-com"),
+		PolicyName:       aws.String("tls-1-2"),
+		PolicyTypeName:   aws.String(sslNegotiationPolicyType),
+		PolicyAttributes: []elbtypes.PolicyAttribute{
+			{AttributeName: aws.String(referenceSecurityPolicyAttribute), AttributeValue: aws.String("ELBSecurityPolicy-TLS-1-2-2017-01")},
+		},
+	}); err != nil {
+		t.Fatalf("error creating policy: %v", err)
+	}
+	if _, err := m.SetLoadBalancerPoliciesOfListener(ctx, &elb.SetLoadBalancerPoliciesOfListenerInput{
+		LoadBalancerName: aws.String("api-example-com"),
+		LoadBalancerPort: 443,
+		PolicyNames:      []string{"tls-1-2"},
+	}); err != nil {
+		t.Fatalf("error setting listener policies: %v", err)
+	}
+
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	// The policy is compared by the security policy it references, not by its name
+	e := newELB(fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01"))
+	a, err := e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes := &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners != nil {
+		t.Errorf("expected the existing security policy to be in steady state, got listener changes %v", changes.Listeners)
+	}
+
+	// Changing the security policy applies it once, without recreating the listener
+	e = newELB(fi.PtrTo("ELBSecurityPolicy-TLS13-1-2-2021-06"))
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners == nil {
+		t.Fatalf("expected a listener change")
+	}
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error from RenderAWS: %v", err)
+	}
+	if len(m.calls) != 0 {
+		t.Errorf("expected the listener to be kept, got %q", m.calls)
+	}
+
+	a, err = e.Find(c)
+	if err != nil {
+		t.Fatalf("unexpected error from Find: %v", err)
+	}
+	changes = &ClassicLoadBalancer{}
+	fi.BuildChanges(a, e, changes)
+	if changes.Listeners != nil {
+		t.Errorf("expected the new security policy to be in steady state, got listener changes %v", changes.Listeners)
+	}
+}
+
+func TestClassicLoadBalancerTerraformSSLPolicy(t *testing.T) {
+	newELB := func(sslPolicy *string) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:             fi.PtrTo("api.example.com"),
+			LoadBalancerName: fi.PtrTo("api-example-com"),
+			Listeners: map[string]*ClassicLoadBalancerListener{
+				"443": {InstancePort: 443, SSLCertificateID: "arn:cert", SSLPolicy: sslPolicy},
+			},
+		}
+	}
+
+	doRenderTests(t, "RenderTerraform", []*renderTest{
+		{
+			Resource: newELB(nil),
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port      = 443
+    instance_protocol  = "SSL"
+    lb_port            = 443
+    lb_protocol        = "SSL"
+    ssl_certificate_id = "arn:cert"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: newELB(fi.PtrTo("ELBSecurityPolicy-TLS-1-2-2017-01")),
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_elb" "api-example-com" {
+  listener {
+    instance_port      = 443
+    instance_protocol  = "SSL"
+    lb_port            = 443
+    lb_protocol        = "SSL"
+    ssl_certificate_id = "arn:cert"
+  }
+  name = "api-example-com"
+  tags = {
+    "Name" = "api.example.com"
+  }
+}
+
+resource "aws_lb_ssl_negotiation_policy" "api-example-com-443" {
+  attribute {
+    name  = "Reference-Security-Policy"
+    value = "ELBSecurityPolicy-TLS-1-2-2017-01"
+  }
+  lb_port       = 443
+  load_balancer = aws_elb.api-example-com.id
+  name          = "kops-ELBSecurityPolicy-TLS-1-2-2017-01"
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	})
+}
@@ -207,9 +207,44 @@ func (s *DNSName) CheckChanges(a, e, changes *DNSName) error {
 			return fi.RequiredField("Zone")
 		}
 	}
+
+	for _, warning := range validateDNSTargetZone(e) {
+		klog.Warningf("%s", warning)
+	}
 	return nil
 }
 
+// validateDNSTargetZone returns warnings for records that would not route to their load balancer:
+// records named outside of their DNS zone, and alias targets in the DNS zone itself,
+// rather than in the canonical hosted zone of the load balancer.
+func validateDNSTargetZone(e *DNSName) []string {
+	var warnings []string
+	if e.Zone == nil {
+		return nil
+	}
+
+	resourceName := strings.TrimSuffix(fi.ValueOf(e.ResourceName), ".")
+	zoneName := strings.TrimSuffix(fi.ValueOf(e.Zone.DNSName), ".")
+	if resourceName != "" && zoneName != "" && resourceName != zoneName && !strings.HasSuffix(resourceName, "."+zoneName) {
+		warnings = append(warnings, fmt.Sprintf("DNS record %q is not in the DNS zone %q", resourceName, zoneName))
+	}
+
+	if e.TargetLoadBalancer != nil {
+		targetZoneID := fi.ValueOf(e.TargetLoadBalancer.getHostedZoneId())
+		zoneID := fi.ValueOf(e.Zone.ZoneID)
+		if targetZoneID != "" && targetZoneID == zoneID {
+			warnings = append(warnings, fmt.Sprintf("DNS record %q targets a load balancer in the DNS zone %q; alias records must use the canonical hosted zone of the load balancer", resourceName, zoneID))
+		}
+
+		targetDNSName := strings.TrimSuffix(fi.ValueOf(e.TargetLoadBalancer.getDNSName()), ".")
+		if targetDNSName != "" && zoneName != "" && strings.HasSuffix(targetDNSName, "."+zoneName) {
+			warnings = append(warnings, fmt.Sprintf("DNS record %q targets %q, which is in the DNS zone %q rather than a load balancer name", resourceName, targetDNSName, zoneName))
+		}
+	}
+
+	return warnings
+}
+
 func (_ *DNSName) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DNSName) error {
 	rrs := &route53types.ResourceRecordSet{
 		Name: e.ResourceName,
@@ -249,10 +284,11 @@ func (_ *DNSName) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DNSName) error
 }
 
 type terraformRoute53Record struct {
-	Name    *string  `cty:"name"`
-	Type    *string  `cty:"type"`
-	TTL     *string  `cty:"ttl"`
-	Records []string `cty:"records"`
+	Count   *terraformWriter.Literal `cty:"count"`
+	Name    *string                  `cty:"name"`
+	Type    *string                  `cty:"type"`
+	TTL     *string                  `cty:"ttl"`
+	Records []string                 `cty:"records"`
 
 	Alias  *terraformAlias          `cty:"alias"`
 	ZoneID *terraformWriter.Literal `cty:"zone_id"`
@@ -278,6 +314,10 @@ func (_ *DNSName) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *D
 			EvaluateTargetHealth: aws.Bool(false),
 			ZoneID:               e.TargetLoadBalancer.TerraformLink("zone_id"),
 		}
+		// An alias to an ELB that is not created would have no target
+		if clb, ok := e.TargetLoadBalancer.(*ClassicLoadBalancer); ok {
+			tf.Count = clb.terraformCount()
+		}
 	}
 
 	return t.RenderResource("aws_route53_record", *e.Name, tf)
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestValidateDNSTargetZone(t *testing.T) {
+	zone := &DNSZone{
+		Name:    fi.PtrTo("example.com"),
+		DNSName: fi.PtrTo("example.com"),
+		ZoneID:  fi.PtrTo("Z1CLUSTERZONE"),
+	}
+	elb := func(dnsName, hostedZoneID string) *ClassicLoadBalancer {
+		return &ClassicLoadBalancer{
+			Name:         fi.PtrTo("api.example.com"),
+			DNSName:      fi.PtrTo(dnsName),
+			HostedZoneId: fi.PtrTo(hostedZoneID),
+		}
+	}
+
+	grid := []struct {
+		name     string
+		record   string
+		target   DNSTarget
+		expected []string
+	}{
+		{
+			name:   "matching zone",
+			record: "api.cluster.example.com",
+			target: elb("api-example-com-123.us-east-1.elb.amazonaws.com", "Z35SXDOTRQ7X7K"),
+		},
+		{
+			name:   "record outside the zone",
+			record: "api.cluster.example.org",
+			target: elb("api-example-com-123.us-east-1.elb.amazonaws.com", "Z35SXDOTRQ7X7K"),
+			expected: []string{
+				`DNS record "api.cluster.example.org" is not in the DNS zone "example.com"`,
+			},
+		},
+		{
+			name:   "load balancer hosted zone is the DNS zone",
+			record: "api.cluster.example.com",
+			target: elb("api.internal.example.com", "Z1CLUSTERZONE"),
+			expected: []string{
+				`DNS record "api.cluster.example.com" targets a load balancer in the DNS zone "Z1CLUSTERZONE"; alias records must use the canonical hosted zone of the load balancer`,
+				`DNS record "api.cluster.example.com" targets "api.internal.example.com", which is in the DNS zone "example.com" rather than a load balancer name`,
+			},
+		},
+		{
+			name:   "load balancer not yet created",
+			record: "api.cluster.example.com.",
+			target: &ClassicLoadBalancer{Name: fi.PtrTo("api.example.com")},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			e := &DNSName{
+				Name:               fi.PtrTo(g.record),
+				ResourceName:       fi.PtrTo(g.record),
+				ResourceType:       fi.PtrTo("A"),
+				Zone:               zone,
+				TargetLoadBalancer: g.target,
+			}
+			actual := validateDNSTargetZone(e)
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("unexpected warnings, expected %q got %q", g.expected, actual)
+			}
+		})
+	}
+}
@@ -366,8 +366,12 @@ func (e *NetworkLoadBalancer) FindAddresses(c *fi.CloudupContext) ([]string, err
 		lb := awsup.FindLatestELBV2ByNameTag(allLoadBalancers, fi.ValueOf(e.Name))
 
 		if lb != nil {
-			if fi.ValueOf(lb.LoadBalancer.DNSName) != "" {
-				addresses = append(addresses, fi.ValueOf(lb.LoadBalancer.DNSName))
+			if dnsName := fi.ValueOf(lb.LoadBalancer.DNSName); dnsName != "" {
+				addresses = append(addresses, dnsName)
+				// IPv6 clients connect through the dualstack name, which resolves to both A and AAAA records
+				if isDualstackIpAddressType(lb.LoadBalancer.IpAddressType) {
+					addresses = append(addresses, "dualstack."+dnsName)
+				}
 			}
 
 			if cluster.UsesNoneDNS() {
@@ -389,6 +393,16 @@ func (e *NetworkLoadBalancer) FindAddresses(c *fi.CloudupContext) ([]string, err
 	return addresses, nil
 }
 
+// isDualstackIpAddressType returns true if the load balancer is reachable over IPv6.
+func isDualstackIpAddressType(ipAddressType elbv2types.IpAddressType) bool {
+	switch ipAddressType {
+	case elbv2types.IpAddressTypeDualstack, elbv2types.IpAddressTypeDualstackWithoutPublicIpv4:
+		return true
+	default:
+		return false
+	}
+}
+
 func (e *NetworkLoadBalancer) Run(c *fi.CloudupContext) error {
 	return fi.CloudupDefaultDeltaRunMethod(e, c)
 }
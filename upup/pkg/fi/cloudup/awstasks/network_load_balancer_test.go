@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"k8s.io/kops/cloudmock/aws/mockelbv2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestNetworkLoadBalancerFindAddresses(t *testing.T) {
+	ctx := context.TODO()
+
+	grid := []struct {
+		name          string
+		ipAddressType elbv2types.IpAddressType
+		expected      []string
+	}{
+		{
+			name:          "ipv4",
+			ipAddressType: elbv2types.IpAddressTypeIpv4,
+			expected:      []string{"api-example-com.amazonaws.com"},
+		},
+		{
+			name:          "dualstack",
+			ipAddressType: elbv2types.IpAddressTypeDualstack,
+			expected:      []string{"api-example-com.amazonaws.com", "dualstack.api-example-com.amazonaws.com"},
+		},
+		{
+			name:          "dualstack without public ipv4",
+			ipAddressType: elbv2types.IpAddressTypeDualstackWithoutPublicIpv4,
+			expected:      []string{"api-example-com.amazonaws.com", "dualstack.api-example-com.amazonaws.com"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+			cloud.MockELBV2 = &mockelbv2.MockELBV2{}
+
+			if _, err := cloud.MockELBV2.CreateLoadBalancer(ctx, &elbv2.CreateLoadBalancerInput{
+				Name:          aws.String("api-example-com"),
+				Type:          elbv2types.LoadBalancerTypeEnumNetwork,
+				IpAddressType: g.ipAddressType,
+				Tags: []elbv2types.Tag{
+					{Key: aws.String("Name"), Value: aws.String("api.example.com")},
+				},
+			}); err != nil {
+				t.Fatalf("error creating NLB: %v", err)
+			}
+
+			c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, awsup.NewAWSAPITarget(cloud), &kops.Cluster{}, cloud, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("error building context: %v", err)
+			}
+
+			e := &NetworkLoadBalancer{Name: fi.PtrTo("api.example.com")}
+			addresses, err := e.FindAddresses(c)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(addresses, g.expected) {
+				t.Errorf("expected addresses %v, got %v", g.expected, addresses)
+			}
+		})
+	}
+}
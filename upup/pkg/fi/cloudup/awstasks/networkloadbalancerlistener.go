@@ -215,7 +215,7 @@ type terraformNetworkLoadBalancerListener struct {
 	LoadBalancer   *terraformWriter.Literal                     `cty:"load_balancer_arn"`
 	Port           int64                                        `cty:"port"`
 	Protocol       elbv2types.ProtocolEnum                      `cty:"protocol"`
-	CertificateARN *string                                      `cty:"certificate_arn"`
+	CertificateARN *terraformWriter.Literal                     `cty:"certificate_arn"`
 	SSLPolicy      *string                                      `cty:"ssl_policy"`
 	DefaultAction  []terraformNetworkLoadBalancerListenerAction `cty:"default_action"`
 }
@@ -240,7 +240,11 @@ func (_ *NetworkLoadBalancerListener) RenderTerraform(t *terraform.TerraformTarg
 		},
 	}
 	if e.SSLCertificateID != "" {
-		listenerTF.CertificateARN = &e.SSLCertificateID
+		certificate, err := renderTerraformListenerCertificate(t, e.SSLCertificateID)
+		if err != nil {
+			return err
+		}
+		listenerTF.CertificateARN = certificate
 		listenerTF.Protocol = elbv2types.ProtocolEnumTls
 		if e.SSLPolicy != "" {
 			listenerTF.SSLPolicy = &e.SSLPolicy
@@ -26,11 +26,14 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 type renderTest struct {
 	Resource interface{}
 	Expected string
+	// ConfigureTerraform optionally sets options on the terraform target before rendering.
+	ConfigureTerraform func(*terraform.TerraformTarget)
 }
 
 func doRenderTests(t *testing.T, method string, cases []*renderTest) {
@@ -44,8 +47,15 @@ func doRenderTests(t *testing.T, method string, cases []*renderTest) {
 
 		switch method {
 		case "RenderTerraform":
-			target = terraform.NewTerraformTarget(cloud, "test", outdir, nil)
+			tfTarget := terraform.NewTerraformTarget(cloud, "test", outdir, nil)
+			if c.ConfigureTerraform != nil {
+				c.ConfigureTerraform(tfTarget)
+			}
+			target = tfTarget
 			filename = "kubernetes.tf"
+			if tfTarget.Syntax == terraformWriter.SyntaxJSON {
+				filename = "kubernetes.tf.json"
+			}
 		default:
 			t.Errorf("unknown render method: %s", method)
 			t.FailNow()
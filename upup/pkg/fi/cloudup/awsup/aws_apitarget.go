@@ -117,58 +117,6 @@ func (t *AWSAPITarget) RemoveELBV2Tags(ResourceArn string, expected map[string]s
 	return nil
 }
 
-func (t *AWSAPITarget) AddELBTags(loadBalancerName string, expected map[string]string) error {
-	actual, err := t.Cloud.GetELBTags(loadBalancerName)
-	if err != nil {
-		return fmt.Errorf("unexpected error fetching tags for resource: %v", err)
-	}
-
-	missing := map[string]string{}
-	for k, v := range expected {
-		actualValue, found := actual[k]
-		if found && actualValue == v {
-			continue
-		}
-		missing[k] = v
-	}
-
-	if len(missing) != 0 {
-		klog.V(4).Infof("adding tags to %q: %v", loadBalancerName, missing)
-		err := t.Cloud.CreateELBTags(loadBalancerName, missing)
-		if err != nil {
-			return fmt.Errorf("error adding tags to ELB %q: %v", loadBalancerName, err)
-		}
-	}
-
-	return nil
-}
-
-func (t *AWSAPITarget) RemoveELBTags(loadBalancerName string, expected map[string]string) error {
-	actual, err := t.Cloud.GetELBTags(loadBalancerName)
-	if err != nil {
-		return fmt.Errorf("unexpected error fetching tags for resource: %v", err)
-	}
-
-	extra := map[string]string{}
-	for k, v := range actual {
-		expectedValue, found := expected[k]
-		if found && expectedValue == v {
-			continue
-		}
-		extra[k] = v
-	}
-
-	if len(extra) != 0 {
-		klog.V(4).Infof("removing tags from %q: %v", loadBalancerName, extra)
-		err := t.Cloud.RemoveELBTags(loadBalancerName, extra)
-		if err != nil {
-			return fmt.Errorf("error removing tags from ELB %q: %v", loadBalancerName, err)
-		}
-	}
-
-	return nil
-}
-
 func (t *AWSAPITarget) WaitForInstanceRunning(instanceID string) error {
 	attempt := 0
 	for {
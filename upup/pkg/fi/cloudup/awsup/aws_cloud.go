@@ -156,6 +156,8 @@ type AWSCloud interface {
 	RemoveELBTags(loadBalancerName string, tags map[string]string) error
 	RemoveELBV2Tags(ResourceArn string, tags map[string]string) error
 	FindELBByNameTag(findNameTag string) (*elbtypes.LoadBalancerDescription, error)
+	// FindELBAndTagsByNameTag is like FindELBByNameTag, but also returns the tags of the ELB, which it has already described
+	FindELBAndTagsByNameTag(findNameTag string) (*elbtypes.LoadBalancerDescription, []elbtypes.Tag, error)
 	DescribeELBTags(loadBalancerNames []string) (map[string][]elbtypes.Tag, error)
 	// TODO: Remove, replace with awsup.ListELBV2LoadBalancers
 	DescribeELBV2Tags(loadBalancerNames []string) (map[string][]elbv2types.Tag, error)
@@ -288,6 +290,13 @@ func loadAWSConfig(ctx context.Context, region string) (aws.Config, error) {
 	return awsconfig.LoadDefaultConfig(ctx, loadOptions...)
 }
 
+// withELBRetryer raises the attempts of the Classic ELB client to ClientMaxRetries.
+// ELB throttles ModifyLoadBalancerAttributes and ConfigureHealthCheck during large rollouts;
+// the adaptive retryer already backs off with jitter on throttling errors, but gives up after 3 attempts.
+func withELBRetryer(o *elb.Options) {
+	o.Retryer = retry.AddWithMaxAttempts(o.Retryer, ClientMaxRetries)
+}
+
 func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
 	ctx := context.TODO()
 	raw := getCloudInstancesFromRegion(region)
@@ -309,7 +318,7 @@ func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
 
 		c.ec2 = ec2.NewFromConfig(cfg)
 		c.iam = iam.NewFromConfig(cfg)
-		c.elb = elb.NewFromConfig(cfg)
+		c.elb = elb.NewFromConfig(cfg, withELBRetryer)
 		c.elbv2 = elbv2.NewFromConfig(cfg)
 		c.sts = sts.NewFromConfig(cfg)
 		c.autoscaling = autoscaling.NewFromConfig(cfg)
@@ -1651,7 +1660,16 @@ func (c *awsCloudImplementation) FindELBByNameTag(findNameTag string) (*elbtypes
 	return findELBByNameTag(c, findNameTag)
 }
 
+func (c *awsCloudImplementation) FindELBAndTagsByNameTag(findNameTag string) (*elbtypes.LoadBalancerDescription, []elbtypes.Tag, error) {
+	return findELBAndTagsByNameTag(c, findNameTag)
+}
+
 func findELBByNameTag(c AWSCloud, findNameTag string) (*elbtypes.LoadBalancerDescription, error) {
+	lb, _, err := findELBAndTagsByNameTag(c, findNameTag)
+	return lb, err
+}
+
+func findELBAndTagsByNameTag(c AWSCloud, findNameTag string) (*elbtypes.LoadBalancerDescription, []elbtypes.Tag, error) {
 	ctx := context.TODO()
 	// TODO: Any way around this?
 	klog.V(2).Infof("Listing all ELBs for findLoadBalancerByNameTag")
@@ -1661,12 +1679,13 @@ func findELBByNameTag(c AWSCloud, findNameTag string) (*elbtypes.LoadBalancerDes
 	request.PageSize = aws.Int32(20)
 
 	var found []elbtypes.LoadBalancerDescription
+	var foundTags [][]elbtypes.Tag
 
 	paginator := elb.NewDescribeLoadBalancersPaginator(c.ELB(), request)
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("error describing LoadBalancers: %w", err)
+			return nil, nil, fmt.Errorf("error describing LoadBalancers: %w", err)
 		}
 		if len(page.LoadBalancerDescriptions) == 0 {
 			continue
@@ -1684,7 +1703,7 @@ func findELBByNameTag(c AWSCloud, findNameTag string) (*elbtypes.LoadBalancerDes
 
 		tagMap, err := c.DescribeELBTags(names)
 		if err != nil {
-			return nil, fmt.Errorf("error describing LoadBalancer tags: %w", err)
+			return nil, nil, fmt.Errorf("error describing LoadBalancer tags: %w", err)
 		}
 
 		for loadBalancerName, tags := range tagMap {
@@ -1695,18 +1714,19 @@ func findELBByNameTag(c AWSCloud, findNameTag string) (*elbtypes.LoadBalancerDes
 
 			elb := nameToELB[loadBalancerName]
 			found = append(found, elb)
+			foundTags = append(foundTags, tags)
 		}
 	}
 
 	if len(found) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	if len(found) != 1 {
-		return nil, fmt.Errorf("Found multiple ELBs with Name %q", findNameTag)
+		return nil, nil, fmt.Errorf("Found multiple ELBs with Name %q", findNameTag)
 	}
 
-	return &found[0], nil
+	return &found[0], foundTags[0], nil
 }
 
 func (c *awsCloudImplementation) DescribeELBTags(loadBalancerNames []string) (map[string][]elbtypes.Tag, error) {
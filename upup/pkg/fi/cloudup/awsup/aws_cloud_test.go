@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+)
+
+const (
+	elbThrottlingResponse           = `<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error><RequestId>1</RequestId></ErrorResponse>`
+	elbConfigureHealthCheckResponse = `<ConfigureHealthCheckResponse><ConfigureHealthCheckResult></ConfigureHealthCheckResult><ResponseMetadata><RequestId>1</RequestId></ResponseMetadata></ConfigureHealthCheckResponse>`
+)
+
+// throttlingHTTPClient throttles the first requests it receives, then succeeds.
+type throttlingHTTPClient struct {
+	throttled int
+	requests  int
+}
+
+func (c *throttlingHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	c.requests++
+	status, body := http.StatusOK, elbConfigureHealthCheckResponse
+	if c.requests <= c.throttled {
+		status, body = http.StatusBadRequest, elbThrottlingResponse
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    request,
+	}, nil
+}
+
+func TestELBRetriesThrottling(t *testing.T) {
+	grid := []struct {
+		name             string
+		throttled        int
+		expectedRequests int
+		expectError      bool
+	}{
+		{
+			name:             "throttled once",
+			throttled:        1,
+			expectedRequests: 2,
+		},
+		{
+			name:             "always throttled",
+			throttled:        ClientMaxRetries + 1,
+			expectedRequests: ClientMaxRetries,
+			expectError:      true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			httpClient := &throttlingHTTPClient{throttled: g.throttled}
+			client := elb.New(elb.Options{
+				Region:      "us-east-1",
+				Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+				HTTPClient:  httpClient,
+				Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+					o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+						return 0, nil
+					})
+				}),
+			}, withELBRetryer)
+
+			_, err := client.ConfigureHealthCheck(context.TODO(), &elb.ConfigureHealthCheckInput{
+				LoadBalancerName: aws.String("api-example-com"),
+				HealthCheck: &elbtypes.HealthCheck{
+					Target:             aws.String("SSL:443"),
+					HealthyThreshold:   aws.Int32(2),
+					UnhealthyThreshold: aws.Int32(2),
+					Interval:           aws.Int32(10),
+					Timeout:            aws.Int32(5),
+				},
+			})
+			if g.expectError && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if !g.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if httpClient.requests != g.expectedRequests {
+				t.Errorf("expected %d requests, got %d", g.expectedRequests, httpClient.requests)
+			}
+		})
+	}
+}
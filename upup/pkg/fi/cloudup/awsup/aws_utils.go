@@ -18,6 +18,8 @@ package awsup
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base32"
 	"errors"
 	"fmt"
 	"os"
@@ -226,6 +228,37 @@ func GetResourceName32(cluster string, prefix string) string {
 	return truncate.TruncateString(s, opt)
 }
 
+// GetLoadBalancerName32 will calculate the name of a classic load balancer using the supplied naming strategy.
+// Will never return a string longer than 32 chars
+func GetLoadBalancerName32(strategy kops.LoadBalancerNamingStrategy, cluster string, prefix string) string {
+	clusterPart := strings.Replace(cluster, ".", "-", -1)
+
+	switch strategy {
+	case kops.LoadBalancerNamingStrategyHashSuffix:
+		// A sha256-based hash is far less likely to collide than the default 32 bit hash
+		s := prefix + "-" + clusterPart
+		sum := sha256.Sum256([]byte(s))
+		hash := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(sum[:]))[:12]
+		return trimName(s, 32-len(hash)-1) + "-" + hash
+
+	case kops.LoadBalancerNamingStrategyClusterPrefix:
+		// The resource prefix is never truncated, so names for the same cluster stay recognizable
+		hash := truncate.HashString(clusterPart+"-"+prefix, 6)
+		return trimName(clusterPart, 32-len(prefix)-len(hash)-2) + "-" + prefix + "-" + hash
+
+	default:
+		return GetResourceName32(cluster, prefix)
+	}
+}
+
+// trimName truncates s to at most maxLength characters, dropping any hyphens left at the end by truncation.
+func trimName(s string, maxLength int) string {
+	if len(s) > maxLength {
+		s = s[:maxLength]
+	}
+	return strings.TrimRight(s, "-")
+}
+
 // NameForExternalTargetGroup will attempt to calculate a meaningful name for a target group given an ARN.
 func NameForExternalTargetGroup(targetGroupARN string) (string, error) {
 	parsed, err := arn.Parse(targetGroupARN)
@@ -19,6 +19,8 @@ package awsup
 import (
 	"context"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -141,3 +143,40 @@ func Test_GetResourceName32(t *testing.T) {
 		}
 	}
 }
+
+func Test_GetLoadBalancerName32(t *testing.T) {
+	validName := regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+
+	clusterNames := []string{
+		"mycluster.example.com",
+		"this.is.a.very.long.cluster.example.com",
+		"this.is.a.very.long.cluster.example.org",
+		"this.is.a.very.long.cluster-1.example.com",
+		"this.is.a.very.long.cluster-2.example.com",
+	}
+	for _, strategy := range []kops.LoadBalancerNamingStrategy{"", kops.LoadBalancerNamingStrategyHashSuffix, kops.LoadBalancerNamingStrategyClusterPrefix} {
+		seen := make(map[string]string)
+		for _, clusterName := range clusterNames {
+			actual := GetLoadBalancerName32(strategy, clusterName, "api")
+			if len(actual) > 32 {
+				t.Errorf("strategy %q: name %q for %q is longer than 32 characters", strategy, actual, clusterName)
+			}
+			if !validName.MatchString(actual) {
+				t.Errorf("strategy %q: name %q for %q is not a valid ELB name", strategy, actual, clusterName)
+			}
+			if other, found := seen[actual]; found {
+				t.Errorf("strategy %q: name %q is shared by %q and %q", strategy, actual, other, clusterName)
+			}
+			seen[actual] = clusterName
+		}
+	}
+
+	if actual, expected := GetLoadBalancerName32("", "this.is.a.very.long.cluster.example.com", "api"), GetResourceName32("this.is.a.very.long.cluster.example.com", "api"); actual != expected {
+		t.Errorf("default strategy changed the existing name, expected %q, got %q", expected, actual)
+	}
+
+	actual := GetLoadBalancerName32(kops.LoadBalancerNamingStrategyClusterPrefix, "this.is.a.very.long.cluster.example.com", "bastion")
+	if !strings.HasPrefix(actual, "this-is-a-") || !strings.Contains(actual, "-bastion-") {
+		t.Errorf("unexpected cluster-prefix name %q", actual)
+	}
+}
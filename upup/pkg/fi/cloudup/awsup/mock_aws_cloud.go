@@ -198,6 +198,10 @@ func (c *MockAWSCloud) FindELBByNameTag(findNameTag string) (*elbtypes.LoadBalan
 	return findELBByNameTag(c, findNameTag)
 }
 
+func (c *MockAWSCloud) FindELBAndTagsByNameTag(findNameTag string) (*elbtypes.LoadBalancerDescription, []elbtypes.Tag, error) {
+	return findELBAndTagsByNameTag(c, findNameTag)
+}
+
 func (c *MockAWSCloud) DescribeELBTags(loadBalancerNames []string) (map[string][]elbtypes.Tag, error) {
 	return describeELBTags(c, loadBalancerNames)
 }
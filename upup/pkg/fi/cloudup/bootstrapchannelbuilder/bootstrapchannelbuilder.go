@@ -538,7 +538,9 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext)
 		}
 
 		if b.UseServiceAccountExternalPermissions() {
-			serviceAccountRoles = append(serviceAccountRoles, &clusterautoscaler.ServiceAccount{})
+			serviceAccountRoles = append(serviceAccountRoles, &clusterautoscaler.ServiceAccount{
+				Namespace: fi.ValueOf(b.Cluster.Spec.ClusterAutoscaler.Namespace),
+			})
 		}
 
 	}
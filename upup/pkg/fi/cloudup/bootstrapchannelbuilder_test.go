@@ -20,13 +20,17 @@ import (
 	"context"
 	"os"
 	"path"
+	"slices"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/client/simple/vfsclientset"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/pkg/templates"
@@ -55,6 +59,14 @@ func TestBootstrapChannelBuilder_BuildTasks(t *testing.T) {
 	runChannelBuilderTest(t, "metrics-server/insecure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "metrics-server/secure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "coredns", []string{"coredns.addons.k8s.io-k8s-1.12"})
+	runChannelBuilderTest(t, "cluster-autoscaler/balancing-labels", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	runChannelBuilderTest(t, "cluster-autoscaler/status-configmap", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	runChannelBuilderTest(t, "cluster-autoscaler/priority-namespace", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	runChannelBuilderTest(t, "cluster-autoscaler/probes", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	runChannelBuilderTest(t, "cluster-autoscaler/pod-annotations", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	runChannelBuilderTest(t, "cluster-autoscaler/termination-grace-period", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	runChannelBuilderTest(t, "cluster-autoscaler/virtual-nodes", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	runChannelBuilderTest(t, "cluster-autoscaler/k8s-1.26", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
 }
 
 func TestBootstrapChannelBuilder_ServiceAccountIAM(t *testing.T) {
@@ -80,7 +92,135 @@ func TestBootstrapChannelBuilder_AWSCloudController(t *testing.T) {
 	runChannelBuilderTest(t, "awscloudcontroller", []string{"aws-cloud-controller.addons.k8s.io-k8s-1.18"})
 }
 
-func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
+func TestBootstrapChannelBuilder_ClusterAutoscalerRegion(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.SetupMockAWS()
+
+	// The autoscaler must not depend on the instance metadata service to find its region,
+	// as it may be unreachable from pods on nodes that require IMDSv2 with a hop limit of 1
+	manifests := runChannelBuilderTest(t, "cluster-autoscaler/balancing-labels", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(manifests["cluster-autoscaler.addons.k8s.io-k8s-1.15"]))
+	if err != nil {
+		t.Fatalf("error parsing cluster-autoscaler manifest: %v", err)
+	}
+
+	var region *corev1.EnvVar
+	for _, object := range objects {
+		if object.Kind() != "Deployment" || object.GetName() != "cluster-autoscaler" {
+			continue
+		}
+		var spec appsv1.DeploymentSpec
+		if err := object.Reparse(&spec, "spec"); err != nil {
+			t.Fatalf("error parsing cluster-autoscaler deployment: %v", err)
+		}
+		for _, container := range spec.Template.Spec.Containers {
+			for i := range container.Env {
+				if container.Env[i].Name == "AWS_REGION" {
+					region = &container.Env[i]
+				}
+			}
+		}
+	}
+	if region == nil {
+		t.Fatalf("cluster-autoscaler deployment does not set AWS_REGION")
+	}
+	if region.Value != "us-east-1" {
+		t.Errorf("expected AWS_REGION to be the cluster region %q, got %q", "us-east-1", region.Value)
+	}
+}
+
+func TestBootstrapChannelBuilder_ClusterAutoscalerGovCloud(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.SetupMockAWS()
+
+	// Without the static instance list, the autoscaler looks up instance types from EC2,
+	// so it is given the endpoint of the GovCloud partition through its cloud config
+	manifests := runChannelBuilderTestInRegion(t, "cluster-autoscaler/govcloud", "us-gov-west-1", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(manifests["cluster-autoscaler.addons.k8s.io-k8s-1.15"]))
+	if err != nil {
+		t.Fatalf("error parsing cluster-autoscaler manifest: %v", err)
+	}
+
+	var cloudConfig string
+	var command []string
+	for _, object := range objects {
+		switch {
+		case object.Kind() == "ConfigMap" && object.GetName() == "cluster-autoscaler-cloud-config":
+			var data map[string]string
+			if err := object.Reparse(&data, "data"); err != nil {
+				t.Fatalf("error parsing cluster-autoscaler cloud config: %v", err)
+			}
+			cloudConfig = data["cloud-config"]
+		case object.Kind() == "Deployment" && object.GetName() == "cluster-autoscaler":
+			var spec appsv1.DeploymentSpec
+			if err := object.Reparse(&spec, "spec"); err != nil {
+				t.Fatalf("error parsing cluster-autoscaler deployment: %v", err)
+			}
+			for _, container := range spec.Template.Spec.Containers {
+				command = append(command, container.Command...)
+			}
+		}
+	}
+
+	expectedCloudConfig := "[ServiceOverride \"1\"]\nService = ec2\nRegion = us-gov-west-1\nURL = https://ec2.us-gov-west-1.amazonaws.com\nSigningRegion = us-gov-west-1"
+	if cloudConfig != expectedCloudConfig {
+		t.Errorf("expected cluster-autoscaler cloud config %q, got %q", expectedCloudConfig, cloudConfig)
+	}
+	if !slices.Contains(command, "--cloud-config=/etc/cluster-autoscaler/cloud-config") {
+		t.Errorf("expected cluster-autoscaler command to use the cloud config, got %v", command)
+	}
+}
+
+func TestBootstrapChannelBuilder_ClusterAutoscalerClusterName(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.SetupMockAWS()
+
+	manifests := runChannelBuilderTest(t, "cluster-autoscaler/balancing-labels", []string{"cluster-autoscaler.addons.k8s.io-k8s-1.15"})
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(manifests["cluster-autoscaler.addons.k8s.io-k8s-1.15"]))
+	if err != nil {
+		t.Fatalf("error parsing cluster-autoscaler manifest: %v", err)
+	}
+
+	var command []string
+	for _, object := range objects {
+		if object.Kind() != "Deployment" || object.GetName() != "cluster-autoscaler" {
+			continue
+		}
+		var spec appsv1.DeploymentSpec
+		if err := object.Reparse(&spec, "spec"); err != nil {
+			t.Fatalf("error parsing cluster-autoscaler deployment: %v", err)
+		}
+		for _, container := range spec.Template.Spec.Containers {
+			command = append(command, container.Command...)
+		}
+	}
+
+	expected := "--cluster-name=minimal.example.com"
+	found := false
+	for _, arg := range command {
+		if arg == expected {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected cluster-autoscaler command to contain %q, got %v", expected, command)
+	}
+}
+
+// runChannelBuilderTest builds the bootstrap channel for the cluster in the key's test directory,
+// compares the manifests against the expected files and returns the addon manifests by name.
+func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) map[string]string {
+	return runChannelBuilderTestInRegion(t, key, "us-east-1", addonManifests)
+}
+
+// runChannelBuilderTestInRegion is runChannelBuilderTest for a cluster in the given region.
+func runChannelBuilderTestInRegion(t *testing.T, key string, region string, addonManifests []string) map[string]string {
 	ctx := context.TODO()
 
 	basedir := path.Join("tests/bootstrapchannelbuilder/", key)
@@ -135,7 +275,7 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 			AWSAccountID: "123456789012",
 			AWSPartition: "aws-test",
 		},
-		Region: "us-east-1",
+		Region: region,
 		InstanceGroups: []*kopsapi.InstanceGroup{
 			{
 				Spec: kopsapi.InstanceGroupSpec{
@@ -157,7 +297,7 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 		KopsModelContext: kopsModel,
 		cloud:            cloud,
 	}
-	tf.AddTo(templates.TemplateFunctions, secretStore)
+	tf.AddTo(ctx, templates.TemplateFunctions, secretStore)
 
 	bcb := bootstrapchannelbuilder.NewBootstrapChannelBuilder(
 		&kopsModel,
@@ -192,6 +332,7 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 		golden.AssertMatchesFile(t, actualManifest, expectedManifestPath)
 	}
 
+	manifests := make(map[string]string)
 	for _, k := range addonManifests {
 		name := cluster.ObjectMeta.Name + "-addons-" + k
 		manifestTask := context.Tasks["ManagedFile/"+name]
@@ -210,5 +351,9 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 
 		expectedManifestPath := path.Join(basedir, k+".yaml")
 		golden.AssertMatchesFile(t, actualManifest, expectedManifestPath)
+
+		manifests[k] = actualManifest
 	}
+
+	return manifests
 }
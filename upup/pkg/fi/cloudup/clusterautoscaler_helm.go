@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// ClusterAutoscalerHelmValues returns values for the upstream cluster-autoscaler Helm chart that configure
+// the autoscaler as kOps would, from the completed cluster autoscaler options of the cluster.
+// This allows the autoscaler to be managed with Helm instead of as a kOps addon.
+func (tf *TemplateFunctions) ClusterAutoscalerHelmValues() (map[string]interface{}, error) {
+	cas := tf.Cluster.Spec.ClusterAutoscaler
+	if cas == nil {
+		return nil, fmt.Errorf("cluster autoscaler is not configured")
+	}
+
+	values := map[string]interface{}{
+		"cloudProvider": string(tf.Cluster.Spec.GetCloudProvider()),
+	}
+	if tf.Cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		values["awsRegion"] = tf.Region
+	}
+
+	image := fi.ValueOf(cas.Image)
+	if image != "" {
+		repository, tag, err := splitClusterAutoscalerImage(image)
+		if err != nil {
+			return nil, err
+		}
+		values["image"] = map[string]interface{}{
+			"repository": repository,
+			"tag":        tag,
+		}
+	}
+
+	requests := map[string]interface{}{}
+	if cas.CPURequest != nil {
+		requests["cpu"] = cas.CPURequest.String()
+	}
+	if cas.MemoryRequest != nil {
+		requests["memory"] = cas.MemoryRequest.String()
+	}
+	if len(requests) != 0 {
+		values["resources"] = map[string]interface{}{"requests": requests}
+	}
+
+	if annotations := clusterAutoscalerPodAnnotations(cas.PodAnnotations); len(annotations) != 0 {
+		values["podAnnotations"] = annotations
+	}
+
+	nodeGroups := tf.GetClusterAutoscalerNodeGroups()
+	names := make([]string, 0, len(nodeGroups))
+	for name := range nodeGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var autoscalingGroups []interface{}
+	for _, name := range names {
+		nodeGroup := nodeGroups[name]
+		autoscalingGroups = append(autoscalingGroups, map[string]interface{}{
+			"name":    nodeGroup.Other,
+			"minSize": nodeGroup.MinSize,
+			"maxSize": nodeGroup.MaxSize,
+		})
+	}
+	if len(autoscalingGroups) != 0 {
+		values["autoscalingGroups"] = autoscalingGroups
+	}
+	if tf.ClusterAutoscalerNodeGroupAutoDiscovery() != "" {
+		values["autoDiscovery"] = map[string]interface{}{
+			"clusterName": tf.Cluster.ObjectMeta.Name,
+		}
+	}
+
+	extraArgs := map[string]interface{}{
+		"cluster-name":                     tf.Cluster.ObjectMeta.Name,
+		"balance-similar-node-groups":      fi.ValueOf(cas.BalanceSimilarNodeGroups),
+		"ignore-daemonsets-utilization":    fi.ValueOf(cas.IgnoreDaemonSetsUtilization),
+		"scale-down-utilization-threshold": fi.ValueOf(cas.ScaleDownUtilizationThreshold),
+		"skip-nodes-with-local-storage":    fi.ValueOf(cas.SkipNodesWithLocalStorage),
+		"skip-nodes-with-system-pods":      fi.ValueOf(cas.SkipNodesWithSystemPods),
+		"scale-down-delay-after-add":       fi.ValueOf(cas.ScaleDownDelayAfterAdd),
+		"scale-down-unneeded-time":         fi.ValueOf(cas.ScaleDownUnneededTime),
+		"scale-down-unready-enabled":       cas.ScaleDownUnreadyEnabled == nil || *cas.ScaleDownUnreadyEnabled,
+		"scale-down-unready-time":          fi.ValueOf(cas.ScaleDownUnreadyTime),
+		"new-pod-scale-up-delay":           fi.ValueOf(cas.NewPodScaleUpDelay),
+		"max-node-provision-time":          cas.MaxNodeProvisionTime,
+		"cordon-node-before-terminating":   cas.CordonNodeBeforeTerminating == nil || *cas.CordonNodeBeforeTerminating,
+		"write-status-configmap":           fi.ValueOf(cas.WriteStatusConfigMap),
+		"status-config-map-name":           fi.ValueOf(cas.StatusConfigMapName),
+	}
+	if cas.Expander != "" {
+		extraArgs["expander"] = cas.Expander
+	}
+	if len(cas.BalancingLabels) != 0 {
+		extraArgs["balancing-label"] = cas.BalancingLabels
+	}
+	if featureGates := clusterAutoscalerFeatureGates(cas.FeatureGates); featureGates != "" {
+		extraArgs["feature-gates"] = featureGates
+	}
+	if tf.Cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		extraArgs["aws-use-static-instance-list"] = fi.ValueOf(cas.AWSUseStaticInstanceList)
+	}
+	if tf.IsKubernetesGTE("1.27.0") {
+		extraArgs["skip-nodes-with-custom-controller-pods"] = fi.ValueOf(cas.SkipNodesWithCustomControllerPods)
+	}
+	values["extraArgs"] = extraArgs
+
+	return values, nil
+}
+
+// splitClusterAutoscalerImage splits an image into the repository and tag values of the Helm chart.
+// A digest is kept with the tag, as the chart joins them with a colon.
+func splitClusterAutoscalerImage(image string) (string, string, error) {
+	name, digest, _ := strings.Cut(image, "@")
+	i := strings.LastIndex(name, ":")
+	if i < 0 || i < strings.LastIndex(name, "/") {
+		return "", "", fmt.Errorf("cluster autoscaler image %q has no tag", image)
+	}
+	repository, tag := name[:i], name[i+1:]
+	if digest != "" {
+		tag += "@" + digest
+	}
+	return repository, tag, nil
+}
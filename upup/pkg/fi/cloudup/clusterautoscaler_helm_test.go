@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestClusterAutoscalerHelmValues(t *testing.T) {
+	cpu := resource.MustParse("100m")
+	memory := resource.MustParse("300Mi")
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			CloudProvider: kops.CloudProviderSpec{
+				AWS: &kops.AWSSpec{},
+			},
+			KubernetesVersion: "1.30.0",
+			ClusterAutoscaler: &kops.ClusterAutoscalerConfig{
+				Enabled:                           fi.PtrTo(true),
+				Expander:                          "least-waste",
+				BalanceSimilarNodeGroups:          fi.PtrTo(true),
+				BalancingLabels:                   []string{"topology.kubernetes.io/zone"},
+				AWSUseStaticInstanceList:          fi.PtrTo(false),
+				IgnoreDaemonSetsUtilization:       fi.PtrTo(false),
+				ScaleDownUtilizationThreshold:     fi.PtrTo("0.5"),
+				SkipNodesWithCustomControllerPods: fi.PtrTo(true),
+				SkipNodesWithLocalStorage:         fi.PtrTo(true),
+				SkipNodesWithSystemPods:           fi.PtrTo(true),
+				NewPodScaleUpDelay:                fi.PtrTo("0s"),
+				ScaleDownDelayAfterAdd:            fi.PtrTo("10m0s"),
+				ScaleDownUnneededTime:             fi.PtrTo("10m0s"),
+				ScaleDownUnreadyTime:              fi.PtrTo("20m0s"),
+				WriteStatusConfigMap:              fi.PtrTo(true),
+				StatusConfigMapName:               fi.PtrTo("cluster-autoscaler-status"),
+				Image:                             fi.PtrTo("registry.k8s.io/autoscaling/cluster-autoscaler:v1.30.0"),
+				CPURequest:                        &cpu,
+				MemoryRequest:                     &memory,
+				FeatureGates:                      map[string]bool{"ExampleFeature": true},
+				MaxNodeProvisionTime:              "15m0s",
+			},
+		},
+	}
+	nodes := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+		Spec: kops.InstanceGroupSpec{
+			Role:    kops.InstanceGroupRoleNode,
+			MinSize: fi.PtrTo(int32(1)),
+			MaxSize: fi.PtrTo(int32(3)),
+		},
+	}
+	fixed := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "fixed"},
+		Spec: kops.InstanceGroupSpec{
+			Role:      kops.InstanceGroupRoleNode,
+			MinSize:   fi.PtrTo(int32(2)),
+			MaxSize:   fi.PtrTo(int32(2)),
+			Autoscale: fi.PtrTo(false),
+		},
+	}
+
+	tf := &TemplateFunctions{}
+	tf.Cluster = cluster
+	tf.InstanceGroups = []*kops.InstanceGroup{nodes, fixed}
+	tf.Region = "us-east-1"
+
+	values, err := tf.ClusterAutoscalerHelmValues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"cloudProvider": "aws",
+		"awsRegion":     "us-east-1",
+		"image": map[string]interface{}{
+			"repository": "registry.k8s.io/autoscaling/cluster-autoscaler",
+			"tag":        "v1.30.0",
+		},
+		"resources": map[string]interface{}{
+			"requests": map[string]interface{}{
+				"cpu":    "100m",
+				"memory": "300Mi",
+			},
+		},
+		"podAnnotations": map[string]string{
+			"prometheus.io/port":   "8085",
+			"prometheus.io/scrape": "true",
+		},
+		"autoscalingGroups": []interface{}{
+			map[string]interface{}{
+				"name":    "nodes.minimal.example.com",
+				"minSize": int32(1),
+				"maxSize": int32(3),
+			},
+		},
+		"extraArgs": map[string]interface{}{
+			"cluster-name":                           "minimal.example.com",
+			"expander":                               "least-waste",
+			"balance-similar-node-groups":            true,
+			"balancing-label":                        []string{"topology.kubernetes.io/zone"},
+			"feature-gates":                          "ExampleFeature=true",
+			"aws-use-static-instance-list":           false,
+			"ignore-daemonsets-utilization":          false,
+			"scale-down-utilization-threshold":       "0.5",
+			"skip-nodes-with-custom-controller-pods": true,
+			"skip-nodes-with-local-storage":          true,
+			"skip-nodes-with-system-pods":            true,
+			"scale-down-delay-after-add":             "10m0s",
+			"scale-down-unneeded-time":               "10m0s",
+			"scale-down-unready-enabled":             true,
+			"scale-down-unready-time":                "20m0s",
+			"new-pod-scale-up-delay":                 "0s",
+			"max-node-provision-time":                "15m0s",
+			"cordon-node-before-terminating":         true,
+			"write-status-configmap":                 true,
+			"status-config-map-name":                 "cluster-autoscaler-status",
+		},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected values\nexpected: %v\n  actual: %v", expected, values)
+	}
+}
+
+func TestSplitClusterAutoscalerImage(t *testing.T) {
+	grid := []struct {
+		image      string
+		repository string
+		tag        string
+		expectErr  bool
+	}{
+		{
+			image:      "registry.k8s.io/autoscaling/cluster-autoscaler:v1.30.0",
+			repository: "registry.k8s.io/autoscaling/cluster-autoscaler",
+			tag:        "v1.30.0",
+		},
+		{
+			image:      "registry.example.com:5000/cluster-autoscaler:v1.30.0@sha256:0123456789abcdef",
+			repository: "registry.example.com:5000/cluster-autoscaler",
+			tag:        "v1.30.0@sha256:0123456789abcdef",
+		},
+		{
+			image:     "registry.example.com:5000/cluster-autoscaler",
+			expectErr: true,
+		},
+	}
+	for _, g := range grid {
+		repository, tag, err := splitClusterAutoscalerImage(g.image)
+		if g.expectErr {
+			if err == nil {
+				t.Errorf("%q: expected error", g.image)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", g.image, err)
+		}
+		if repository != g.repository || tag != g.tag {
+			t.Errorf("%q: expected %q and %q, got %q and %q", g.image, g.repository, g.tag, repository, tag)
+		}
+	}
+}
@@ -59,6 +59,7 @@ import (
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/components/kopscontroller"
 	"k8s.io/kops/pkg/model/iam"
+	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	"k8s.io/kops/pkg/resources/spotinst"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/pkg/fi"
@@ -85,7 +86,7 @@ type TemplateFunctions struct {
 // AddTo defines the available functions we can use in our YAML models.
 // If we are trying to get a new function implemented it MUST
 // be defined here.
-func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretStore) (err error) {
+func (tf *TemplateFunctions) AddTo(ctx context.Context, dest template.FuncMap, secretStore fi.SecretStore) (err error) {
 	cluster := tf.Cluster
 
 	dest["ToJSON"] = tf.ToJSON
@@ -342,30 +343,23 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["UseServiceAccountExternalPermissions"] = tf.UseServiceAccountExternalPermissions
 
 	if cluster.Spec.ClusterAutoscaler != nil {
-		dest["ClusterAutoscalerPriorities"] = func() string {
-			priorities := make(map[string][]string)
-			if cluster.Spec.ClusterAutoscaler.CustomPriorityExpanderConfig != nil {
-				priorities = cluster.Spec.ClusterAutoscaler.CustomPriorityExpanderConfig
-			} else {
-				for name, spec := range tf.GetNodeInstanceGroups() {
-					if spec.Autoscale != nil {
-						priorities[strconv.Itoa(int(spec.AutoscalePriority))] = append(priorities[strconv.Itoa(int(spec.AutoscalePriority))], fmt.Sprintf("%s.%s", name, tf.ClusterName()))
-					}
-				}
-			}
-
-			var prioritiesStr []string
-			for _, prio := range maps.SortedKeys(priorities) {
-				prioritiesStr = append(prioritiesStr, fmt.Sprintf("%s:", prio))
-				for _, value := range priorities[prio] {
-					prioritiesStr = append(prioritiesStr, fmt.Sprintf("- %s", value))
-				}
-			}
-			return strings.Join(prioritiesStr, "\n")
-		}
+		dest["ClusterAutoscalerPriorities"] = tf.ClusterAutoscalerPriorities
 		dest["CreateClusterAutoscalerPriorityConfig"] = func() bool {
 			return fi.ValueOf(cluster.Spec.ClusterAutoscaler.CreatePriorityExpenderConfig)
 		}
+		dest["ClusterAutoscalerNamespace"] = func() string {
+			return fi.ValueOf(cluster.Spec.ClusterAutoscaler.Namespace)
+		}
+		dest["ClusterAutoscalerPodAnnotations"] = func() map[string]string {
+			return clusterAutoscalerPodAnnotations(cluster.Spec.ClusterAutoscaler.PodAnnotations)
+		}
+		dest["ClusterAutoscalerCloudConfig"] = func() (string, error) {
+			return clusterAutoscalerCloudConfig(ctx, tf.Region, fi.ValueOf(cluster.Spec.ClusterAutoscaler.AWSUseStaticInstanceList))
+		}
+		dest["ClusterAutoscalerNodeGroupAutoDiscovery"] = tf.ClusterAutoscalerNodeGroupAutoDiscovery
+		dest["ClusterAutoscalerFeatureGates"] = func() string {
+			return clusterAutoscalerFeatureGates(cluster.Spec.ClusterAutoscaler.FeatureGates)
+		}
 	}
 
 	if cluster.Spec.CloudProvider.AWS != nil && cluster.Spec.CloudProvider.AWS.NodeTerminationHandler != nil {
@@ -935,6 +929,113 @@ func (tf *TemplateFunctions) GetNodeInstanceGroups() map[string]kops.InstanceGro
 	return nodegroups
 }
 
+// ClusterAutoscalerPriorities returns the contents of the priority expander ConfigMap,
+// built from the autoscalePriority of the node InstanceGroups unless a custom configuration is set.
+func (tf *TemplateFunctions) ClusterAutoscalerPriorities() string {
+	priorities := make(map[string][]string)
+	if tf.Cluster.Spec.ClusterAutoscaler.CustomPriorityExpanderConfig != nil {
+		priorities = tf.Cluster.Spec.ClusterAutoscaler.CustomPriorityExpanderConfig
+	} else {
+		nodeGroups := tf.GetNodeInstanceGroups()
+		for _, name := range maps.SortedKeys(nodeGroups) {
+			spec := nodeGroups[name]
+			if fi.ValueOf(spec.Autoscale) {
+				priority := strconv.Itoa(int(spec.AutoscalePriority))
+				priorities[priority] = append(priorities[priority], fmt.Sprintf("%s.%s", name, tf.ClusterName()))
+			}
+		}
+	}
+
+	var prioritiesStr []string
+	for _, prio := range maps.SortedKeys(priorities) {
+		prioritiesStr = append(prioritiesStr, fmt.Sprintf("%s:", prio))
+		for _, value := range priorities[prio] {
+			prioritiesStr = append(prioritiesStr, fmt.Sprintf("- %s", value))
+		}
+	}
+	return strings.Join(prioritiesStr, "\n")
+}
+
+// clusterAutoscalerManagedPodAnnotations are the annotations kOps sets on the cluster autoscaler pods.
+var clusterAutoscalerManagedPodAnnotations = map[string]string{
+	"prometheus.io/port":   "8085",
+	"prometheus.io/scrape": "true",
+}
+
+// clusterAutoscalerPodAnnotations merges the user-supplied annotations for the cluster autoscaler pods
+// with the ones managed by kOps. Managed annotations take precedence.
+func clusterAutoscalerPodAnnotations(podAnnotations map[string]string) map[string]string {
+	annotations := make(map[string]string, len(podAnnotations)+len(clusterAutoscalerManagedPodAnnotations))
+	for k, v := range podAnnotations {
+		if _, found := clusterAutoscalerManagedPodAnnotations[k]; found {
+			klog.Warningf("ignoring cluster autoscaler pod annotation %q, it is managed by kOps", k)
+			continue
+		}
+		annotations[k] = v
+	}
+	for k, v := range clusterAutoscalerManagedPodAnnotations {
+		annotations[k] = v
+	}
+	return annotations
+}
+
+// clusterAutoscalerFeatureGates returns the value of the cluster autoscaler --feature-gates flag, sorted by gate name.
+func clusterAutoscalerFeatureGates(featureGates map[string]bool) string {
+	gates := make([]string, 0, len(featureGates))
+	for name, enabled := range featureGates {
+		gates = append(gates, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(gates)
+	return strings.Join(gates, ",")
+}
+
+// awsNonCommercialRegionPrefixes are the prefixes of the regions outside the commercial "aws" partition.
+var awsNonCommercialRegionPrefixes = []string{"cn-", "us-gov-", "us-iso-", "us-isob-"}
+
+// clusterAutoscalerCloudConfig returns the cloud config of the cluster autoscaler, which overrides the EC2 endpoint
+// the autoscaler looks up instance types from, or an empty string if no override is needed.
+// The autoscaler's AWS SDK predates the service-specific endpoint environment variables, such as AWS_ENDPOINT_URL_EC2,
+// so the endpoint is passed as a ServiceOverride, which the autoscaler applies to the clients it creates.
+func clusterAutoscalerCloudConfig(ctx context.Context, region string, useStaticInstanceList bool) (string, error) {
+	endpoint, err := clusterAutoscalerEC2Endpoint(ctx, region, useStaticInstanceList)
+	if err != nil || endpoint == "" {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.WriteString("[ServiceOverride \"1\"]\n")
+	b.WriteString("Service = ec2\n")
+	b.WriteString("Region = " + region + "\n")
+	b.WriteString("URL = " + endpoint + "\n")
+	b.WriteString("SigningRegion = " + region + "\n")
+	return b.String(), nil
+}
+
+// clusterAutoscalerEC2Endpoint returns the EC2 endpoint the cluster autoscaler should use to look up instance types.
+// It is only needed when the static instance list is disabled, and only set for regions outside the commercial partition,
+// so that the autoscaler doesn't rely on resolving the endpoint of the region's partition itself.
+func clusterAutoscalerEC2Endpoint(ctx context.Context, region string, useStaticInstanceList bool) (string, error) {
+	if useStaticInstanceList {
+		return "", nil
+	}
+	nonCommercial := false
+	for _, prefix := range awsNonCommercialRegionPrefixes {
+		if strings.HasPrefix(region, prefix) {
+			nonCommercial = true
+		}
+	}
+	if !nonCommercial {
+		return "", nil
+	}
+
+	resolver := ec2.NewDefaultEndpointResolverV2()
+	ep, err := resolver.ResolveEndpoint(ctx, ec2.EndpointParameters{Region: fi.PtrTo(region)})
+	if err != nil {
+		return "", fmt.Errorf("error resolving EC2 endpoint for region %q: %w", region, err)
+	}
+	return ep.URI.String(), nil
+}
+
 type ClusterAutoscalerNodeGroup struct {
 	AutoScale *bool
 	MinSize   int32
@@ -948,6 +1049,10 @@ func (tf *TemplateFunctions) GetClusterAutoscalerNodeGroups() map[string]Cluster
 	groups := make(map[string]ClusterAutoscalerNodeGroup)
 	for _, ig := range tf.KopsModelContext.InstanceGroups {
 		if ig.Spec.Role == kops.InstanceGroupRoleNode && (ig.Spec.Autoscale == nil || fi.ValueOf(ig.Spec.Autoscale)) {
+			if tf.HasClusterAutoscalerExternalBounds(ig) {
+				// These groups are auto-discovered, so that the cluster autoscaler honors their external bounds
+				continue
+			}
 			group := ClusterAutoscalerNodeGroup{
 				AutoScale: ig.Spec.Autoscale,
 				MinSize:   fi.ValueOf(ig.Spec.MinSize),
@@ -966,6 +1071,17 @@ func (tf *TemplateFunctions) GetClusterAutoscalerNodeGroups() map[string]Cluster
 	return groups
 }
 
+// ClusterAutoscalerNodeGroupAutoDiscovery returns the --node-group-auto-discovery value matching the instance groups
+// with externally-managed bounds, or an empty string if there are none.
+func (tf *TemplateFunctions) ClusterAutoscalerNodeGroupAutoDiscovery() string {
+	for _, ig := range tf.KopsModelContext.InstanceGroups {
+		if tf.HasClusterAutoscalerExternalBounds(ig) {
+			return "asg:tag=" + nodeidentityaws.ClusterAutoscalerAutoDiscoveryEnabled + "," + nodeidentityaws.ClusterAutoscalerAutoDiscoveryClusterPrefix + tf.Cluster.ObjectMeta.Name
+		}
+	}
+	return ""
+}
+
 func (tf *TemplateFunctions) architectureOfAMI(amiID string) string {
 	image, _ := tf.cloud.(awsup.AWSCloud).ResolveImage(amiID)
 	switch image.Architecture {
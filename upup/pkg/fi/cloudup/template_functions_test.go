@@ -17,12 +17,14 @@ limitations under the License.
 package cloudup
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/cloudmock/aws/mockec2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/featureflag"
@@ -360,3 +362,226 @@ func TestKopsFeatureEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterAutoscalerPodAnnotations(t *testing.T) {
+	actual := clusterAutoscalerPodAnnotations(map[string]string{
+		"sidecar.istio.io/inject": "true",
+		"prometheus.io/port":      "9090",
+	})
+	expected := map[string]string{
+		"prometheus.io/port":      "8085",
+		"prometheus.io/scrape":    "true",
+		"sidecar.istio.io/inject": "true",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	if actual := clusterAutoscalerPodAnnotations(nil); !reflect.DeepEqual(actual, clusterAutoscalerManagedPodAnnotations) {
+		t.Errorf("expected %v, got %v", clusterAutoscalerManagedPodAnnotations, actual)
+	}
+}
+
+func TestClusterAutoscalerFeatureGates(t *testing.T) {
+	actual := clusterAutoscalerFeatureGates(map[string]bool{
+		"ZetaFeature":  true,
+		"AlphaFeature": false,
+		"BetaFeature":  true,
+	})
+	expected := "AlphaFeature=false,BetaFeature=true,ZetaFeature=true"
+	if actual != expected {
+		t.Errorf("expected feature gates %q, got %q", expected, actual)
+	}
+
+	if actual := clusterAutoscalerFeatureGates(nil); actual != "" {
+		t.Errorf("expected no feature gates, got %q", actual)
+	}
+}
+
+func TestClusterAutoscalerEC2Endpoint(t *testing.T) {
+	grid := []struct {
+		region                string
+		useStaticInstanceList bool
+		expected              string
+	}{
+		{
+			region:   "us-gov-west-1",
+			expected: "https://ec2.us-gov-west-1.amazonaws.com",
+		},
+		{
+			region:   "cn-north-1",
+			expected: "https://ec2.cn-north-1.amazonaws.com.cn",
+		},
+		{
+			region:   "us-east-1",
+			expected: "",
+		},
+		{
+			region:                "us-gov-west-1",
+			useStaticInstanceList: true,
+			expected:              "",
+		},
+	}
+	for _, g := range grid {
+		t.Run(fmt.Sprintf("%s static=%v", g.region, g.useStaticInstanceList), func(t *testing.T) {
+			actual, err := clusterAutoscalerEC2Endpoint(context.TODO(), g.region, g.useStaticInstanceList)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != g.expected {
+				t.Errorf("expected endpoint %q, got %q", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetClusterAutoscalerNodeGroupsExternalBounds(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			CloudProvider: kops.CloudProviderSpec{
+				AWS: &kops.AWSSpec{},
+			},
+			ClusterAutoscaler: &kops.ClusterAutoscalerConfig{
+				Enabled:                 fi.PtrTo(true),
+				ExternalNodeGroupBounds: fi.PtrTo(true),
+			},
+		},
+	}
+	managed := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+		Spec: kops.InstanceGroupSpec{
+			Role:    kops.InstanceGroupRoleNode,
+			MinSize: fi.PtrTo(int32(1)),
+			MaxSize: fi.PtrTo(int32(3)),
+		},
+	}
+	external := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "external",
+			Annotations: map[string]string{
+				kops.AnnotationNameClusterAutoscalerExternalBounds: "true",
+			},
+		},
+		Spec: kops.InstanceGroupSpec{
+			Role:    kops.InstanceGroupRoleNode,
+			MinSize: fi.PtrTo(int32(0)),
+			MaxSize: fi.PtrTo(int32(10)),
+		},
+	}
+
+	tf := &TemplateFunctions{}
+	tf.Cluster = cluster
+	tf.InstanceGroups = []*kops.InstanceGroup{managed, external}
+
+	groups := tf.GetClusterAutoscalerNodeGroups()
+	expected := map[string]ClusterAutoscalerNodeGroup{
+		"nodes": {
+			MinSize: 1,
+			MaxSize: 3,
+			Other:   "nodes.minimal.example.com",
+		},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("expected node groups %v, got %v", expected, groups)
+	}
+
+	if actual, expected := tf.ClusterAutoscalerNodeGroupAutoDiscovery(), "asg:tag=k8s.io/cluster-autoscaler/enabled,k8s.io/cluster-autoscaler/minimal.example.com"; actual != expected {
+		t.Errorf("expected auto-discovery %q, got %q", expected, actual)
+	}
+
+	tags, err := tf.CloudTagsForInstanceGroup(external)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags["k8s.io/cluster-autoscaler/enabled"] != "true" || tags["k8s.io/cluster-autoscaler/minimal.example.com"] != "owned" {
+		t.Errorf("expected auto-discovery tags on external group, got %v", tags)
+	}
+
+	// Without the cluster option, the annotation is ignored and kOps manages the bounds
+	cluster.Spec.ClusterAutoscaler.ExternalNodeGroupBounds = nil
+	if groups := tf.GetClusterAutoscalerNodeGroups(); len(groups) != 2 {
+		t.Errorf("expected both groups to have kOps-managed bounds, got %v", groups)
+	}
+	if actual := tf.ClusterAutoscalerNodeGroupAutoDiscovery(); actual != "" {
+		t.Errorf("expected no auto-discovery, got %q", actual)
+	}
+}
+
+func TestClusterAutoscalerPriorities(t *testing.T) {
+	nodeGroup := func(name string, priority int16, autoscale *bool) *kops.InstanceGroup {
+		return &kops.InstanceGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kops.InstanceGroupSpec{
+				Role:              kops.InstanceGroupRoleNode,
+				Autoscale:         autoscale,
+				AutoscalePriority: priority,
+			},
+		}
+	}
+	instanceGroups := []*kops.InstanceGroup{
+		nodeGroup("spot-b", 100, fi.PtrTo(true)),
+		nodeGroup("on-demand", 10, fi.PtrTo(true)),
+		nodeGroup("spot-a", 100, fi.PtrTo(true)),
+		nodeGroup("fallback", 0, fi.PtrTo(true)),
+		nodeGroup("static", 50, fi.PtrTo(false)),
+		nodeGroup("unmanaged", 50, nil),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "control-plane"},
+			Spec: kops.InstanceGroupSpec{
+				Role:              kops.InstanceGroupRoleControlPlane,
+				Autoscale:         fi.PtrTo(true),
+				AutoscalePriority: 50,
+			},
+		},
+	}
+
+	grid := []struct {
+		name     string
+		custom   map[string][]string
+		expected string
+	}{
+		{
+			name: "instance group priorities",
+			expected: `0:
+- fallback.minimal.example.com
+10:
+- on-demand.minimal.example.com
+100:
+- spot-a.minimal.example.com
+- spot-b.minimal.example.com`,
+		},
+		{
+			name: "custom priorities",
+			custom: map[string][]string{
+				"10": {".*"},
+				"50": {"spot-.*", "on-demand"},
+			},
+			expected: `10:
+- .*
+50:
+- spot-.*
+- on-demand`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			tf := &TemplateFunctions{}
+			tf.Cluster = &kops.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+				Spec: kops.ClusterSpec{
+					ClusterAutoscaler: &kops.ClusterAutoscalerConfig{
+						Enabled:                      fi.PtrTo(true),
+						Expander:                     "priority",
+						CustomPriorityExpanderConfig: g.custom,
+					},
+				},
+			}
+			tf.InstanceGroups = instanceGroups
+
+			if actual := tf.ClusterAutoscalerPriorities(); actual != g.expected {
+				t.Errorf("expected priorities\n%s\ngot\n%s", g.expected, actual)
+			}
+		})
+	}
+}
@@ -80,6 +80,9 @@ func (o *object) Write(buffer *bytes.Buffer, indent int, key string) {
 var literalType = reflect.TypeOf(terraformWriter.Literal{})
 
 func toElement(item interface{}) element {
+	if item == nil {
+		return nil
+	}
 	if literal, ok := item.(*terraformWriter.Literal); ok {
 		if literal == nil {
 			return nil
@@ -98,6 +101,8 @@ func toElement(item interface{}) element {
 		return terraformWriter.LiteralTokens(strconv.FormatBool(v.Bool()))
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return terraformWriter.LiteralTokens(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return terraformWriter.LiteralTokens(strconv.FormatUint(v.Uint(), 10))
 	case reflect.Map:
 		return mapToElement(v.Interface())
 	case reflect.String:
@@ -108,7 +113,7 @@ func toElement(item interface{}) element {
 		}
 		for _, field := range reflect.VisibleFields(v.Type()) {
 			element := toElement(v.FieldByIndex(field.Index).Interface())
-			if element != nil {
+			if element != nil && !isProviderDefault(field, element) {
 				o.field[fieldKey(field)] = element
 			}
 		}
@@ -174,6 +179,29 @@ func sliceToElement(v reflect.Value) element {
 	}
 }
 
+// isProviderDefault returns true if the field declares the provider's default value with a providerdefault tag,
+// e.g. `cty:"interval" providerdefault:"60"`, and the element has that value.
+// Such attributes are omitted, so that the generated terraform only contains the values we actually care about.
+func isProviderDefault(field reflect.StructField, e element) bool {
+	value, found := field.Tag.Lookup("providerdefault")
+	if !found {
+		return false
+	}
+	literal, ok := e.(*terraformWriter.Literal)
+	if !ok {
+		return false
+	}
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.String {
+		return literal.String == terraformWriter.LiteralFromStringValue(value).String
+	}
+	return literal.String == value
+}
+
 func fieldKey(field reflect.StructField) string {
 	key := field.Tag.Get("cty")
 	if key != "" {
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+func TestToElementIntegers(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{name: "negative int32", value: fi.PtrTo(int32(-1)), expected: "-1"},
+		{name: "zero int32", value: fi.PtrTo(int32(0)), expected: "0"},
+		{name: "max int32", value: fi.PtrTo(int32(2147483647)), expected: "2147483647"},
+		{name: "min int32", value: int32(-2147483648), expected: "-2147483648"},
+		// Not representable as a float64, so would be rounded by a float conversion
+		{name: "large int64", value: int64(9007199254740993), expected: "9007199254740993"},
+		{name: "max int64", value: int64(9223372036854775807), expected: "9223372036854775807"},
+		{name: "max uint16", value: uint16(65535), expected: "65535"},
+		{name: "max uint64", value: uint64(18446744073709551615), expected: "18446744073709551615"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			literal, ok := toElement(tc.value).(*terraformWriter.Literal)
+			if !ok {
+				t.Fatalf("expected a literal, got %T", toElement(tc.value))
+			}
+			if literal.String != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, literal.String)
+			}
+			// The JSON syntax must not round the value either
+			if actual := jsonLiteral(literal); actual != json.Number(tc.expected) {
+				t.Errorf("expected JSON number %s, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestToElementOmitsProviderDefaults(t *testing.T) {
+	type healthCheck struct {
+		Target   *string `cty:"target" providerdefault:"HTTP:80/"`
+		Interval *int32  `cty:"interval" providerdefault:"30"`
+		Timeout  *int32  `cty:"timeout" providerdefault:"5"`
+		Enabled  *bool   `cty:"enabled" providerdefault:"true"`
+	}
+	type resource struct {
+		Name        *string      `cty:"name"`
+		Port        *int32       `cty:"port" providerdefault:"80"`
+		HealthCheck *healthCheck `cty:"health_check"`
+	}
+
+	r := &resource{
+		Name: fi.PtrTo("example"),
+		Port: fi.PtrTo(int32(443)),
+		HealthCheck: &healthCheck{
+			Target:   fi.PtrTo("HTTP:80/"),
+			Interval: fi.PtrTo(int32(10)),
+			Timeout:  fi.PtrTo(int32(5)),
+			Enabled:  fi.PtrTo(true),
+		},
+	}
+
+	var buf bytes.Buffer
+	toElement(r).Write(&buf, 0, `resource "example" "example"`)
+
+	// Only the values that differ from the provider defaults are written, at any depth
+	expected := `resource "example" "example" {
+  health_check {
+    interval = 10
+  }
+  name = "example"
+  port = 443
+}
+`
+	if actual := buf.String(); actual != expected {
+		t.Errorf("unexpected output, expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
@@ -21,7 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
-	"strings"
+	"unicode/utf8"
 
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -59,7 +59,7 @@ func (m *mapStringLiteral) Write(buffer *bytes.Buffer, indent int, key string) {
 	keys := make([]string, 0, len(m.members))
 	maxKeyLen := 0
 	for k := range m.members {
-		kLen := len(quote(k))
+		kLen := utf8.RuneCountInString(terraformWriter.Quote(k))
 		if kLen > maxKeyLen {
 			maxKeyLen = kLen
 		}
@@ -68,9 +68,9 @@ func (m *mapStringLiteral) Write(buffer *bytes.Buffer, indent int, key string) {
 	sort.Strings(keys)
 	for _, k := range keys {
 		writeIndent(buffer, indent+2)
-		quoted := quote(k)
+		quoted := terraformWriter.Quote(k)
 		buffer.WriteString(quoted)
-		writeIndent(buffer, maxKeyLen-len(quoted))
+		writeIndent(buffer, maxKeyLen-utf8.RuneCountInString(quoted))
 		buffer.WriteString(" = ")
 		buffer.WriteString(m.members[k].String)
 		buffer.WriteRune('\n')
@@ -100,7 +100,7 @@ func mapToElement(item interface{}) *mapStringLiteral {
 	}
 	o := &mapStringLiteral{members: make(map[string]*terraformWriter.Literal, v.Len())}
 	for _, key := range v.MapKeys() {
-		o.members[key.String()] = terraformWriter.LiteralFromStringValue(v.MapIndex(key).String())
+		o.members[key.String()] = &terraformWriter.Literal{String: terraformWriter.Quote(v.MapIndex(key).String())}
 	}
 	return o
 }
@@ -111,15 +111,21 @@ func writeIndent(buf *bytes.Buffer, indent int) {
 	}
 }
 
-func quote(s string) string {
-	var b strings.Builder
-	b.WriteRune('"')
-	for _, r := range s {
-		if r == '\\' || r == '"' {
-			b.WriteRune('\\')
+// reindent rewrites the leading indentation of every line in data,
+// which is always written two spaces per level, using the given style.
+// Whitespace used to align values within a line is left alone.
+func reindent(data []byte, style terraformWriter.Indentation) []byte {
+	if style.IsDefault() {
+		return data
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		trimmed := bytes.TrimLeft(line, " ")
+		level := (len(line) - len(trimmed)) / 2
+		if level == 0 {
+			continue
 		}
-		b.WriteRune(r)
+		lines[i] = append([]byte(style.Prefix(level)), trimmed...)
 	}
-	b.WriteRune('"')
-	return b.String()
+	return bytes.Join(lines, []byte("\n"))
 }
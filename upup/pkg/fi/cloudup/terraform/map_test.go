@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMapUnicodeRoundTrip(t *testing.T) {
+	tags := map[string]string{
+		"Name":             "nodes.example.com",
+		"team/🚀":           "plateforme-données",
+		"owner":            "山田太郎",
+		"emoji":            "🐳 containers 🐧",
+		"quoted \"key\"":   `back\slash`,
+		"template":         "${var.not_a_reference} and %{ if true }",
+		"multi\nline\tkey": "bell\a",
+		"k8s.io/role/node": "1",
+		"ключ":             "значение",
+		"mixed-ünïcödé-🎉":  "∑ ≠ ∞",
+	}
+
+	var buf bytes.Buffer
+	mapToElement(tags).Write(&buf, 0, "tags")
+	written := buf.String()
+	if !utf8.ValidString(written) {
+		t.Fatalf("written map is not valid UTF-8:\n%s", written)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(written, "\n"), "\n")
+	if lines[0] != "tags = {" || lines[len(lines)-1] != "}" {
+		t.Fatalf("unexpected map framing:\n%s", written)
+	}
+
+	actual := make(map[string]string)
+	for _, line := range lines[1 : len(lines)-1] {
+		key, rest, err := parseHCLQuotedString(strings.TrimLeft(line, " "))
+		if err != nil {
+			t.Fatalf("error parsing key of line %q: %v", line, err)
+		}
+		rest = strings.TrimLeft(rest, " ")
+		if !strings.HasPrefix(rest, "= ") {
+			t.Fatalf("expected = after key in line %q", line)
+		}
+		value, rest, err := parseHCLQuotedString(strings.TrimPrefix(rest, "= "))
+		if err != nil {
+			t.Fatalf("error parsing value of line %q: %v", line, err)
+		}
+		if rest != "" {
+			t.Fatalf("unexpected trailing %q in line %q", rest, line)
+		}
+		actual[key] = value
+	}
+
+	if !reflect.DeepEqual(actual, tags) {
+		t.Errorf("map did not round-trip:\nwritten:\n%s\nparsed: %q\nexpected: %q", written, actual, tags)
+	}
+
+	// Keys are aligned by the number of characters, as terraform fmt does
+	equalsColumn := -1
+	for _, line := range lines[1 : len(lines)-1] {
+		column := utf8.RuneCountInString(line[:strings.Index(line, "= \"")])
+		if equalsColumn == -1 {
+			equalsColumn = column
+		} else if column != equalsColumn {
+			t.Errorf("expected values to be aligned at column %d, got %d in line %q", equalsColumn, column, line)
+		}
+	}
+}
+
+// parseHCLQuotedString parses an HCL2 quoted string literal at the start of s,
+// returning the string it evaluates to and the remainder of s.
+func parseHCLQuotedString(s string) (string, string, error) {
+	if !strings.HasPrefix(s, "\"") {
+		return "", "", fmt.Errorf("expected opening quote")
+	}
+	var b strings.Builder
+	for i := 1; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '"':
+			return b.String(), s[i+1:], nil
+		case r < 0x20 || r == 0x7f:
+			return "", "", fmt.Errorf("unescaped control character %U", r)
+		case r == '\\':
+			if i+1 >= len(s) {
+				return "", "", fmt.Errorf("unterminated escape sequence")
+			}
+			switch c := s[i+1]; c {
+			case 'n':
+				b.WriteRune('\n')
+			case 'r':
+				b.WriteRune('\r')
+			case 't':
+				b.WriteRune('\t')
+			case '"', '\\':
+				b.WriteByte(c)
+			case 'u', 'U':
+				digits := 4
+				if c == 'U' {
+					digits = 8
+				}
+				if i+2+digits > len(s) {
+					return "", "", fmt.Errorf("short unicode escape sequence")
+				}
+				code, err := strconv.ParseUint(s[i+2:i+2+digits], 16, 32)
+				if err != nil {
+					return "", "", fmt.Errorf("invalid unicode escape sequence: %w", err)
+				}
+				b.WriteRune(rune(code))
+				i += digits
+			default:
+				return "", "", fmt.Errorf("invalid escape sequence \\%c", c)
+			}
+			i += 2
+			continue
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			return "", "", fmt.Errorf("unescaped template sequence %c{", r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], string(r)+"{"):
+			// $${ and %%{ are the escaped forms of ${ and %{
+			b.WriteRune(r)
+			b.WriteRune('{')
+			i += 3
+			continue
+		default:
+			b.WriteRune(r)
+		}
+		i += size
+	}
+	return "", "", fmt.Errorf("unterminated string")
+}
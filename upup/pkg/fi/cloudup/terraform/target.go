@@ -48,6 +48,31 @@ func NewTerraformTarget(cloud fi.Cloud, project string, outDir string, clusterSp
 		clusterSpecTarget: clusterSpecTarget,
 	}
 	target.InitTerraformWriter()
+	if clusterSpecTarget != nil && clusterSpecTarget.Terraform != nil {
+		if clusterSpecTarget.Terraform.Syntax == kops.TerraformSyntaxJSON {
+			target.Syntax = terraformWriter.SyntaxJSON
+		}
+		if indentation := clusterSpecTarget.Terraform.Indentation; indentation != nil {
+			target.Indentation = terraformWriter.Indentation{
+				UseTabs: indentation.UseTabs,
+				Width:   int(indentation.Width),
+			}
+		}
+		target.LocalExecProvisioners = clusterSpecTarget.Terraform.AllowLocalExecProvisioners
+		if lb := clusterSpecTarget.Terraform.APILoadBalancer; lb != nil {
+			target.LoadBalancerIdleTimeoutVariables = lb.IdleTimeoutVariable
+			target.CommonTagsVariable = lb.TagsVariable
+			target.LoadBalancerLocalExecCommand = lb.LocalExecCommand
+			if timeouts := lb.Timeouts; timeouts != nil {
+				if timeouts.Create != nil {
+					target.LoadBalancerTimeouts.Create = timeouts.Create.Duration.String()
+				}
+				if timeouts.Delete != nil {
+					target.LoadBalancerTimeouts.Delete = timeouts.Delete.Duration.String()
+				}
+			}
+		}
+	}
 	return &target
 }
 
@@ -86,8 +111,17 @@ func tfGetFilesProviderExtraConfig(c *kops.TargetSpec) map[string]string {
 }
 
 func (t *TerraformTarget) Finish(taskMap map[string]fi.CloudupTask) error {
-	if err := t.finishHCL2(); err != nil {
-		return err
+	switch t.Syntax {
+	case terraformWriter.SyntaxHCL:
+		if err := t.finishHCL2(); err != nil {
+			return err
+		}
+	case terraformWriter.SyntaxJSON:
+		if err := t.finishJSON(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown terraform syntax %q", t.Syntax)
 	}
 
 	for relativePath, contents := range t.Files {
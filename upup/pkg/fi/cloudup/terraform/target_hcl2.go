@@ -31,6 +31,8 @@ import (
 func (t *TerraformTarget) finishHCL2() error {
 	buf := &bytes.Buffer{}
 
+	writeVariables(buf, t.GetInputVariables())
+
 	outputs, err := t.GetOutputs()
 	if err != nil {
 		return err
@@ -44,7 +46,7 @@ func (t *TerraformTarget) finishHCL2() error {
 		return err
 	}
 
-	t.writeResources(buf, resourcesByType)
+	t.writeResources(buf, resourcesByType, t.GetResourceCounts())
 
 	dataSourcesByType, err := t.GetDataSourcesByType()
 	if err != nil {
@@ -53,13 +55,57 @@ func (t *TerraformTarget) finishHCL2() error {
 
 	t.writeDataSources(buf, dataSourcesByType)
 
+	writeImports(buf, t.GetImports())
+
 	t.writeTerraform(buf)
 
-	t.Files["kubernetes.tf"] = buf.Bytes()
+	t.Files["kubernetes.tf"] = reindent(buf.Bytes(), t.Indentation)
 
 	return nil
 }
 
+type variable struct {
+	Default *terraformWriter.Literal
+}
+
+// writeVariables creates a variable block for every input variable
+// Example:
+//
+//	variable "create_elb" {
+//	  default = true
+//	}
+func writeVariables(buf *bytes.Buffer, variables map[string]*terraformWriter.Literal) {
+	names := make([]string, 0, len(variables))
+	for k := range variables {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		toElement(&variable{Default: variables[name]}).Write(buf, 0, fmt.Sprintf("variable %q", name))
+		buf.WriteString("\n")
+	}
+}
+
+type importBlock struct {
+	ID string                   `cty:"id"`
+	To *terraformWriter.Literal `cty:"to"`
+}
+
+// writeImports creates an import block for every existing resource that is adopted into terraform
+// Example:
+//
+//	import {
+//	  id = "api-example-com"
+//	  to = aws_elb.api-example-com
+//	}
+func writeImports(buf *bytes.Buffer, imports []terraformWriter.Import) {
+	for _, i := range imports {
+		toElement(&importBlock{ID: i.ID, To: i.To}).Write(buf, 0, "import")
+		buf.WriteString("\n")
+	}
+}
+
 type output struct {
 	Value *terraformWriter.Literal
 }
@@ -111,7 +157,15 @@ func writeLocalsOutputs(buf *bytes.Buffer, outputs map[string]terraformWriter.Ou
 	return
 }
 
-func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
+// providerBlock is a provider block in the generated terraform.
+type providerBlock struct {
+	Name string
+	Body map[string]string
+}
+
+// providers returns the provider blocks for the cloud provider and for any managed files,
+// with the cloud provider first.
+func (t *TerraformTarget) providers() []providerBlock {
 	providerName := string(t.Cloud.ProviderID())
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
 		providerName = "google"
@@ -132,26 +186,30 @@ func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
 	for k, v := range tfGetProviderExtraConfig(t.clusterSpecTarget) {
 		providerBody[k] = v
 	}
-	mapToElement(providerBody).
-		ToObject().
-		Write(buf, 0, fmt.Sprintf("provider %q", providerName))
-	buf.WriteString("\n")
+	providers := []providerBlock{{Name: providerName, Body: providerBody}}
 
 	// Add any additional provider definition for managed files
 	keys := sortedKeysForMap(t.TerraformWriter.Providers)
 	for _, key := range keys {
-		provider := t.TerraformWriter.Providers[key]
+		p := t.TerraformWriter.Providers[key]
 		providerBody := map[string]string{}
 		providerBody["alias"] = "files"
-		for k, v := range provider.Arguments {
+		for k, v := range p.Arguments {
 			providerBody[k] = v
 		}
 		for k, v := range tfGetFilesProviderExtraConfig(t.clusterSpecTarget) {
 			providerBody[k] = v
 		}
-		mapToElement(providerBody).
+		providers = append(providers, providerBlock{Name: p.Name, Body: providerBody})
+	}
+	return providers
+}
+
+func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
+	for _, p := range t.providers() {
+		mapToElement(p.Body).
 			ToObject().
-			Write(buf, 0, fmt.Sprintf("provider %q", provider.Name))
+			Write(buf, 0, fmt.Sprintf("provider %q", p.Name))
 		buf.WriteString("\n")
 	}
 }
@@ -165,12 +223,13 @@ func sortedKeysForMap[K ~string, V any](m map[K]V) []K {
 	return keys
 }
 
-func (t *TerraformTarget) writeResources(buf *bytes.Buffer, resourcesByType map[string]map[string]interface{}) {
+func (t *TerraformTarget) writeResources(buf *bytes.Buffer, resourcesByType map[string]map[string]interface{}, counts map[string]map[string]*terraformWriter.Literal) {
 	resourceTypes := make([]string, 0, len(resourcesByType))
 	for resourceType := range resourcesByType {
 		resourceTypes = append(resourceTypes, resourceType)
 	}
 	sort.Strings(resourceTypes)
+	provisioners := t.GetLocalExecProvisioners()
 	for _, resourceType := range resourceTypes {
 		resources := resourcesByType[resourceType]
 		resourceNames := make([]string, 0, len(resources))
@@ -179,13 +238,37 @@ func (t *TerraformTarget) writeResources(buf *bytes.Buffer, resourcesByType map[
 		}
 		sort.Strings(resourceNames)
 		for _, resourceName := range resourceNames {
-			toElement(resources[resourceName]).
-				Write(buf, 0, fmt.Sprintf("resource %q %q", resourceType, resourceName))
+			e := toElement(resources[resourceName])
+			if count := counts[resourceType][resourceName]; count != nil {
+				e.(*object).field["count"] = count
+			}
+			if commands := provisioners[resourceType][resourceName]; len(commands) != 0 {
+				e.(*object).field[`provisioner "local-exec"`] = localExecProvisionersToElement(commands)
+			}
+			e.Write(buf, 0, fmt.Sprintf("resource %q %q", resourceType, resourceName))
 			buf.WriteString("\n")
 		}
 	}
 }
 
+type localExecProvisioner struct {
+	Command *terraformWriter.Literal `cty:"command"`
+}
+
+// localExecProvisionersToElement returns the provisioner blocks running the commands
+// Example:
+//
+//	provisioner "local-exec" {
+//	  command = "dig +short ${self.dns_name}"
+//	}
+func localExecProvisionersToElement(commands []*terraformWriter.Literal) element {
+	blocks := &sliceObject{}
+	for _, command := range commands {
+		blocks.members = append(blocks.members, toElement(&localExecProvisioner{Command: command}))
+	}
+	return blocks
+}
+
 func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType map[string]map[string]interface{}) {
 	dataSourceTypes := make([]string, 0, len(dataSourcesByType))
 	for dataSourceType := range dataSourcesByType {
@@ -209,9 +292,29 @@ func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType
 
 func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 	buf.WriteString("terraform {\n")
-	buf.WriteString("  required_version = \">= 0.15.0\"\n")
+	buf.WriteString(fmt.Sprintf("  required_version = %q\n", t.requiredVersion()))
 	buf.WriteString("  required_providers {\n")
 
+	requiredProviders := t.requiredProviders()
+	for _, provider := range sortedKeysForMap(requiredProviders) {
+		mapToElement(requiredProviders[provider]).Write(buf, 4, provider)
+	}
+
+	buf.WriteString("  }\n")
+	buf.WriteString("}\n")
+}
+
+// requiredVersion returns the terraform version constraint for the generated configuration.
+func (t *TerraformTarget) requiredVersion() string {
+	if len(t.GetImports()) > 0 {
+		// import blocks were added in terraform 1.5
+		return ">= 1.5.0"
+	}
+	return ">= 0.15.0"
+}
+
+// requiredProviders returns the source and version constraints of every provider used by the generated configuration.
+func (t *TerraformTarget) requiredProviders() map[string]map[string]*terraformWriter.Literal {
 	providers := make(map[string]bool)
 	providerAliases := make(map[string][]string)
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
@@ -234,8 +337,8 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 		providerAliases[tfProvider.Name] = append(providerAliases[tfProvider.Name], "files")
 	}
 
-	providerKeys := sortedKeysForMap(providers)
-	for _, provider := range providerKeys {
+	requiredProviders := make(map[string]map[string]*terraformWriter.Literal)
+	for provider := range providers {
 		// providerVersions could be a constant, but keeping it here
 		// because it isn't shared and to allow for more complex logic in future.
 		providerVersions := map[string]map[string]string{
@@ -284,9 +387,7 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 			tf["configuration_aliases"] = terraformWriter.LiteralListExpression(configurationAliases...)
 		}
 
-		mapToElement(tf).Write(buf, 4, provider)
+		requiredProviders[provider] = tf
 	}
-
-	buf.WriteString("  }\n")
-	buf.WriteString("}\n")
+	return requiredProviders
 }
@@ -18,10 +18,14 @@ package terraform
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
@@ -68,6 +72,22 @@ locals {
 
 output "key1" {
   value = ["value1", "value2"]
+}`,
+		},
+		{
+			name: "nonsensitive output",
+			values: map[string]terraformWriter.OutputValue{
+				"api_elb_dns_name": {
+					Value: terraformWriter.LiteralNonsensitive(terraformWriter.LiteralProperty("aws_elb", "api-minimal-example-com", "dns_name")),
+				},
+			},
+			expected: `
+locals {
+  api_elb_dns_name = nonsensitive(aws_elb.api-minimal-example-com.dns_name)
+}
+
+output "api_elb_dns_name" {
+  value = nonsensitive(aws_elb.api-minimal-example-com.dns_name)
 }`,
 		},
 	}
@@ -85,3 +105,162 @@ output "key1" {
 		})
 	}
 }
+
+func TestReindent(t *testing.T) {
+	type listener struct {
+		InstancePort int
+		LBPort       int `cty:"lb_port"`
+	}
+	type resource struct {
+		Listener *listener
+		Name     string
+		Tags     map[string]string
+	}
+	r := &resource{
+		Listener: &listener{InstancePort: 443, LBPort: 443},
+		Name:     "api",
+		Tags:     map[string]string{"Name": "api", "KubernetesCluster": "example.com"},
+	}
+
+	cases := []struct {
+		name     string
+		style    terraformWriter.Indentation
+		expected string
+	}{
+		{
+			name: "default",
+			expected: `resource "aws_elb" "api" {
+  listener {
+    instance_port = 443
+    lb_port       = 443
+  }
+  name = "api"
+  tags = {
+    "KubernetesCluster" = "example.com"
+    "Name"              = "api"
+  }
+}
+`,
+		},
+		{
+			name:  "tabs",
+			style: terraformWriter.Indentation{UseTabs: true},
+			expected: "resource \"aws_elb\" \"api\" {\n" +
+				"\tlistener {\n" +
+				"\t\tinstance_port = 443\n" +
+				"\t\tlb_port       = 443\n" +
+				"\t}\n" +
+				"\tname = \"api\"\n" +
+				"\ttags = {\n" +
+				"\t\t\"KubernetesCluster\" = \"example.com\"\n" +
+				"\t\t\"Name\"              = \"api\"\n" +
+				"\t}\n" +
+				"}\n",
+		},
+		{
+			name:  "four spaces",
+			style: terraformWriter.Indentation{Width: 4},
+			expected: `resource "aws_elb" "api" {
+    listener {
+        instance_port = 443
+        lb_port       = 443
+    }
+    name = "api"
+    tags = {
+        "KubernetesCluster" = "example.com"
+        "Name"              = "api"
+    }
+}
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			toElement(r).Write(buf, 0, `resource "aws_elb" "api"`)
+			actual := string(reindent(buf.Bytes(), tc.style))
+			if actual != tc.expected {
+				diffString := diff.FormatDiff(tc.expected, actual)
+				t.Logf("diff:\n%s\n", diffString)
+				t.Errorf("unexpected output for %s indentation", tc.name)
+			}
+		})
+	}
+}
+
+func TestWriteCountedResource(t *testing.T) {
+	type eip struct {
+		Instance *terraformWriter.Literal `cty:"instance"`
+		Tags     map[string]string        `cty:"tags"`
+	}
+
+	target := &TerraformTarget{}
+	target.InitTerraformWriter()
+	err := target.RenderCountedResource("aws_eip", "nodes.example.com", terraformWriter.LiteralFromIntValue(3), &eip{
+		Instance: terraformWriter.LiteralIndexedProperty("aws_instance", "nodes.example.com", terraformWriter.LiteralCountIndex(), "id"),
+		Tags:     map[string]string{"Name": "nodes.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resourcesByType, err := target.GetResourcesByType()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	target.writeResources(buf, resourcesByType, target.GetResourceCounts())
+
+	expected := `resource "aws_eip" "nodes-example-com" {
+  count    = 3
+  instance = aws_instance.nodes-example-com[count.index].id
+  tags = {
+    "Name" = "nodes.example.com"
+  }
+}
+
+`
+	actual := buf.String()
+	if actual != expected {
+		diffString := diff.FormatDiff(expected, actual)
+		t.Logf("diff:\n%s\n", diffString)
+		t.Errorf("unexpected output for counted resource")
+	}
+}
+
+func TestTerraformTargetIndentation(t *testing.T) {
+	outDir := t.TempDir()
+	target := NewTerraformTarget(awsup.BuildMockAWSCloud("us-test-1", "a"), "", outDir, &kops.TargetSpec{
+		Terraform: &kops.TerraformSpec{
+			Indentation: &kops.TerraformIndentationSpec{UseTabs: true},
+		},
+	})
+
+	type resource struct {
+		Name string            `cty:"name"`
+		Tags map[string]string `cty:"tags"`
+	}
+	if err := target.RenderResource("aws_elb", "api", &resource{Name: "api", Tags: map[string]string{"Name": "api"}}); err != nil {
+		t.Fatalf("error rendering resource: %v", err)
+	}
+	if err := target.Finish(nil); err != nil {
+		t.Fatalf("error finishing target: %v", err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(outDir, "kubernetes.tf"))
+	if err != nil {
+		t.Fatalf("error reading kubernetes.tf: %v", err)
+	}
+	expected := "resource \"aws_elb\" \"api\" {\n" +
+		"\tname = \"api\"\n" +
+		"\ttags = {\n" +
+		"\t\t\"Name\" = \"api\"\n" +
+		"\t}\n" +
+		"}\n"
+	if !strings.Contains(string(b), expected) {
+		t.Errorf("expected tab indented resource in kubernetes.tf, got:\n%s", b)
+	}
+	if strings.Contains(string(b), "\n  ") {
+		t.Errorf("expected no space indentation in kubernetes.tf, got:\n%s", b)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// finishJSON writes the same configuration as finishHCL2, but in terraform's JSON syntax.
+// See https://developer.hashicorp.com/terraform/language/syntax/json
+func (t *TerraformTarget) finishJSON() error {
+	root := map[string]interface{}{}
+
+	if variables := t.GetInputVariables(); len(variables) != 0 {
+		v := make(map[string]interface{}, len(variables))
+		for name, value := range variables {
+			v[name] = map[string]interface{}{"default": jsonLiteral(value)}
+		}
+		root["variable"] = v
+	}
+
+	outputs, err := t.GetOutputs()
+	if err != nil {
+		return err
+	}
+	if len(outputs) != 0 {
+		locals := make(map[string]interface{}, len(outputs))
+		o := make(map[string]interface{}, len(outputs))
+		for name, output := range outputs {
+			value := output.Value
+			if value == nil {
+				value = terraformWriter.LiteralListExpression(output.ValueArray...)
+			}
+			locals[name] = jsonLiteral(value)
+			o[name] = map[string]interface{}{"value": jsonLiteral(value)}
+		}
+		root["locals"] = locals
+		root["output"] = o
+	}
+
+	providers := map[string][]interface{}{}
+	for _, p := range t.providers() {
+		providers[p.Name] = append(providers[p.Name], jsonValue("", mapToElement(p.Body)))
+	}
+	providerBlocks := make(map[string]interface{}, len(providers))
+	for name, blocks := range providers {
+		if len(blocks) == 1 {
+			providerBlocks[name] = blocks[0]
+		} else {
+			providerBlocks[name] = blocks
+		}
+	}
+	root["provider"] = providerBlocks
+
+	resourcesByType, err := t.GetResourcesByType()
+	if err != nil {
+		return err
+	}
+	if len(resourcesByType) != 0 {
+		counts := t.GetResourceCounts()
+		provisioners := t.GetLocalExecProvisioners()
+		resources := make(map[string]interface{}, len(resourcesByType))
+		for resourceType, byName := range resourcesByType {
+			r := make(map[string]interface{}, len(byName))
+			for resourceName, item := range byName {
+				e := toElement(item)
+				if count := counts[resourceType][resourceName]; count != nil {
+					e.(*object).field["count"] = count
+				}
+				resource := jsonValue("", e).(map[string]interface{})
+				// Each provisioner is an object keyed by the provisioner type, as the type is a block label
+				for _, command := range provisioners[resourceType][resourceName] {
+					p, _ := resource["provisioner"].([]interface{})
+					resource["provisioner"] = append(p, map[string]interface{}{
+						"local-exec": map[string]interface{}{"command": jsonLiteral(command)},
+					})
+				}
+				r[resourceName] = resource
+			}
+			resources[resourceType] = r
+		}
+		root["resource"] = resources
+	}
+
+	dataSourcesByType, err := t.GetDataSourcesByType()
+	if err != nil {
+		return err
+	}
+	if len(dataSourcesByType) != 0 {
+		dataSources := make(map[string]interface{}, len(dataSourcesByType))
+		for dataSourceType, byName := range dataSourcesByType {
+			d := make(map[string]interface{}, len(byName))
+			for dataSourceName, item := range byName {
+				d[dataSourceName] = jsonValue("", toElement(item))
+			}
+			dataSources[dataSourceType] = d
+		}
+		root["data"] = dataSources
+	}
+
+	if imports := t.GetImports(); len(imports) != 0 {
+		i := make([]interface{}, 0, len(imports))
+		for _, imp := range imports {
+			// The import target is a static resource address, not an expression
+			i = append(i, map[string]interface{}{"id": imp.ID, "to": imp.To.String})
+		}
+		root["import"] = i
+	}
+
+	requiredProviders := make(map[string]interface{})
+	for name, provider := range t.requiredProviders() {
+		requiredProviders[name] = jsonValue("", mapToElement(provider))
+	}
+	root["terraform"] = map[string]interface{}{
+		"required_version":   t.requiredVersion(),
+		"required_providers": requiredProviders,
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", t.Indentation.Prefix(1))
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("error encoding terraform JSON: %w", err)
+	}
+
+	t.Files["kubernetes.tf.json"] = buf.Bytes()
+
+	return nil
+}
+
+// jsonValue converts an element into the value representing it in terraform's JSON syntax.
+// Blocks become objects, repeated blocks become arrays of objects and literals are converted by jsonLiteral.
+func jsonValue(key string, e element) interface{} {
+	switch e := e.(type) {
+	case *object:
+		o := make(map[string]interface{}, len(e.field))
+		for k, v := range e.field {
+			o[k] = jsonValue(k, v)
+		}
+		return o
+	case *sliceObject:
+		members := make([]interface{}, 0, len(e.members))
+		for _, member := range e.members {
+			members = append(members, jsonValue(key, member))
+		}
+		return members
+	case *mapStringLiteral:
+		o := make(map[string]interface{}, len(e.members))
+		for k, v := range e.members {
+			o[k] = jsonLiteral(v)
+		}
+		return o
+	case *terraformWriter.Literal:
+		if key == "ignore_changes" || key == "configuration_aliases" {
+			// These arguments take a list of static references rather than an expression
+			return jsonKeywords(e)
+		}
+		return jsonLiteral(e)
+	default:
+		panic(fmt.Sprintf("unhandled element type %T", e))
+	}
+}
+
+// jsonLiteral converts a literal into its terraform JSON representation.
+// Booleans, numbers, null and quoted strings map onto the corresponding JSON values;
+// any other expression is wrapped in an interpolation sequence, which terraform evaluates
+// to the value of the expression itself.
+func jsonLiteral(l *terraformWriter.Literal) interface{} {
+	s := l.String
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	case "{}":
+		return map[string]interface{}{}
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil && strings.IndexAny(s[:1], "-0123456789") == 0 {
+		return json.Number(s)
+	}
+	if strings.HasPrefix(s, "\"") {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	}
+	return "${" + s + "}"
+}
+
+// jsonKeywords converts a list literal of bare references, e.g. [aws.files], into a list of strings.
+func jsonKeywords(l *terraformWriter.Literal) []string {
+	s := strings.TrimSuffix(strings.TrimPrefix(l.String, "["), "]")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ", ")
+}
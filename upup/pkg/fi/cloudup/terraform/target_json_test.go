@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+func TestJSONLiteral(t *testing.T) {
+	cases := []struct {
+		literal  *terraformWriter.Literal
+		expected interface{}
+	}{
+		{terraformWriter.LiteralTokens("true"), true},
+		{terraformWriter.LiteralFromIntValue(443), json.Number("443")},
+		{terraformWriter.LiteralTokens("null"), nil},
+		{terraformWriter.LiteralFromStringValue("api.example.com"), "api.example.com"},
+		{terraformWriter.LiteralWithIndex("nodes"), "nodes-${count.index}"},
+		{terraformWriter.LiteralProperty("aws_elb", "api.example.com", "id"), "${aws_elb.api-example-com.id}"},
+		{terraformWriter.LiteralTokens("infinity"), "${infinity}"},
+		{
+			terraformWriter.LiteralEmptyStrConditionalExpression(terraformWriter.LiteralVariable("name"), terraformWriter.LiteralFromStringValue("x")),
+			`${var.name == "" ? null : "x"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.literal.String, func(t *testing.T) {
+			actual := jsonLiteral(tc.literal)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestJSONValueStaticReferences(t *testing.T) {
+	lifecycle := toElement(&Lifecycle{
+		IgnoreChanges: []*terraformWriter.Literal{{String: "data"}, {String: "tags"}},
+	})
+	actual := jsonValue("lifecycle", lifecycle)
+	expected := map[string]interface{}{"ignore_changes": []string{"data", "tags"}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %#v, got %#v", expected, actual)
+	}
+}
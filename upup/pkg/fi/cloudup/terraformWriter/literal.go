@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"unicode"
 
 	"golang.org/x/exp/constraints"
 )
@@ -63,6 +64,13 @@ func LiteralFunctionExpression(functionName string, args ...*Literal) *Literal {
 	}
 }
 
+// LiteralNonsensitive constructs a Literal that wraps l in nonsensitive(), so that a value derived from
+// a sensitive one can be used where terraform refuses sensitive values, such as in a non-sensitive output.
+// Terraform rejects nonsensitive() on values that are not sensitive, so only wrap values known to be.
+func LiteralNonsensitive(l *Literal) *Literal {
+	return LiteralFunctionExpression("nonsensitive", l)
+}
+
 func LiteralSelfLink(resourceType, resourceName string) *Literal {
 	return LiteralProperty(resourceType, resourceName, "self_link")
 }
@@ -81,6 +89,39 @@ func LiteralProperty(resourceType, resourceName, prop string) *Literal {
 	}
 }
 
+// LiteralSplatProperty constructs a Literal listing the property of every instance
+// of a resource created with count, e.g. aws_elb.api[*].id
+func LiteralSplatProperty(resourceType, resourceName, prop string) *Literal {
+	tfName := sanitizeName(resourceName)
+	return &Literal{
+		String: resourceType + "." + tfName + "[*]." + prop,
+	}
+}
+
+// LiteralIndexedProperty constructs a Literal referencing the property of a single instance
+// of a resource created with count, e.g. aws_elb.api[count.index].id
+func LiteralIndexedProperty(resourceType, resourceName string, index *Literal, prop string) *Literal {
+	tfName := sanitizeName(resourceName)
+	return &Literal{
+		String: resourceType + "." + tfName + "[" + index.String + "]." + prop,
+	}
+}
+
+// LiteralCountIndex constructs a Literal referencing the index of the current instance
+// of a resource created with count.
+func LiteralCountIndex() *Literal {
+	return &Literal{
+		String: "count.index",
+	}
+}
+
+// LiteralVariable constructs a Literal referencing the input variable with the supplied name.
+func LiteralVariable(name string) *Literal {
+	return &Literal{
+		String: "var." + name,
+	}
+}
+
 func LiteralTokens(tokens ...string) *Literal {
 	return &Literal{
 		String: strings.Join(tokens, "."),
@@ -140,6 +181,34 @@ func LiteralListExpression(args ...*Literal) *Literal {
 	}
 }
 
+// LiteralMapExpression constructs a Literal consisting of a map of the supplied string values, sorted by key.
+func LiteralMapExpression(m map[string]string) *Literal {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteRune('{')
+	for i, k := range keys {
+		if i != 0 {
+			b.WriteRune(',')
+		}
+		b.WriteString(" ")
+		b.WriteString(Quote(k))
+		b.WriteString(" = ")
+		b.WriteString(Quote(m[k]))
+	}
+	if len(keys) != 0 {
+		b.WriteRune(' ')
+	}
+	b.WriteRune('}')
+	return &Literal{
+		String: b.String(),
+	}
+}
+
 // LiteralEmptyStrConditionalExpression constructs a Literal which returns `null`
 // if the supplied "empty" expression is an empty string, otherwise returns "value".
 // It is the caller's responsibility to ensure the supplied parameters do not use operators
@@ -150,6 +219,16 @@ func LiteralEmptyStrConditionalExpression(empty, value *Literal) *Literal {
 	}
 }
 
+// LiteralConditionalExpression constructs a Literal which returns "trueValue"
+// if the supplied condition is true, otherwise returns "falseValue".
+// It is the caller's responsibility to ensure the supplied parameters do not use operators
+// with lower precedence than the conditional operator.
+func LiteralConditionalExpression(condition, trueValue, falseValue *Literal) *Literal {
+	return &Literal{
+		String: fmt.Sprintf("%s ? %s : %s", condition.String, trueValue.String, falseValue.String),
+	}
+}
+
 // SortLiterals sorts a list of Literal, by key.  It does so in-place
 func SortLiterals(v []*Literal) {
 	sort.Slice(v, func(i, j int) bool {
@@ -176,3 +255,43 @@ func dedupLiterals(v []*Literal) ([]*Literal, error) {
 
 	return deduped, nil
 }
+
+// Quote returns s as an HCL2 quoted string literal, which parses back to s.
+// Printable characters, including multibyte ones such as emoji, are written as-is since HCL is UTF-8.
+// Control characters are escaped, as are "${" and "%{", which would otherwise start a template sequence.
+func Quote(s string) string {
+	return quote(s, true)
+}
+
+// QuoteTemplate returns s as an HCL2 quoted template, in which "${...}" interpolations are evaluated by terraform.
+// It escapes characters like Quote does, except "${"; "%{" is still escaped, so that s can't contain template directives.
+func QuoteTemplate(s string) string {
+	return quote(s, false)
+}
+
+func quote(s string, escapeInterpolations bool) string {
+	var b strings.Builder
+	b.WriteRune('"')
+	for i, r := range s {
+		switch {
+		case r == '\\' || r == '"':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\u%04X`, r)
+		case (r == '%' || (r == '$' && escapeInterpolations)) && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteRune('"')
+	return b.String()
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraformWriter
+
+import (
+	"testing"
+)
+
+func TestLiteralConditionalExpression(t *testing.T) {
+	cases := []struct {
+		name     string
+		literal  *Literal
+		expected string
+	}{
+		{
+			name:     "count",
+			literal:  LiteralConditionalExpression(LiteralVariable("create_elb"), LiteralFromIntValue(1), LiteralFromIntValue(0)),
+			expected: "var.create_elb ? 1 : 0",
+		},
+		{
+			name: "comparison condition",
+			literal: LiteralConditionalExpression(
+				LiteralBinaryExpression(LiteralVariable("environment"), "==", LiteralFromStringValue("prod")),
+				LiteralFromStringValue("internal"),
+				LiteralFromStringValue("internet-facing"),
+			),
+			expected: `var.environment == "prod" ? "internal" : "internet-facing"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.literal.String != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, tc.literal.String)
+			}
+		})
+	}
+}
+
+func TestLiteralMapExpression(t *testing.T) {
+	cases := []struct {
+		name     string
+		values   map[string]string
+		expected string
+	}{
+		{
+			name:     "empty",
+			values:   map[string]string{},
+			expected: "{}",
+		},
+		{
+			name:     "sorted by key",
+			values:   map[string]string{"team": "infra", "cost-center": "platform"},
+			expected: `{ "cost-center" = "platform", "team" = "infra" }`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := LiteralMapExpression(tc.values).String; actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestQuoteTemplate(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        string
+		expected string
+	}{
+		{
+			name:     "interpolation",
+			s:        `dig +short "${self.dns_name}"`,
+			expected: `"dig +short \"${self.dns_name}\""`,
+		},
+		{
+			name:     "directive",
+			s:        `date +%{Y}`,
+			expected: `"date +%%{Y}"`,
+		},
+		{
+			name:     "control characters",
+			s:        "printf '\a\v\x00'\n",
+			expected: `"printf '\u0007\u000B\u0000'\n"`,
+		},
+		{
+			name:     "unicode",
+			s:        "echo héllo",
+			expected: `"echo héllo"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := QuoteTemplate(tc.s); actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"path"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/klog/v2"
 )
@@ -36,12 +38,128 @@ type TerraformWriter struct {
 	resources []*terraformResource
 	// outputs is a list of our TF output variables
 	outputs map[string]*terraformOutputVariable
+	// inputs is a map of our TF input variables to their default values
+	inputs map[string]*Literal
 
 	// Providers is a list of TF Providers we need for writing files
 	Providers map[string]*TerraformProvider
 
 	// Files is a map of TF resource Files that should be created
 	Files map[string][]byte
+
+	// Indentation controls how nested blocks are indented in the generated terraform.
+	Indentation Indentation
+
+	// Syntax selects the syntax of the generated terraform.
+	Syntax Syntax
+
+	// LoadBalancerIdleTimeoutVariables makes load balancer idle timeouts reference terraform input variables,
+	// declared with the configured timeout as their default, so they can be overridden without regenerating.
+	LoadBalancerIdleTimeoutVariables bool
+
+	// LoadBalancerTimeouts overrides the terraform create and delete timeouts of load balancers.
+	// If empty, no timeouts block is written and the provider defaults apply.
+	LoadBalancerTimeouts Timeouts
+
+	// CommonTagsVariable is the name of a terraform input variable holding a map of tags,
+	// which is merged into the tags of load balancers. The variable defaults to an empty map.
+	// If empty, the tags are rendered as a static map.
+	CommonTagsVariable string
+
+	// imports is a list of existing cloud resources that should be adopted into the terraform state.
+	// Import blocks require terraform 1.5+.
+	imports []*terraformImport
+
+	// LocalExecProvisioners allows resources to be written with local-exec provisioner blocks.
+	// Provisioners run arbitrary commands on the machine applying the configuration,
+	// so AddLocalExecProvisioner refuses to add them unless this is set.
+	LocalExecProvisioners bool
+
+	// LoadBalancerLocalExecCommand is run by a local-exec provisioner once each load balancer is created,
+	// for example to warm its DNS name, which the command can reference as ${self.dns_name}.
+	// It requires LocalExecProvisioners. If empty, no provisioner is written.
+	LoadBalancerLocalExecCommand string
+	// provisioners is a list of the local-exec provisioners of resources
+	provisioners []*terraformProvisioner
+
+	// ACMCertificateDomains maps the ARNs of ACM certificates that are managed outside kOps to their domain name.
+	// Load balancer listeners using one of these certificates reference an aws_acm_certificate data source
+	// instead of embedding the ARN. The data source cannot look up a certificate by ARN, so it looks up
+	// the most recent issued certificate for the domain; the mapped ARN should be that certificate.
+	ACMCertificateDomains map[string]string
+}
+
+// Indentation is the indentation style of the generated terraform.
+// The zero value is the default two-space style.
+type Indentation struct {
+	// UseTabs indents each level with a tab instead of spaces.
+	UseTabs bool
+	// Width is the number of spaces per level when UseTabs is not set.
+	// Default: 2
+	Width int
+}
+
+// Prefix returns the indentation for the given nesting level.
+func (i Indentation) Prefix(level int) string {
+	if i.UseTabs {
+		return strings.Repeat("\t", level)
+	}
+	width := i.Width
+	if width <= 0 {
+		width = 2
+	}
+	return strings.Repeat(" ", level*width)
+}
+
+// IsDefault returns true if this is the default two-space style.
+func (i Indentation) IsDefault() bool {
+	return !i.UseTabs && (i.Width <= 0 || i.Width == 2)
+}
+
+// Timeouts are the timeouts of terraform operations on a resource, as durations such as "30m".
+// An empty value keeps the provider default for that operation.
+type Timeouts struct {
+	Create string
+	Delete string
+}
+
+// IsEmpty returns true if no timeout is overridden.
+func (t Timeouts) IsEmpty() bool {
+	return t.Create == "" && t.Delete == ""
+}
+
+// Validate checks that the timeouts are valid durations.
+func (t Timeouts) Validate() error {
+	if t.Create != "" {
+		if _, err := time.ParseDuration(t.Create); err != nil {
+			return fmt.Errorf("invalid create timeout %q: %w", t.Create, err)
+		}
+	}
+	if t.Delete != "" {
+		if _, err := time.ParseDuration(t.Delete); err != nil {
+			return fmt.Errorf("invalid delete timeout %q: %w", t.Delete, err)
+		}
+	}
+	return nil
+}
+
+// Syntax is the syntax the terraform configuration is written in.
+type Syntax string
+
+const (
+	// SyntaxHCL writes the configuration in terraform's native syntax, to kubernetes.tf.
+	// This is the default.
+	SyntaxHCL Syntax = ""
+	// SyntaxJSON writes the configuration in terraform's JSON syntax, to kubernetes.tf.json.
+	SyntaxJSON Syntax = "json"
+)
+
+// Import is an existing cloud resource that is imported into a terraform resource.
+type Import struct {
+	// To is the address of the terraform resource.
+	To *Literal
+	// ID is the cloud identifier of the existing resource.
+	ID string
 }
 
 type OutputValue struct {
@@ -59,6 +177,20 @@ type terraformResource struct {
 	ResourceType string
 	ResourceName string
 	Item         interface{}
+	// Count is the number of instances of the resource to create; nil for a single instance.
+	Count *Literal
+}
+
+type terraformImport struct {
+	ResourceType string
+	ResourceName string
+	ID           string
+}
+
+type terraformProvisioner struct {
+	ResourceType string
+	ResourceName string
+	Command      string
 }
 
 type terraformOutputVariable struct {
@@ -79,6 +211,7 @@ func sanitizeName(name string) string {
 func (t *TerraformWriter) InitTerraformWriter() {
 	t.Files = make(map[string][]byte)
 	t.outputs = make(map[string]*terraformOutputVariable)
+	t.inputs = make(map[string]*Literal)
 }
 
 func (t *TerraformWriter) AddFileBytes(resourceType string, resourceName string, key string, data []byte, base64 bool) (*Literal, error) {
@@ -150,6 +283,27 @@ func (t *TerraformWriter) RenderDataSource(dataType string, dataName string, e i
 	return nil
 }
 
+// RenderSharedDataSource renders a data source that several resources may reference.
+// Unlike RenderDataSource, rendering the same data source again is not an error; only the first one is kept.
+func (t *TerraformWriter) RenderSharedDataSource(dataType string, dataName string, e interface{}) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, data := range t.dataSources {
+		if data.DataType == dataType && data.DataName == dataName {
+			return nil
+		}
+	}
+
+	t.dataSources = append(t.dataSources, &terraformDataSource{
+		DataType: dataType,
+		DataName: dataName,
+		Item:     e,
+	})
+
+	return nil
+}
+
 func (t *TerraformWriter) RenderResource(resourceType string, resourceName string, e interface{}) error {
 	res := &terraformResource{
 		ResourceType: resourceType,
@@ -165,6 +319,25 @@ func (t *TerraformWriter) RenderResource(resourceType string, resourceName strin
 	return nil
 }
 
+// RenderCountedResource renders a resource with a count meta-argument, creating the supplied number of instances.
+// Properties of the resource can reference the current instance with LiteralCountIndex,
+// and other resources reference a single instance with LiteralIndexedProperty.
+func (t *TerraformWriter) RenderCountedResource(resourceType string, resourceName string, count *Literal, e interface{}) error {
+	res := &terraformResource{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Item:         e,
+		Count:        count,
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.resources = append(t.resources, res)
+
+	return nil
+}
+
 func (t *TerraformWriter) AddOutputVariable(key string, literal *Literal) error {
 	v := &terraformOutputVariable{
 		Key:   key,
@@ -201,6 +374,100 @@ func (t *TerraformWriter) AddOutputVariableArray(key string, literal *Literal) e
 	return nil
 }
 
+// AddImport records that the existing cloud resource with the supplied ID should be imported into the named resource.
+func (t *TerraformWriter) AddImport(resourceType string, resourceName string, id string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for _, i := range t.imports {
+		if i.ResourceType == resourceType && sanitizeName(i.ResourceName) == sanitizeName(resourceName) {
+			return fmt.Errorf("duplicate import: %s.%s", resourceType, sanitizeName(resourceName))
+		}
+	}
+	t.imports = append(t.imports, &terraformImport{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		ID:           id,
+	})
+
+	return nil
+}
+
+// GetImports returns the recorded imports, sorted by resource address.
+func (t *TerraformWriter) GetImports() []Import {
+	var imports []Import
+	for _, i := range t.imports {
+		imports = append(imports, Import{
+			To: LiteralTokens(i.ResourceType, sanitizeName(i.ResourceName)),
+			ID: i.ID,
+		})
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].To.String < imports[j].To.String
+	})
+	return imports
+}
+
+// AddLocalExecProvisioner adds a local-exec provisioner running the command to the named resource.
+// The command is a terraform string template, so it can reference attributes of the resource as ${self.<attribute>}.
+func (t *TerraformWriter) AddLocalExecProvisioner(resourceType string, resourceName string, command string) error {
+	if !t.LocalExecProvisioners {
+		return fmt.Errorf("cannot add local-exec provisioner to %s.%s: local-exec provisioners are not enabled", resourceType, sanitizeName(resourceName))
+	}
+	if command == "" {
+		return fmt.Errorf("cannot add local-exec provisioner to %s.%s: command is empty", resourceType, sanitizeName(resourceName))
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.provisioners = append(t.provisioners, &terraformProvisioner{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Command:      command,
+	})
+
+	return nil
+}
+
+// GetLocalExecProvisioners returns the commands of the local-exec provisioners of every resource, in the order they were added,
+// keyed by resource type and then by sanitized resource name.
+func (t *TerraformWriter) GetLocalExecProvisioners() map[string]map[string][]*Literal {
+	provisioners := make(map[string]map[string][]*Literal)
+
+	for _, p := range t.provisioners {
+		if provisioners[p.ResourceType] == nil {
+			provisioners[p.ResourceType] = make(map[string][]*Literal)
+		}
+		name := sanitizeName(p.ResourceName)
+		provisioners[p.ResourceType][name] = append(provisioners[p.ResourceType][name], &Literal{String: QuoteTemplate(p.Command)})
+	}
+
+	return provisioners
+}
+
+// AddInputVariable declares an input variable with the supplied default value.
+// Declaring the same variable more than once is allowed, as long as the default values match.
+func (t *TerraformWriter) AddInputVariable(name string, defaultValue *Literal) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if existing, found := t.inputs[name]; found {
+		if existing.String != defaultValue.String {
+			return fmt.Errorf("variable %q declared with different default values %s and %s", name, existing.String, defaultValue.String)
+		}
+		return nil
+	}
+	t.inputs[name] = defaultValue
+
+	return nil
+}
+
+// GetInputVariables returns the declared input variables and their default values.
+func (t *TerraformWriter) GetInputVariables() map[string]*Literal {
+	return t.inputs
+}
+
 func (t *TerraformWriter) GetDataSourcesByType() (map[string]map[string]interface{}, error) {
 	dataSourcesByType := make(map[string]map[string]interface{})
 
@@ -245,6 +512,24 @@ func (t *TerraformWriter) GetResourcesByType() (map[string]map[string]interface{
 	return resourcesByType, nil
 }
 
+// GetResourceCounts returns the count of every resource rendered with RenderCountedResource,
+// keyed by resource type and then by sanitized resource name.
+func (t *TerraformWriter) GetResourceCounts() map[string]map[string]*Literal {
+	counts := make(map[string]map[string]*Literal)
+
+	for _, res := range t.resources {
+		if res.Count == nil {
+			continue
+		}
+		if counts[res.ResourceType] == nil {
+			counts[res.ResourceType] = make(map[string]*Literal)
+		}
+		counts[res.ResourceType][sanitizeName(res.ResourceName)] = res.Count
+	}
+
+	return counts
+}
+
 func (t *TerraformWriter) GetOutputs() (map[string]OutputValue, error) {
 	values := map[string]OutputValue{}
 	for _, v := range t.outputs {
@@ -282,6 +282,13 @@ func NewExistsAndWarnIfChangesError(message string) *ExistsAndWarnIfChangesError
 // ExistsAndWarnIfChangesError implementation of the error interface.
 func (e *ExistsAndWarnIfChangesError) Error() string { return e.msg }
 
+// RetryableError is implemented by errors that know whether running the task again can succeed.
+// The executor stops retrying a task that fails with an error that is not retryable.
+type RetryableError interface {
+	error
+	IsRetryable() bool
+}
+
 // TryAgainLaterError is the custom used when a task needs to fail validation with a message and try again later
 type TryAgainLaterError struct {
 	msg   string
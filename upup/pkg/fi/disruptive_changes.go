@@ -0,0 +1,25 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+// HasDisruptiveChanges is implemented by tasks that know which of their field changes interrupt traffic
+// (for example because the resource has to be replaced), and which are applied in place.
+// The dry-run report labels each modified field of such tasks accordingly.
+type HasDisruptiveChanges interface {
+	// IsDisruptiveChange returns true if changing the named field is disruptive.
+	IsDisruptiveChange(fieldName string) bool
+}
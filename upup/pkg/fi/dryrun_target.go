@@ -239,9 +239,9 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 				for _, change := range changeList {
 					lines := strings.Split(change.Description, "\n")
 					if len(lines) == 1 {
-						fmt.Fprintf(b, "  \t%-20s\t%s\n", change.FieldName, change.Description)
+						fmt.Fprintf(b, "  \t%-20s\t%s%s\n", change.FieldName, change.Description, change.Disruption)
 					} else {
-						fmt.Fprintf(b, "  \t%-20s\n", change.FieldName)
+						fmt.Fprintf(b, "  \t%-20s%s\n", change.FieldName, change.Disruption)
 						for _, line := range lines {
 							fmt.Fprintf(b, "  \t%-20s\t%s\n", "", line)
 						}
@@ -306,6 +306,8 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 type change struct {
 	FieldName   string
 	Description string
+	// Disruption labels the change as disruptive or not, for tasks implementing HasDisruptiveChanges.
+	Disruption string
 }
 
 func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
@@ -374,7 +376,16 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 			if ignored {
 				continue
 			}
-			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description})
+			fieldName := valC.Type().Field(i).Name
+			disruption := ""
+			if hasDisruptiveChanges, ok := e.(HasDisruptiveChanges); ok {
+				if hasDisruptiveChanges.IsDisruptiveChange(fieldName) {
+					disruption = " (disruptive)"
+				} else {
+					disruption = " (non-disruptive)"
+				}
+			}
+			changeList = append(changeList, change{FieldName: fieldName, Description: description, Disruption: disruption})
 		}
 	} else {
 		return nil, fmt.Errorf("unhandled change type: %v", valC.Type())
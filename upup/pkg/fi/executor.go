@@ -134,6 +134,11 @@ func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T])
 					continue
 				}
 
+				var retryableError RetryableError
+				if errors.As(err, &retryableError) && !retryableError.IsRetryable() {
+					return fmt.Errorf("error running task %q: %w", ts.key, err)
+				}
+
 				remaining := time.Second * time.Duration(int(time.Until(ts.deadline).Seconds()))
 				if _, ok := err.(*TryAgainLaterError); ok {
 					klog.V(2).Infof("Task %q not ready: %v", ts.key, err)